@@ -0,0 +1,105 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.17.3
+// source: signer.proto
+
+package trxv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SignerServiceClient is the client API for SignerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SignerServiceClient interface {
+	SignTransaction(ctx context.Context, in *SignTransactionRequest, opts ...grpc.CallOption) (*SignTransactionReply, error)
+}
+
+type signerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSignerServiceClient(cc grpc.ClientConnInterface) SignerServiceClient {
+	return &signerServiceClient{cc}
+}
+
+func (c *signerServiceClient) SignTransaction(ctx context.Context, in *SignTransactionRequest, opts ...grpc.CallOption) (*SignTransactionReply, error) {
+	out := new(SignTransactionReply)
+	err := c.cc.Invoke(ctx, "/trxv1.SignerService/SignTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SignerServiceServer is the server API for SignerService service.
+// All implementations must embed UnimplementedSignerServiceServer
+// for forward compatibility
+type SignerServiceServer interface {
+	SignTransaction(context.Context, *SignTransactionRequest) (*SignTransactionReply, error)
+	mustEmbedUnimplementedSignerServiceServer()
+}
+
+// UnimplementedSignerServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSignerServiceServer struct {
+}
+
+func (UnimplementedSignerServiceServer) SignTransaction(context.Context, *SignTransactionRequest) (*SignTransactionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignTransaction not implemented")
+}
+func (UnimplementedSignerServiceServer) mustEmbedUnimplementedSignerServiceServer() {}
+
+// UnsafeSignerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SignerServiceServer will
+// result in compilation errors.
+type UnsafeSignerServiceServer interface {
+	mustEmbedUnimplementedSignerServiceServer()
+}
+
+func RegisterSignerServiceServer(s grpc.ServiceRegistrar, srv SignerServiceServer) {
+	s.RegisterService(&SignerService_ServiceDesc, srv)
+}
+
+func _SignerService_SignTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServiceServer).SignTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.SignerService/SignTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServiceServer).SignTransaction(ctx, req.(*SignTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SignerService_ServiceDesc is the grpc.ServiceDesc for SignerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SignerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "trxv1.SignerService",
+	HandlerType: (*SignerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SignTransaction",
+			Handler:    _SignerService_SignTransaction_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "signer.proto",
+}
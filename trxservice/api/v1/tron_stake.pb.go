@@ -0,0 +1,762 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.17.3
+// source: tron_stake.proto
+
+// Stake 2.0 合约及账户字段, 字段编号与 java-tron 的 protocol 定义保持一致.
+// gotron-sdk 尚未包含这些类型, 交易通过 CreateCommonTransaction 构建.
+// 放在本仓库的 package 下, 以免 gotron-sdk 升级后与其 protocol 类型重复注册;
+// 写入 Any 时按 protocol.<消息名> 的 type_url 转换.
+
+package trxv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FreezeBalanceV2Contract struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OwnerAddress  []byte `protobuf:"bytes,1,opt,name=owner_address,json=ownerAddress,proto3" json:"owner_address,omitempty"`
+	FrozenBalance int64  `protobuf:"varint,2,opt,name=frozen_balance,json=frozenBalance,proto3" json:"frozen_balance,omitempty"`
+	Resource      int32  `protobuf:"varint,3,opt,name=resource,proto3" json:"resource,omitempty"`
+}
+
+func (x *FreezeBalanceV2Contract) Reset() {
+	*x = FreezeBalanceV2Contract{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tron_stake_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FreezeBalanceV2Contract) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FreezeBalanceV2Contract) ProtoMessage() {}
+
+func (x *FreezeBalanceV2Contract) ProtoReflect() protoreflect.Message {
+	mi := &file_tron_stake_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FreezeBalanceV2Contract.ProtoReflect.Descriptor instead.
+func (*FreezeBalanceV2Contract) Descriptor() ([]byte, []int) {
+	return file_tron_stake_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FreezeBalanceV2Contract) GetOwnerAddress() []byte {
+	if x != nil {
+		return x.OwnerAddress
+	}
+	return nil
+}
+
+func (x *FreezeBalanceV2Contract) GetFrozenBalance() int64 {
+	if x != nil {
+		return x.FrozenBalance
+	}
+	return 0
+}
+
+func (x *FreezeBalanceV2Contract) GetResource() int32 {
+	if x != nil {
+		return x.Resource
+	}
+	return 0
+}
+
+type UnfreezeBalanceV2Contract struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OwnerAddress    []byte `protobuf:"bytes,1,opt,name=owner_address,json=ownerAddress,proto3" json:"owner_address,omitempty"`
+	UnfreezeBalance int64  `protobuf:"varint,2,opt,name=unfreeze_balance,json=unfreezeBalance,proto3" json:"unfreeze_balance,omitempty"`
+	Resource        int32  `protobuf:"varint,3,opt,name=resource,proto3" json:"resource,omitempty"`
+}
+
+func (x *UnfreezeBalanceV2Contract) Reset() {
+	*x = UnfreezeBalanceV2Contract{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tron_stake_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnfreezeBalanceV2Contract) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnfreezeBalanceV2Contract) ProtoMessage() {}
+
+func (x *UnfreezeBalanceV2Contract) ProtoReflect() protoreflect.Message {
+	mi := &file_tron_stake_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnfreezeBalanceV2Contract.ProtoReflect.Descriptor instead.
+func (*UnfreezeBalanceV2Contract) Descriptor() ([]byte, []int) {
+	return file_tron_stake_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UnfreezeBalanceV2Contract) GetOwnerAddress() []byte {
+	if x != nil {
+		return x.OwnerAddress
+	}
+	return nil
+}
+
+func (x *UnfreezeBalanceV2Contract) GetUnfreezeBalance() int64 {
+	if x != nil {
+		return x.UnfreezeBalance
+	}
+	return 0
+}
+
+func (x *UnfreezeBalanceV2Contract) GetResource() int32 {
+	if x != nil {
+		return x.Resource
+	}
+	return 0
+}
+
+type WithdrawExpireUnfreezeContract struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OwnerAddress []byte `protobuf:"bytes,1,opt,name=owner_address,json=ownerAddress,proto3" json:"owner_address,omitempty"`
+}
+
+func (x *WithdrawExpireUnfreezeContract) Reset() {
+	*x = WithdrawExpireUnfreezeContract{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tron_stake_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WithdrawExpireUnfreezeContract) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawExpireUnfreezeContract) ProtoMessage() {}
+
+func (x *WithdrawExpireUnfreezeContract) ProtoReflect() protoreflect.Message {
+	mi := &file_tron_stake_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawExpireUnfreezeContract.ProtoReflect.Descriptor instead.
+func (*WithdrawExpireUnfreezeContract) Descriptor() ([]byte, []int) {
+	return file_tron_stake_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WithdrawExpireUnfreezeContract) GetOwnerAddress() []byte {
+	if x != nil {
+		return x.OwnerAddress
+	}
+	return nil
+}
+
+type DelegateResourceContract struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OwnerAddress    []byte `protobuf:"bytes,1,opt,name=owner_address,json=ownerAddress,proto3" json:"owner_address,omitempty"`
+	Resource        int32  `protobuf:"varint,2,opt,name=resource,proto3" json:"resource,omitempty"`
+	Balance         int64  `protobuf:"varint,3,opt,name=balance,proto3" json:"balance,omitempty"`
+	ReceiverAddress []byte `protobuf:"bytes,4,opt,name=receiver_address,json=receiverAddress,proto3" json:"receiver_address,omitempty"`
+	Lock            bool   `protobuf:"varint,5,opt,name=lock,proto3" json:"lock,omitempty"`
+	LockPeriod      int64  `protobuf:"varint,6,opt,name=lock_period,json=lockPeriod,proto3" json:"lock_period,omitempty"`
+}
+
+func (x *DelegateResourceContract) Reset() {
+	*x = DelegateResourceContract{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tron_stake_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DelegateResourceContract) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DelegateResourceContract) ProtoMessage() {}
+
+func (x *DelegateResourceContract) ProtoReflect() protoreflect.Message {
+	mi := &file_tron_stake_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DelegateResourceContract.ProtoReflect.Descriptor instead.
+func (*DelegateResourceContract) Descriptor() ([]byte, []int) {
+	return file_tron_stake_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DelegateResourceContract) GetOwnerAddress() []byte {
+	if x != nil {
+		return x.OwnerAddress
+	}
+	return nil
+}
+
+func (x *DelegateResourceContract) GetResource() int32 {
+	if x != nil {
+		return x.Resource
+	}
+	return 0
+}
+
+func (x *DelegateResourceContract) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *DelegateResourceContract) GetReceiverAddress() []byte {
+	if x != nil {
+		return x.ReceiverAddress
+	}
+	return nil
+}
+
+func (x *DelegateResourceContract) GetLock() bool {
+	if x != nil {
+		return x.Lock
+	}
+	return false
+}
+
+func (x *DelegateResourceContract) GetLockPeriod() int64 {
+	if x != nil {
+		return x.LockPeriod
+	}
+	return 0
+}
+
+type UnDelegateResourceContract struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OwnerAddress    []byte `protobuf:"bytes,1,opt,name=owner_address,json=ownerAddress,proto3" json:"owner_address,omitempty"`
+	Resource        int32  `protobuf:"varint,2,opt,name=resource,proto3" json:"resource,omitempty"`
+	Balance         int64  `protobuf:"varint,3,opt,name=balance,proto3" json:"balance,omitempty"`
+	ReceiverAddress []byte `protobuf:"bytes,4,opt,name=receiver_address,json=receiverAddress,proto3" json:"receiver_address,omitempty"`
+}
+
+func (x *UnDelegateResourceContract) Reset() {
+	*x = UnDelegateResourceContract{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tron_stake_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnDelegateResourceContract) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnDelegateResourceContract) ProtoMessage() {}
+
+func (x *UnDelegateResourceContract) ProtoReflect() protoreflect.Message {
+	mi := &file_tron_stake_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnDelegateResourceContract.ProtoReflect.Descriptor instead.
+func (*UnDelegateResourceContract) Descriptor() ([]byte, []int) {
+	return file_tron_stake_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UnDelegateResourceContract) GetOwnerAddress() []byte {
+	if x != nil {
+		return x.OwnerAddress
+	}
+	return nil
+}
+
+func (x *UnDelegateResourceContract) GetResource() int32 {
+	if x != nil {
+		return x.Resource
+	}
+	return 0
+}
+
+func (x *UnDelegateResourceContract) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *UnDelegateResourceContract) GetReceiverAddress() []byte {
+	if x != nil {
+		return x.ReceiverAddress
+	}
+	return nil
+}
+
+// AccountStakeV2 只包含 protocol.Account 中 Stake 2.0 新增的字段,
+// 用于解析 gotron-sdk 的 Account 无法识别的部分
+type AccountStakeV2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FrozenV2   []*AccountStakeV2_FreezeV2   `protobuf:"bytes,34,rep,name=frozenV2,proto3" json:"frozenV2,omitempty"`
+	UnfrozenV2 []*AccountStakeV2_UnFreezeV2 `protobuf:"bytes,35,rep,name=unfrozenV2,proto3" json:"unfrozenV2,omitempty"`
+}
+
+func (x *AccountStakeV2) Reset() {
+	*x = AccountStakeV2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tron_stake_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AccountStakeV2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountStakeV2) ProtoMessage() {}
+
+func (x *AccountStakeV2) ProtoReflect() protoreflect.Message {
+	mi := &file_tron_stake_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountStakeV2.ProtoReflect.Descriptor instead.
+func (*AccountStakeV2) Descriptor() ([]byte, []int) {
+	return file_tron_stake_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AccountStakeV2) GetFrozenV2() []*AccountStakeV2_FreezeV2 {
+	if x != nil {
+		return x.FrozenV2
+	}
+	return nil
+}
+
+func (x *AccountStakeV2) GetUnfrozenV2() []*AccountStakeV2_UnFreezeV2 {
+	if x != nil {
+		return x.UnfrozenV2
+	}
+	return nil
+}
+
+type AccountStakeV2_FreezeV2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type   int32 `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	Amount int64 `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (x *AccountStakeV2_FreezeV2) Reset() {
+	*x = AccountStakeV2_FreezeV2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tron_stake_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AccountStakeV2_FreezeV2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountStakeV2_FreezeV2) ProtoMessage() {}
+
+func (x *AccountStakeV2_FreezeV2) ProtoReflect() protoreflect.Message {
+	mi := &file_tron_stake_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountStakeV2_FreezeV2.ProtoReflect.Descriptor instead.
+func (*AccountStakeV2_FreezeV2) Descriptor() ([]byte, []int) {
+	return file_tron_stake_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *AccountStakeV2_FreezeV2) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *AccountStakeV2_FreezeV2) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type AccountStakeV2_UnFreezeV2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type               int32 `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	UnfreezeAmount     int64 `protobuf:"varint,3,opt,name=unfreeze_amount,json=unfreezeAmount,proto3" json:"unfreeze_amount,omitempty"`
+	UnfreezeExpireTime int64 `protobuf:"varint,4,opt,name=unfreeze_expire_time,json=unfreezeExpireTime,proto3" json:"unfreeze_expire_time,omitempty"`
+}
+
+func (x *AccountStakeV2_UnFreezeV2) Reset() {
+	*x = AccountStakeV2_UnFreezeV2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tron_stake_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AccountStakeV2_UnFreezeV2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountStakeV2_UnFreezeV2) ProtoMessage() {}
+
+func (x *AccountStakeV2_UnFreezeV2) ProtoReflect() protoreflect.Message {
+	mi := &file_tron_stake_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountStakeV2_UnFreezeV2.ProtoReflect.Descriptor instead.
+func (*AccountStakeV2_UnFreezeV2) Descriptor() ([]byte, []int) {
+	return file_tron_stake_proto_rawDescGZIP(), []int{5, 1}
+}
+
+func (x *AccountStakeV2_UnFreezeV2) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *AccountStakeV2_UnFreezeV2) GetUnfreezeAmount() int64 {
+	if x != nil {
+		return x.UnfreezeAmount
+	}
+	return 0
+}
+
+func (x *AccountStakeV2_UnFreezeV2) GetUnfreezeExpireTime() int64 {
+	if x != nil {
+		return x.UnfreezeExpireTime
+	}
+	return 0
+}
+
+var File_tron_stake_proto protoreflect.FileDescriptor
+
+var file_tron_stake_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x74, 0x72, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x05, 0x74, 0x72, 0x78, 0x76, 0x31, 0x22, 0x81, 0x01, 0x0a, 0x17, 0x46, 0x72,
+	0x65, 0x65, 0x7a, 0x65, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x56, 0x32, 0x43, 0x6f, 0x6e,
+	0x74, 0x72, 0x61, 0x63, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x6f, 0x77,
+	0x6e, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x66, 0x72,
+	0x6f, 0x7a, 0x65, 0x6e, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0d, 0x66, 0x72, 0x6f, 0x7a, 0x65, 0x6e, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x87, 0x01,
+	0x0a, 0x19, 0x55, 0x6e, 0x66, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x56, 0x32, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x6f,
+	0x77, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0c, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x29, 0x0a, 0x10, 0x75, 0x6e, 0x66, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x5f, 0x62, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x75, 0x6e, 0x66, 0x72,
+	0x65, 0x65, 0x7a, 0x65, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x45, 0x0a, 0x1e, 0x57, 0x69, 0x74, 0x68, 0x64,
+	0x72, 0x61, 0x77, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x55, 0x6e, 0x66, 0x72, 0x65, 0x65, 0x7a,
+	0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0c, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0xd5,
+	0x01, 0x0a, 0x18, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x6f,
+	0x77, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0c, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x62,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x70, 0x65,
+	0x72, 0x69, 0x6f, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6c, 0x6f, 0x63, 0x6b,
+	0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x22, 0xa2, 0x01, 0x0a, 0x1a, 0x55, 0x6e, 0x44, 0x65, 0x6c,
+	0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x6f, 0x6e,
+	0x74, 0x72, 0x61, 0x63, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x6f, 0x77,
+	0x6e, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0xc3, 0x02, 0x0a, 0x0e,
+	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x56, 0x32, 0x12, 0x3a,
+	0x0a, 0x08, 0x66, 0x72, 0x6f, 0x7a, 0x65, 0x6e, 0x56, 0x32, 0x18, 0x22, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x74, 0x72, 0x78, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x53, 0x74, 0x61, 0x6b, 0x65, 0x56, 0x32, 0x2e, 0x46, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x56, 0x32,
+	0x52, 0x08, 0x66, 0x72, 0x6f, 0x7a, 0x65, 0x6e, 0x56, 0x32, 0x12, 0x40, 0x0a, 0x0a, 0x75, 0x6e,
+	0x66, 0x72, 0x6f, 0x7a, 0x65, 0x6e, 0x56, 0x32, 0x18, 0x23, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x74, 0x72, 0x78, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x74,
+	0x61, 0x6b, 0x65, 0x56, 0x32, 0x2e, 0x55, 0x6e, 0x46, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x56, 0x32,
+	0x52, 0x0a, 0x75, 0x6e, 0x66, 0x72, 0x6f, 0x7a, 0x65, 0x6e, 0x56, 0x32, 0x1a, 0x36, 0x0a, 0x08,
+	0x46, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x56, 0x32, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x1a, 0x7b, 0x0a, 0x0a, 0x55, 0x6e, 0x46, 0x72, 0x65, 0x65, 0x7a, 0x65,
+	0x56, 0x32, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x75, 0x6e, 0x66, 0x72, 0x65, 0x65,
+	0x7a, 0x65, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0e, 0x75, 0x6e, 0x66, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x30, 0x0a, 0x14, 0x75, 0x6e, 0x66, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x5f, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x75,
+	0x6e, 0x66, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x54, 0x69, 0x6d,
+	0x65, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x3b, 0x74, 0x72, 0x78, 0x76, 0x31, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_tron_stake_proto_rawDescOnce sync.Once
+	file_tron_stake_proto_rawDescData = file_tron_stake_proto_rawDesc
+)
+
+func file_tron_stake_proto_rawDescGZIP() []byte {
+	file_tron_stake_proto_rawDescOnce.Do(func() {
+		file_tron_stake_proto_rawDescData = protoimpl.X.CompressGZIP(file_tron_stake_proto_rawDescData)
+	})
+	return file_tron_stake_proto_rawDescData
+}
+
+var file_tron_stake_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_tron_stake_proto_goTypes = []interface{}{
+	(*FreezeBalanceV2Contract)(nil),        // 0: trxv1.FreezeBalanceV2Contract
+	(*UnfreezeBalanceV2Contract)(nil),      // 1: trxv1.UnfreezeBalanceV2Contract
+	(*WithdrawExpireUnfreezeContract)(nil), // 2: trxv1.WithdrawExpireUnfreezeContract
+	(*DelegateResourceContract)(nil),       // 3: trxv1.DelegateResourceContract
+	(*UnDelegateResourceContract)(nil),     // 4: trxv1.UnDelegateResourceContract
+	(*AccountStakeV2)(nil),                 // 5: trxv1.AccountStakeV2
+	(*AccountStakeV2_FreezeV2)(nil),        // 6: trxv1.AccountStakeV2.FreezeV2
+	(*AccountStakeV2_UnFreezeV2)(nil),      // 7: trxv1.AccountStakeV2.UnFreezeV2
+}
+var file_tron_stake_proto_depIdxs = []int32{
+	6, // 0: trxv1.AccountStakeV2.frozenV2:type_name -> trxv1.AccountStakeV2.FreezeV2
+	7, // 1: trxv1.AccountStakeV2.unfrozenV2:type_name -> trxv1.AccountStakeV2.UnFreezeV2
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_tron_stake_proto_init() }
+func file_tron_stake_proto_init() {
+	if File_tron_stake_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_tron_stake_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FreezeBalanceV2Contract); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tron_stake_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnfreezeBalanceV2Contract); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tron_stake_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WithdrawExpireUnfreezeContract); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tron_stake_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DelegateResourceContract); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tron_stake_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnDelegateResourceContract); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tron_stake_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AccountStakeV2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tron_stake_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AccountStakeV2_FreezeV2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tron_stake_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AccountStakeV2_UnFreezeV2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_tron_stake_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_tron_stake_proto_goTypes,
+		DependencyIndexes: file_tron_stake_proto_depIdxs,
+		MessageInfos:      file_tron_stake_proto_msgTypes,
+	}.Build()
+	File_tron_stake_proto = out.File
+	file_tron_stake_proto_rawDesc = nil
+	file_tron_stake_proto_goTypes = nil
+	file_tron_stake_proto_depIdxs = nil
+}
@@ -24,6 +24,51 @@ const _ = grpc.SupportPackageIsVersion7
 type TrxServiceClient interface {
 	GetTrxBalance(ctx context.Context, in *GetTrxBalanceRequest, opts ...grpc.CallOption) (*GetTrxBalanceReply, error)
 	GetTRC20TokenBalance(ctx context.Context, in *GetTRC20TokenBalanceRequest, opts ...grpc.CallOption) (*GetTRC20TokenBalanceReply, error)
+	// 构建、签名并广播 TRX 转账交易
+	TransferTrx(ctx context.Context, in *TransferTrxRequest, opts ...grpc.CallOption) (*TransferTrxReply, error)
+	// 构建、签名并广播 TRC20 代币转账交易
+	TransferTRC20(ctx context.Context, in *TransferTRC20Request, opts ...grpc.CallOption) (*TransferTRC20Reply, error)
+	// 构建未签名的 TRX 或 TRC20 转账, 导出给离线机器签名
+	ExportTransaction(ctx context.Context, in *ExportTransactionRequest, opts ...grpc.CallOption) (*ExportTransactionReply, error)
+	// 导入离线签名后的交易, 校验签名属于转出地址后广播
+	ImportTransaction(ctx context.Context, in *ImportTransactionRequest, opts ...grpc.CallOption) (*ImportTransactionReply, error)
+	// 预估 TRX 或 TRC20 转账消耗的能量、带宽及需燃烧的 TRX
+	EstimateFee(ctx context.Context, in *EstimateFeeRequest, opts ...grpc.CallOption) (*EstimateFeeReply, error)
+	// 质押 TRX 获取能量或带宽 (Stake 2.0)
+	FreezeBalanceV2(ctx context.Context, in *FreezeBalanceV2Request, opts ...grpc.CallOption) (*StakeReply, error)
+	// 解除质押, 等待期满后通过 WithdrawExpireUnfreeze 提取
+	UnfreezeBalanceV2(ctx context.Context, in *UnfreezeBalanceV2Request, opts ...grpc.CallOption) (*StakeReply, error)
+	// 提取已到期的解除质押 TRX
+	WithdrawExpireUnfreeze(ctx context.Context, in *WithdrawExpireUnfreezeRequest, opts ...grpc.CallOption) (*StakeReply, error)
+	// 将质押获得的能量或带宽代理给其他地址, 例如热钱包
+	DelegateResource(ctx context.Context, in *DelegateResourceRequest, opts ...grpc.CallOption) (*StakeReply, error)
+	// 收回代理出去的能量或带宽
+	UnDelegateResource(ctx context.Context, in *UnDelegateResourceRequest, opts ...grpc.CallOption) (*StakeReply, error)
+	// 查询地址的资源上限、使用量、质押及代理情况
+	GetAccountResources(ctx context.Context, in *GetAccountResourcesRequest, opts ...grpc.CallOption) (*GetAccountResourcesReply, error)
+	// 提交批量付款, 按 client_reference 幂等: 重复提交同一 client_reference 不会重复付款
+	SubmitPayoutBatch(ctx context.Context, in *SubmitPayoutBatchRequest, opts ...grpc.CallOption) (*PayoutBatchReply, error)
+	// 查询批量付款中每一笔的状态
+	GetPayoutBatch(ctx context.Context, in *GetPayoutBatchRequest, opts ...grpc.CallOption) (*PayoutBatchReply, error)
+	// 立即开始一次归集, 上一次归集未完成时失败
+	StartSweep(ctx context.Context, in *StartSweepRequest, opts ...grpc.CallOption) (*SweepRunReply, error)
+	// 查询归集的进度和每个地址每种代币的归集步骤
+	GetSweepRun(ctx context.Context, in *GetSweepRunRequest, opts ...grpc.CallOption) (*SweepRunReply, error)
+	// 创建多签账户的转账, 按 permission_id 的权限收集签名
+	CreateMultisigTransaction(ctx context.Context, in *CreateMultisigTransactionRequest, opts ...grpc.CallOption) (*MultisigTransaction, error)
+	// 提交一个审批人对 txid 的签名, 权重达到阈值后广播
+	SignMultisigTransaction(ctx context.Context, in *SignMultisigTransactionRequest, opts ...grpc.CallOption) (*MultisigTransaction, error)
+	GetMultisigTransaction(ctx context.Context, in *GetMultisigTransactionRequest, opts ...grpc.CallOption) (*MultisigTransaction, error)
+	// 列出待审批的多签交易
+	ListMultisigTransactions(ctx context.Context, in *ListMultisigTransactionsRequest, opts ...grpc.CallOption) (*ListMultisigTransactionsReply, error)
+	// 获取用户的充值地址, 同一 (user_id, account) 重复调用返回同一地址
+	NewDepositAddress(ctx context.Context, in *NewDepositAddressRequest, opts ...grpc.CallOption) (*NewDepositAddressReply, error)
+	// 查询交易记录, 支持页码分页, 大量数据时使用游标分页
+	ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsReply, error)
+	// 订阅地址的转入转出, 交易被发现及每次状态变化时推送; HTTP 网关以 SSE (Accept: text/event-stream) 输出
+	SubscribeTransfers(ctx context.Context, in *SubscribeTransfersRequest, opts ...grpc.CallOption) (TrxService_SubscribeTransfersClient, error)
+	// 管理接口: 重放投递失败(dead)的 webhook
+	ReplayWebhooks(ctx context.Context, in *ReplayWebhooksRequest, opts ...grpc.CallOption) (*ReplayWebhooksReply, error)
 }
 
 type trxServiceClient struct {
@@ -52,12 +97,287 @@ func (c *trxServiceClient) GetTRC20TokenBalance(ctx context.Context, in *GetTRC2
 	return out, nil
 }
 
+func (c *trxServiceClient) TransferTrx(ctx context.Context, in *TransferTrxRequest, opts ...grpc.CallOption) (*TransferTrxReply, error) {
+	out := new(TransferTrxReply)
+	err := c.cc.Invoke(ctx, "/trxv1.TrxService/TransferTrx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trxServiceClient) TransferTRC20(ctx context.Context, in *TransferTRC20Request, opts ...grpc.CallOption) (*TransferTRC20Reply, error) {
+	out := new(TransferTRC20Reply)
+	err := c.cc.Invoke(ctx, "/trxv1.TrxService/TransferTRC20", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trxServiceClient) ExportTransaction(ctx context.Context, in *ExportTransactionRequest, opts ...grpc.CallOption) (*ExportTransactionReply, error) {
+	out := new(ExportTransactionReply)
+	err := c.cc.Invoke(ctx, "/trxv1.TrxService/ExportTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trxServiceClient) ImportTransaction(ctx context.Context, in *ImportTransactionRequest, opts ...grpc.CallOption) (*ImportTransactionReply, error) {
+	out := new(ImportTransactionReply)
+	err := c.cc.Invoke(ctx, "/trxv1.TrxService/ImportTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trxServiceClient) EstimateFee(ctx context.Context, in *EstimateFeeRequest, opts ...grpc.CallOption) (*EstimateFeeReply, error) {
+	out := new(EstimateFeeReply)
+	err := c.cc.Invoke(ctx, "/trxv1.TrxService/EstimateFee", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trxServiceClient) FreezeBalanceV2(ctx context.Context, in *FreezeBalanceV2Request, opts ...grpc.CallOption) (*StakeReply, error) {
+	out := new(StakeReply)
+	err := c.cc.Invoke(ctx, "/trxv1.TrxService/FreezeBalanceV2", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trxServiceClient) UnfreezeBalanceV2(ctx context.Context, in *UnfreezeBalanceV2Request, opts ...grpc.CallOption) (*StakeReply, error) {
+	out := new(StakeReply)
+	err := c.cc.Invoke(ctx, "/trxv1.TrxService/UnfreezeBalanceV2", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trxServiceClient) WithdrawExpireUnfreeze(ctx context.Context, in *WithdrawExpireUnfreezeRequest, opts ...grpc.CallOption) (*StakeReply, error) {
+	out := new(StakeReply)
+	err := c.cc.Invoke(ctx, "/trxv1.TrxService/WithdrawExpireUnfreeze", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trxServiceClient) DelegateResource(ctx context.Context, in *DelegateResourceRequest, opts ...grpc.CallOption) (*StakeReply, error) {
+	out := new(StakeReply)
+	err := c.cc.Invoke(ctx, "/trxv1.TrxService/DelegateResource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trxServiceClient) UnDelegateResource(ctx context.Context, in *UnDelegateResourceRequest, opts ...grpc.CallOption) (*StakeReply, error) {
+	out := new(StakeReply)
+	err := c.cc.Invoke(ctx, "/trxv1.TrxService/UnDelegateResource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trxServiceClient) GetAccountResources(ctx context.Context, in *GetAccountResourcesRequest, opts ...grpc.CallOption) (*GetAccountResourcesReply, error) {
+	out := new(GetAccountResourcesReply)
+	err := c.cc.Invoke(ctx, "/trxv1.TrxService/GetAccountResources", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trxServiceClient) SubmitPayoutBatch(ctx context.Context, in *SubmitPayoutBatchRequest, opts ...grpc.CallOption) (*PayoutBatchReply, error) {
+	out := new(PayoutBatchReply)
+	err := c.cc.Invoke(ctx, "/trxv1.TrxService/SubmitPayoutBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trxServiceClient) GetPayoutBatch(ctx context.Context, in *GetPayoutBatchRequest, opts ...grpc.CallOption) (*PayoutBatchReply, error) {
+	out := new(PayoutBatchReply)
+	err := c.cc.Invoke(ctx, "/trxv1.TrxService/GetPayoutBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trxServiceClient) StartSweep(ctx context.Context, in *StartSweepRequest, opts ...grpc.CallOption) (*SweepRunReply, error) {
+	out := new(SweepRunReply)
+	err := c.cc.Invoke(ctx, "/trxv1.TrxService/StartSweep", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trxServiceClient) GetSweepRun(ctx context.Context, in *GetSweepRunRequest, opts ...grpc.CallOption) (*SweepRunReply, error) {
+	out := new(SweepRunReply)
+	err := c.cc.Invoke(ctx, "/trxv1.TrxService/GetSweepRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trxServiceClient) CreateMultisigTransaction(ctx context.Context, in *CreateMultisigTransactionRequest, opts ...grpc.CallOption) (*MultisigTransaction, error) {
+	out := new(MultisigTransaction)
+	err := c.cc.Invoke(ctx, "/trxv1.TrxService/CreateMultisigTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trxServiceClient) SignMultisigTransaction(ctx context.Context, in *SignMultisigTransactionRequest, opts ...grpc.CallOption) (*MultisigTransaction, error) {
+	out := new(MultisigTransaction)
+	err := c.cc.Invoke(ctx, "/trxv1.TrxService/SignMultisigTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trxServiceClient) GetMultisigTransaction(ctx context.Context, in *GetMultisigTransactionRequest, opts ...grpc.CallOption) (*MultisigTransaction, error) {
+	out := new(MultisigTransaction)
+	err := c.cc.Invoke(ctx, "/trxv1.TrxService/GetMultisigTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trxServiceClient) ListMultisigTransactions(ctx context.Context, in *ListMultisigTransactionsRequest, opts ...grpc.CallOption) (*ListMultisigTransactionsReply, error) {
+	out := new(ListMultisigTransactionsReply)
+	err := c.cc.Invoke(ctx, "/trxv1.TrxService/ListMultisigTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trxServiceClient) NewDepositAddress(ctx context.Context, in *NewDepositAddressRequest, opts ...grpc.CallOption) (*NewDepositAddressReply, error) {
+	out := new(NewDepositAddressReply)
+	err := c.cc.Invoke(ctx, "/trxv1.TrxService/NewDepositAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trxServiceClient) ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsReply, error) {
+	out := new(ListTransactionsReply)
+	err := c.cc.Invoke(ctx, "/trxv1.TrxService/ListTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *trxServiceClient) SubscribeTransfers(ctx context.Context, in *SubscribeTransfersRequest, opts ...grpc.CallOption) (TrxService_SubscribeTransfersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TrxService_ServiceDesc.Streams[0], "/trxv1.TrxService/SubscribeTransfers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &trxServiceSubscribeTransfersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TrxService_SubscribeTransfersClient interface {
+	Recv() (*TransferEvent, error)
+	grpc.ClientStream
+}
+
+type trxServiceSubscribeTransfersClient struct {
+	grpc.ClientStream
+}
+
+func (x *trxServiceSubscribeTransfersClient) Recv() (*TransferEvent, error) {
+	m := new(TransferEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *trxServiceClient) ReplayWebhooks(ctx context.Context, in *ReplayWebhooksRequest, opts ...grpc.CallOption) (*ReplayWebhooksReply, error) {
+	out := new(ReplayWebhooksReply)
+	err := c.cc.Invoke(ctx, "/trxv1.TrxService/ReplayWebhooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TrxServiceServer is the server API for TrxService service.
 // All implementations must embed UnimplementedTrxServiceServer
 // for forward compatibility
 type TrxServiceServer interface {
 	GetTrxBalance(context.Context, *GetTrxBalanceRequest) (*GetTrxBalanceReply, error)
 	GetTRC20TokenBalance(context.Context, *GetTRC20TokenBalanceRequest) (*GetTRC20TokenBalanceReply, error)
+	// 构建、签名并广播 TRX 转账交易
+	TransferTrx(context.Context, *TransferTrxRequest) (*TransferTrxReply, error)
+	// 构建、签名并广播 TRC20 代币转账交易
+	TransferTRC20(context.Context, *TransferTRC20Request) (*TransferTRC20Reply, error)
+	// 构建未签名的 TRX 或 TRC20 转账, 导出给离线机器签名
+	ExportTransaction(context.Context, *ExportTransactionRequest) (*ExportTransactionReply, error)
+	// 导入离线签名后的交易, 校验签名属于转出地址后广播
+	ImportTransaction(context.Context, *ImportTransactionRequest) (*ImportTransactionReply, error)
+	// 预估 TRX 或 TRC20 转账消耗的能量、带宽及需燃烧的 TRX
+	EstimateFee(context.Context, *EstimateFeeRequest) (*EstimateFeeReply, error)
+	// 质押 TRX 获取能量或带宽 (Stake 2.0)
+	FreezeBalanceV2(context.Context, *FreezeBalanceV2Request) (*StakeReply, error)
+	// 解除质押, 等待期满后通过 WithdrawExpireUnfreeze 提取
+	UnfreezeBalanceV2(context.Context, *UnfreezeBalanceV2Request) (*StakeReply, error)
+	// 提取已到期的解除质押 TRX
+	WithdrawExpireUnfreeze(context.Context, *WithdrawExpireUnfreezeRequest) (*StakeReply, error)
+	// 将质押获得的能量或带宽代理给其他地址, 例如热钱包
+	DelegateResource(context.Context, *DelegateResourceRequest) (*StakeReply, error)
+	// 收回代理出去的能量或带宽
+	UnDelegateResource(context.Context, *UnDelegateResourceRequest) (*StakeReply, error)
+	// 查询地址的资源上限、使用量、质押及代理情况
+	GetAccountResources(context.Context, *GetAccountResourcesRequest) (*GetAccountResourcesReply, error)
+	// 提交批量付款, 按 client_reference 幂等: 重复提交同一 client_reference 不会重复付款
+	SubmitPayoutBatch(context.Context, *SubmitPayoutBatchRequest) (*PayoutBatchReply, error)
+	// 查询批量付款中每一笔的状态
+	GetPayoutBatch(context.Context, *GetPayoutBatchRequest) (*PayoutBatchReply, error)
+	// 立即开始一次归集, 上一次归集未完成时失败
+	StartSweep(context.Context, *StartSweepRequest) (*SweepRunReply, error)
+	// 查询归集的进度和每个地址每种代币的归集步骤
+	GetSweepRun(context.Context, *GetSweepRunRequest) (*SweepRunReply, error)
+	// 创建多签账户的转账, 按 permission_id 的权限收集签名
+	CreateMultisigTransaction(context.Context, *CreateMultisigTransactionRequest) (*MultisigTransaction, error)
+	// 提交一个审批人对 txid 的签名, 权重达到阈值后广播
+	SignMultisigTransaction(context.Context, *SignMultisigTransactionRequest) (*MultisigTransaction, error)
+	GetMultisigTransaction(context.Context, *GetMultisigTransactionRequest) (*MultisigTransaction, error)
+	// 列出待审批的多签交易
+	ListMultisigTransactions(context.Context, *ListMultisigTransactionsRequest) (*ListMultisigTransactionsReply, error)
+	// 获取用户的充值地址, 同一 (user_id, account) 重复调用返回同一地址
+	NewDepositAddress(context.Context, *NewDepositAddressRequest) (*NewDepositAddressReply, error)
+	// 查询交易记录, 支持页码分页, 大量数据时使用游标分页
+	ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsReply, error)
+	// 订阅地址的转入转出, 交易被发现及每次状态变化时推送; HTTP 网关以 SSE (Accept: text/event-stream) 输出
+	SubscribeTransfers(*SubscribeTransfersRequest, TrxService_SubscribeTransfersServer) error
+	// 管理接口: 重放投递失败(dead)的 webhook
+	ReplayWebhooks(context.Context, *ReplayWebhooksRequest) (*ReplayWebhooksReply, error)
 	mustEmbedUnimplementedTrxServiceServer()
 }
 
@@ -71,6 +391,75 @@ func (UnimplementedTrxServiceServer) GetTrxBalance(context.Context, *GetTrxBalan
 func (UnimplementedTrxServiceServer) GetTRC20TokenBalance(context.Context, *GetTRC20TokenBalanceRequest) (*GetTRC20TokenBalanceReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTRC20TokenBalance not implemented")
 }
+func (UnimplementedTrxServiceServer) TransferTrx(context.Context, *TransferTrxRequest) (*TransferTrxReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferTrx not implemented")
+}
+func (UnimplementedTrxServiceServer) TransferTRC20(context.Context, *TransferTRC20Request) (*TransferTRC20Reply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferTRC20 not implemented")
+}
+func (UnimplementedTrxServiceServer) ExportTransaction(context.Context, *ExportTransactionRequest) (*ExportTransactionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportTransaction not implemented")
+}
+func (UnimplementedTrxServiceServer) ImportTransaction(context.Context, *ImportTransactionRequest) (*ImportTransactionReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportTransaction not implemented")
+}
+func (UnimplementedTrxServiceServer) EstimateFee(context.Context, *EstimateFeeRequest) (*EstimateFeeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateFee not implemented")
+}
+func (UnimplementedTrxServiceServer) FreezeBalanceV2(context.Context, *FreezeBalanceV2Request) (*StakeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FreezeBalanceV2 not implemented")
+}
+func (UnimplementedTrxServiceServer) UnfreezeBalanceV2(context.Context, *UnfreezeBalanceV2Request) (*StakeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnfreezeBalanceV2 not implemented")
+}
+func (UnimplementedTrxServiceServer) WithdrawExpireUnfreeze(context.Context, *WithdrawExpireUnfreezeRequest) (*StakeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WithdrawExpireUnfreeze not implemented")
+}
+func (UnimplementedTrxServiceServer) DelegateResource(context.Context, *DelegateResourceRequest) (*StakeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DelegateResource not implemented")
+}
+func (UnimplementedTrxServiceServer) UnDelegateResource(context.Context, *UnDelegateResourceRequest) (*StakeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnDelegateResource not implemented")
+}
+func (UnimplementedTrxServiceServer) GetAccountResources(context.Context, *GetAccountResourcesRequest) (*GetAccountResourcesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountResources not implemented")
+}
+func (UnimplementedTrxServiceServer) SubmitPayoutBatch(context.Context, *SubmitPayoutBatchRequest) (*PayoutBatchReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitPayoutBatch not implemented")
+}
+func (UnimplementedTrxServiceServer) GetPayoutBatch(context.Context, *GetPayoutBatchRequest) (*PayoutBatchReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPayoutBatch not implemented")
+}
+func (UnimplementedTrxServiceServer) StartSweep(context.Context, *StartSweepRequest) (*SweepRunReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartSweep not implemented")
+}
+func (UnimplementedTrxServiceServer) GetSweepRun(context.Context, *GetSweepRunRequest) (*SweepRunReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSweepRun not implemented")
+}
+func (UnimplementedTrxServiceServer) CreateMultisigTransaction(context.Context, *CreateMultisigTransactionRequest) (*MultisigTransaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMultisigTransaction not implemented")
+}
+func (UnimplementedTrxServiceServer) SignMultisigTransaction(context.Context, *SignMultisigTransactionRequest) (*MultisigTransaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignMultisigTransaction not implemented")
+}
+func (UnimplementedTrxServiceServer) GetMultisigTransaction(context.Context, *GetMultisigTransactionRequest) (*MultisigTransaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMultisigTransaction not implemented")
+}
+func (UnimplementedTrxServiceServer) ListMultisigTransactions(context.Context, *ListMultisigTransactionsRequest) (*ListMultisigTransactionsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMultisigTransactions not implemented")
+}
+func (UnimplementedTrxServiceServer) NewDepositAddress(context.Context, *NewDepositAddressRequest) (*NewDepositAddressReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewDepositAddress not implemented")
+}
+func (UnimplementedTrxServiceServer) ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransactions not implemented")
+}
+func (UnimplementedTrxServiceServer) SubscribeTransfers(*SubscribeTransfersRequest, TrxService_SubscribeTransfersServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeTransfers not implemented")
+}
+func (UnimplementedTrxServiceServer) ReplayWebhooks(context.Context, *ReplayWebhooksRequest) (*ReplayWebhooksReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayWebhooks not implemented")
+}
 func (UnimplementedTrxServiceServer) mustEmbedUnimplementedTrxServiceServer() {}
 
 // UnsafeTrxServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -120,22 +509,533 @@ func _TrxService_GetTRC20TokenBalance_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
-// TrxService_ServiceDesc is the grpc.ServiceDesc for TrxService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var TrxService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "trxv1.TrxService",
-	HandlerType: (*TrxServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetTrxBalance",
-			Handler:    _TrxService_GetTrxBalance_Handler,
-		},
-		{
-			MethodName: "GetTRC20TokenBalance",
-			Handler:    _TrxService_GetTRC20TokenBalance_Handler,
+func _TrxService_TransferTrx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferTrxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrxServiceServer).TransferTrx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.TrxService/TransferTrx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrxServiceServer).TransferTrx(ctx, req.(*TransferTrxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrxService_TransferTRC20_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferTRC20Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrxServiceServer).TransferTRC20(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.TrxService/TransferTRC20",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrxServiceServer).TransferTRC20(ctx, req.(*TransferTRC20Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrxService_ExportTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrxServiceServer).ExportTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.TrxService/ExportTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrxServiceServer).ExportTransaction(ctx, req.(*ExportTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrxService_ImportTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrxServiceServer).ImportTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.TrxService/ImportTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrxServiceServer).ImportTransaction(ctx, req.(*ImportTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrxService_EstimateFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrxServiceServer).EstimateFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.TrxService/EstimateFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrxServiceServer).EstimateFee(ctx, req.(*EstimateFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrxService_FreezeBalanceV2_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreezeBalanceV2Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrxServiceServer).FreezeBalanceV2(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.TrxService/FreezeBalanceV2",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrxServiceServer).FreezeBalanceV2(ctx, req.(*FreezeBalanceV2Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrxService_UnfreezeBalanceV2_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnfreezeBalanceV2Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrxServiceServer).UnfreezeBalanceV2(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.TrxService/UnfreezeBalanceV2",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrxServiceServer).UnfreezeBalanceV2(ctx, req.(*UnfreezeBalanceV2Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrxService_WithdrawExpireUnfreeze_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WithdrawExpireUnfreezeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrxServiceServer).WithdrawExpireUnfreeze(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.TrxService/WithdrawExpireUnfreeze",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrxServiceServer).WithdrawExpireUnfreeze(ctx, req.(*WithdrawExpireUnfreezeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrxService_DelegateResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelegateResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrxServiceServer).DelegateResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.TrxService/DelegateResource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrxServiceServer).DelegateResource(ctx, req.(*DelegateResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrxService_UnDelegateResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnDelegateResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrxServiceServer).UnDelegateResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.TrxService/UnDelegateResource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrxServiceServer).UnDelegateResource(ctx, req.(*UnDelegateResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrxService_GetAccountResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrxServiceServer).GetAccountResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.TrxService/GetAccountResources",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrxServiceServer).GetAccountResources(ctx, req.(*GetAccountResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrxService_SubmitPayoutBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitPayoutBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrxServiceServer).SubmitPayoutBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.TrxService/SubmitPayoutBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrxServiceServer).SubmitPayoutBatch(ctx, req.(*SubmitPayoutBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrxService_GetPayoutBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPayoutBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrxServiceServer).GetPayoutBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.TrxService/GetPayoutBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrxServiceServer).GetPayoutBatch(ctx, req.(*GetPayoutBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrxService_StartSweep_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartSweepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrxServiceServer).StartSweep(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.TrxService/StartSweep",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrxServiceServer).StartSweep(ctx, req.(*StartSweepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrxService_GetSweepRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSweepRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrxServiceServer).GetSweepRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.TrxService/GetSweepRun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrxServiceServer).GetSweepRun(ctx, req.(*GetSweepRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrxService_CreateMultisigTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMultisigTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrxServiceServer).CreateMultisigTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.TrxService/CreateMultisigTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrxServiceServer).CreateMultisigTransaction(ctx, req.(*CreateMultisigTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrxService_SignMultisigTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignMultisigTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrxServiceServer).SignMultisigTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.TrxService/SignMultisigTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrxServiceServer).SignMultisigTransaction(ctx, req.(*SignMultisigTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrxService_GetMultisigTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMultisigTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrxServiceServer).GetMultisigTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.TrxService/GetMultisigTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrxServiceServer).GetMultisigTransaction(ctx, req.(*GetMultisigTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrxService_ListMultisigTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMultisigTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrxServiceServer).ListMultisigTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.TrxService/ListMultisigTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrxServiceServer).ListMultisigTransactions(ctx, req.(*ListMultisigTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrxService_NewDepositAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewDepositAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrxServiceServer).NewDepositAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.TrxService/NewDepositAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrxServiceServer).NewDepositAddress(ctx, req.(*NewDepositAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrxService_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrxServiceServer).ListTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.TrxService/ListTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrxServiceServer).ListTransactions(ctx, req.(*ListTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TrxService_SubscribeTransfers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeTransfersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TrxServiceServer).SubscribeTransfers(m, &trxServiceSubscribeTransfersServer{stream})
+}
+
+type TrxService_SubscribeTransfersServer interface {
+	Send(*TransferEvent) error
+	grpc.ServerStream
+}
+
+type trxServiceSubscribeTransfersServer struct {
+	grpc.ServerStream
+}
+
+func (x *trxServiceSubscribeTransfersServer) Send(m *TransferEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TrxService_ReplayWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrxServiceServer).ReplayWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/trxv1.TrxService/ReplayWebhooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrxServiceServer).ReplayWebhooks(ctx, req.(*ReplayWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TrxService_ServiceDesc is the grpc.ServiceDesc for TrxService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TrxService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "trxv1.TrxService",
+	HandlerType: (*TrxServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTrxBalance",
+			Handler:    _TrxService_GetTrxBalance_Handler,
+		},
+		{
+			MethodName: "GetTRC20TokenBalance",
+			Handler:    _TrxService_GetTRC20TokenBalance_Handler,
+		},
+		{
+			MethodName: "TransferTrx",
+			Handler:    _TrxService_TransferTrx_Handler,
+		},
+		{
+			MethodName: "TransferTRC20",
+			Handler:    _TrxService_TransferTRC20_Handler,
+		},
+		{
+			MethodName: "ExportTransaction",
+			Handler:    _TrxService_ExportTransaction_Handler,
+		},
+		{
+			MethodName: "ImportTransaction",
+			Handler:    _TrxService_ImportTransaction_Handler,
+		},
+		{
+			MethodName: "EstimateFee",
+			Handler:    _TrxService_EstimateFee_Handler,
+		},
+		{
+			MethodName: "FreezeBalanceV2",
+			Handler:    _TrxService_FreezeBalanceV2_Handler,
+		},
+		{
+			MethodName: "UnfreezeBalanceV2",
+			Handler:    _TrxService_UnfreezeBalanceV2_Handler,
+		},
+		{
+			MethodName: "WithdrawExpireUnfreeze",
+			Handler:    _TrxService_WithdrawExpireUnfreeze_Handler,
+		},
+		{
+			MethodName: "DelegateResource",
+			Handler:    _TrxService_DelegateResource_Handler,
+		},
+		{
+			MethodName: "UnDelegateResource",
+			Handler:    _TrxService_UnDelegateResource_Handler,
+		},
+		{
+			MethodName: "GetAccountResources",
+			Handler:    _TrxService_GetAccountResources_Handler,
+		},
+		{
+			MethodName: "SubmitPayoutBatch",
+			Handler:    _TrxService_SubmitPayoutBatch_Handler,
+		},
+		{
+			MethodName: "GetPayoutBatch",
+			Handler:    _TrxService_GetPayoutBatch_Handler,
+		},
+		{
+			MethodName: "StartSweep",
+			Handler:    _TrxService_StartSweep_Handler,
+		},
+		{
+			MethodName: "GetSweepRun",
+			Handler:    _TrxService_GetSweepRun_Handler,
+		},
+		{
+			MethodName: "CreateMultisigTransaction",
+			Handler:    _TrxService_CreateMultisigTransaction_Handler,
+		},
+		{
+			MethodName: "SignMultisigTransaction",
+			Handler:    _TrxService_SignMultisigTransaction_Handler,
+		},
+		{
+			MethodName: "GetMultisigTransaction",
+			Handler:    _TrxService_GetMultisigTransaction_Handler,
+		},
+		{
+			MethodName: "ListMultisigTransactions",
+			Handler:    _TrxService_ListMultisigTransactions_Handler,
+		},
+		{
+			MethodName: "NewDepositAddress",
+			Handler:    _TrxService_NewDepositAddress_Handler,
+		},
+		{
+			MethodName: "ListTransactions",
+			Handler:    _TrxService_ListTransactions_Handler,
+		},
+		{
+			MethodName: "ReplayWebhooks",
+			Handler:    _TrxService_ReplayWebhooks_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeTransfers",
+			Handler:       _TrxService_SubscribeTransfers_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "trx.proto",
 }
@@ -19,6 +19,15 @@ import (
 
 func main() {
 
+	// 离线签名, 不连接服务, 签名结果可能写到 stdout
+	if len(os.Args) > 1 && os.Args[1] == "sign" {
+		_ = godotenv.Load()
+		if err := runSign(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	if err := godotenv.Load(); err != nil {
 		fmt.Printf("load env file err:%v\n", err)
 	}
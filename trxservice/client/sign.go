@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/leondevpt/wallet/trxservice/internal/biz"
+	"github.com/leondevpt/wallet/trxservice/pkg/bundle"
+	"google.golang.org/protobuf/proto"
+)
+
+// runSign sign a bundle exported by ExportTransaction with a keystore file, without any
+// network access. The signed bundle is written in the format it was read in, to be passed
+// to ImportTransaction.
+//
+//	client sign -keystore key.json -in unsigned.json -out signed.json
+func runSign(args []string) error {
+	fs := flag.NewFlagSet("sign", flag.ExitOnError)
+	keystoreFile := fs.String("keystore", "", "keystore file of the owner address")
+	passwordFile := fs.String("password-file", "", "file holding the keystore password, KEYSTORE_PASSWORD when empty")
+	in := fs.String("in", "-", "unsigned bundle, - for stdin")
+	out := fs.String("out", "-", "signed bundle, - for stdout")
+	yes := fs.Bool("yes", false, "sign without asking for confirmation")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *keystoreFile == "" {
+		return fmt.Errorf("-keystore is required")
+	}
+	if !*yes && *in == "-" {
+		return fmt.Errorf("confirmation reads stdin, pass the bundle with -in or use -yes")
+	}
+
+	password := os.Getenv("KEYSTORE_PASSWORD")
+	if *passwordFile != "" {
+		b, err := os.ReadFile(*passwordFile)
+		if err != nil {
+			return err
+		}
+		password = strings.TrimRight(string(b), "\r\n")
+	}
+	signer, err := biz.NewKeystoreSigner(*keystoreFile, password)
+	if err != nil {
+		return err
+	}
+
+	raw, err := readInput(*in)
+	if err != nil {
+		return err
+	}
+	b, format, err := bundle.Decode(string(raw))
+	if err != nil {
+		return err
+	}
+	tx, err := bundle.Transaction(b)
+	if err != nil {
+		return err
+	}
+	// check the bundle describes its own transaction before showing it
+	rawData, err := proto.Marshal(tx.GetRawData())
+	if err != nil {
+		return err
+	}
+	if txid := sha256.Sum256(rawData); hex.EncodeToString(txid[:]) != b.Txid {
+		return fmt.Errorf("txid %s of the bundle does not match its transaction %x", b.Txid, txid)
+	}
+	if len(tx.Signature) > 0 {
+		return fmt.Errorf("transaction %s is signed already", b.Txid)
+	}
+	described, err := bundle.Describe(tx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "txid:        %s\nsummary:     %s\ntransaction: %s\n", b.Txid, b.Summary, described)
+	if time.Now().After(time.UnixMilli(b.Expiration)) {
+		fmt.Fprintln(os.Stderr, "warning: the transaction expired by this machine's clock")
+	}
+	if !*yes {
+		fmt.Fprint(os.Stderr, "sign? [y/N] ")
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if a := strings.TrimSpace(answer); a != "y" && a != "Y" {
+			return fmt.Errorf("not signed")
+		}
+	}
+
+	if tx, err = signer.Sign(context.Background(), tx); err != nil {
+		return err
+	}
+	if err := bundle.SetTransaction(b, tx); err != nil {
+		return err
+	}
+	encoded, err := bundle.Encode(b, format)
+	if err != nil {
+		return err
+	}
+	return writeOutput(*out, encoded+"\n")
+}
+
+func readInput(name string) ([]byte, error) {
+	if name == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(name)
+}
+
+func writeOutput(name, s string) error {
+	if name == "-" {
+		_, err := os.Stdout.WriteString(s)
+		return err
+	}
+	return os.WriteFile(name, []byte(s), 0o600)
+}
@@ -1,4 +0,0 @@
-package global
-
-const StaticSchema = "static"
-const TronNode = "trx_node"
@@ -0,0 +1,76 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrNotFound is returned by repos when the record does not exist
+var ErrNotFound = errors.New("record not found")
+
+// DepositAddress maps a derived address to the user who owns it
+type DepositAddress struct {
+	ID        int64     `gorm:"primaryKey" json:"id"`
+	UserID    string    `gorm:"size:64;not null;uniqueIndex:uk_user_account" json:"user_id"`
+	Account   uint32    `gorm:"not null;uniqueIndex:uk_user_account;uniqueIndex:uk_account_index" json:"account"`
+	Index     uint32    `gorm:"column:address_index;not null;uniqueIndex:uk_account_index" json:"index"`
+	Address   string    `gorm:"size:34;not null;uniqueIndex" json:"address"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AddressRepo persists derived deposit addresses
+type AddressRepo interface {
+	GetDepositAddress(ctx context.Context, userID string, account uint32) (*DepositAddress, error)
+	// CreateDepositAddress allocates the next index of account and stores the address
+	// returned by derive. It returns the existing record if the user already has one.
+	CreateDepositAddress(ctx context.Context, userID string, account uint32, derive func(index uint32) (string, error)) (*DepositAddress, error)
+	// FilterManagedAddresses report which of addrs are deposit addresses we manage
+	FilterManagedAddresses(ctx context.Context, addrs []string) (map[string]bool, error)
+	// ListDepositAddresses return the deposit addresses among addrs
+	ListDepositAddresses(ctx context.Context, addrs []string) ([]*DepositAddress, error)
+	// ListDepositAddressesAfter return up to limit deposit addresses with an ID above afterID in ID order
+	ListDepositAddressesAfter(ctx context.Context, afterID int64, limit int) ([]*DepositAddress, error)
+}
+
+type AddressUsecase struct {
+	repo   AddressRepo
+	wallet *HDWallet
+	log    *zap.Logger
+}
+
+// NewAddressUsecase new a deposit address usecase.
+func NewAddressUsecase(repo AddressRepo, wallet *HDWallet, logger *zap.Logger) *AddressUsecase {
+	return &AddressUsecase{repo: repo, wallet: wallet, log: logger}
+}
+
+// NewDepositAddress return the deposit address of user under account, deriving
+// a new one on first call. Retries return the same address and never burn an index.
+func (uc *AddressUsecase) NewDepositAddress(ctx context.Context, userID string, account uint32) (*DepositAddress, error) {
+	addr, err := uc.repo.GetDepositAddress(ctx, userID, account)
+	if err == nil {
+		return addr, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	// derive before touching the db so a misconfigured account fails fast
+	if _, err := uc.wallet.Derive(account, 0); err != nil {
+		return nil, err
+	}
+	addr, err = uc.repo.CreateDepositAddress(ctx, userID, account, func(index uint32) (string, error) {
+		return uc.wallet.Derive(account, index)
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.log.Sugar().Infow("NewDepositAddress", "user", userID, "account", account, "index", addr.Index, "address", addr.Address)
+	return addr, nil
+}
+
+// Path return the BIP44 path of a deposit address
+func (uc *AddressUsecase) Path(addr *DepositAddress) string {
+	return uc.wallet.Path(addr.Account, addr.Index)
+}
@@ -0,0 +1,22 @@
+package biz
+
+import (
+	"context"
+	"time"
+)
+
+// AuditLog records an action the service took on its own, e.g. delegating energy
+type AuditLog struct {
+	ID        int64     `gorm:"primaryKey" json:"id"`
+	Actor     string    `gorm:"size:32;not null;index" json:"actor"` // the controller or job acting
+	Action    string    `gorm:"size:32;not null" json:"action"`
+	Address   string    `gorm:"size:34;index" json:"address"` // the address acted for
+	Txid      string    `gorm:"size:64" json:"txid"`
+	Detail    string    `gorm:"type:text" json:"detail"` // JSON
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AuditRepo persists the audit log
+type AuditRepo interface {
+	CreateAuditLog(ctx context.Context, l *AuditLog) error
+}
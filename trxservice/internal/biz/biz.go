@@ -3,4 +3,6 @@ package biz
 import "github.com/google/wire"
 
 // ProviderSet is service providers.
-var ProviderSet = wire.NewSet(NewTrxUsecase, NewTronCli)
+var ProviderSet = wire.NewSet(NewTrxUsecase, NewTronCli, NewNodePool, NewSolidityNodePool, NewSigner, NewHDWallet, NewAddressUsecase, NewBlockScanner,
+	NewEventBus, NewConfirmationTracker, NewWebhookUsecase, NewEnergyController, NewPayoutUsecase,
+	NewSweepUsecase, NewMultisigUsecase, NewOfflineUsecase)
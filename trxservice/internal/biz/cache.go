@@ -0,0 +1,33 @@
+package biz
+
+import (
+	"context"
+	"math/big"
+)
+
+// BalanceCache keeps balances read from the nodes, by address, token and the block height
+// they were read at, and the metadata of TRC20 contracts. Concurrent loads of the same entry
+// share one node call. Errors are never cached.
+type BalanceCache interface {
+	// Balance return the balance of key, loaded by load on a miss
+	Balance(ctx context.Context, key BalanceKey, load func(ctx context.Context) (*big.Int, error)) (*big.Int, error)
+	// InvalidateBalances drop the balances of addrs at every token and height
+	InvalidateBalances(ctx context.Context, addrs ...string) error
+	// TokenInfo return the metadata of a TRC20 contract, loaded by load on a miss
+	TokenInfo(ctx context.Context, contract string, load func(ctx context.Context) (*TokenInfo, error)) (*TokenInfo, error)
+}
+
+// BalanceKey names a cached balance
+type BalanceKey struct {
+	Address   string
+	Token     string // TRX or the TRC20 contract address
+	Height    int64  // head block of the pool the balance was read from
+	Confirmed bool   // read from the solidity nodes
+}
+
+// TokenInfo is the metadata a TRC20 contract reports
+type TokenInfo struct {
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Decimals int32  `json:"decimals"`
+}
@@ -0,0 +1,235 @@
+package biz
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// memBalanceCache is a BalanceCache in memory counting the loads it makes
+type memBalanceCache struct {
+	mu          sync.Mutex
+	balances    map[BalanceKey]*big.Int
+	tokens      map[string]*TokenInfo
+	loads       int
+	invalidated []string
+}
+
+func newMemBalanceCache() *memBalanceCache {
+	return &memBalanceCache{balances: make(map[BalanceKey]*big.Int), tokens: make(map[string]*TokenInfo)}
+}
+
+func (c *memBalanceCache) Balance(ctx context.Context, key BalanceKey, load func(ctx context.Context) (*big.Int, error)) (*big.Int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if b, ok := c.balances[key]; ok {
+		return new(big.Int).Set(b), nil
+	}
+	c.loads++
+	b, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.balances[key] = new(big.Int).Set(b)
+	return b, nil
+}
+
+func (c *memBalanceCache) InvalidateBalances(ctx context.Context, addrs ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, addr := range addrs {
+		for k := range c.balances {
+			if k.Address == addr {
+				delete(c.balances, k)
+			}
+		}
+	}
+	c.invalidated = append(c.invalidated, addrs...)
+	return nil
+}
+
+func (c *memBalanceCache) TokenInfo(ctx context.Context, contract string, load func(ctx context.Context) (*TokenInfo, error)) (*TokenInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if info, ok := c.tokens[contract]; ok {
+		return info, nil
+	}
+	c.loads++
+	info, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.tokens[contract] = info
+	return info, nil
+}
+
+func (c *memBalanceCache) keys() map[BalanceKey]int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make(map[BalanceKey]int64, len(c.balances))
+	for k, b := range c.balances {
+		keys[k] = b.Int64()
+	}
+	return keys
+}
+
+func TestBalanceCache(t *testing.T) {
+	chain := newStandInChain(t)
+	addr := chain.serveGRPC(t)
+	cfg := &setting.Config{App: setting.App{Node_Addr: []string{addr}, Solidity_Addr: []string{addr}}}
+	pool, solidity := NewNodePool(cfg, zap.NewNop()), NewSolidityNodePool(cfg, zap.NewNop())
+	cli := NewTronCli(pool, solidity)
+	defer cli.Stop()
+	cache := newMemBalanceCache()
+	uc := NewTrxUsecase(newMemTrxRepo(), cache, zap.NewNop(), cli, nopSigner{}, NewEventBus(), cfg)
+	ctx := context.Background()
+	owner, token := base58Addr(chain.owner), base58Addr(chain.token)
+
+	// read from the nodes until they are probed
+	if _, err := uc.GetBalance(ctx, owner, false); err != nil || cache.loads != 0 {
+		t.Fatalf("loads %d: %v", cache.loads, err)
+	}
+
+	pool.probe(ctx)
+	solidity.probe(ctx)
+	for i := 0; i < 2; i++ {
+		if balance, err := uc.GetBalance(ctx, owner, false); err != nil || balance.Int64() != chain.balance {
+			t.Fatalf("balance %v: %v", balance, err)
+		}
+		if balance, err := uc.GetBalance(ctx, owner, true); err != nil || balance.Int64() != chain.solidBalance {
+			t.Fatalf("confirmed balance %v: %v", balance, err)
+		}
+		if balance, err := uc.GetTRC20TokenBalance(ctx, owner, token, false); err != nil || balance.Int64() != chain.tokenBalance {
+			t.Fatalf("token balance %v: %v", balance, err)
+		}
+	}
+	want := map[BalanceKey]int64{
+		{Address: owner, Token: TokenTRX, Height: 10}:                 chain.balance,
+		{Address: owner, Token: TokenTRX, Height: 9, Confirmed: true}: chain.solidBalance,
+		{Address: owner, Token: token, Height: 10}:                    chain.tokenBalance,
+	}
+	if keys := cache.keys(); cache.loads != 3 || len(keys) != len(want) {
+		t.Fatalf("loads %d, cached %v", cache.loads, keys)
+	}
+	for k, balance := range cache.keys() {
+		if want[k] != balance {
+			t.Fatalf("cached %+v: %d", k, balance)
+		}
+	}
+
+	// errors are not cached
+	if _, err := uc.GetBalance(ctx, base58Addr(chain.receiver), true); err == nil {
+		t.Fatal("balance of an account not on chain")
+	}
+	if keys := cache.keys(); len(keys) != len(want) {
+		t.Fatalf("cached %v", keys)
+	}
+}
+
+func TestScannerInvalidatesBalances(t *testing.T) {
+	deposit, other, x, y, idle := newTestAddress(t), newTestAddress(t), newTestAddress(t), newTestAddress(t), newTestAddress(t)
+	transfer := func(from, to []byte) *core.Transaction {
+		return newTestContractTx(t, core.Transaction_Contract_TransferContract, &core.TransferContract{OwnerAddress: from, ToAddress: to, Amount: 1})
+	}
+	chain := newFakeChain()
+	chain.addBlock([]*core.Transaction{transfer(other.Bytes(), deposit.Bytes()), transfer(x.Bytes(), y.Bytes())}, nil)
+
+	s, _ := newTestScanner(chain, newMemTrxRepo(), deposit)
+	cache := newMemBalanceCache()
+	s.cache = cache
+	for _, a := range []string{deposit.String(), x.String(), idle.String()} {
+		cache.balances[BalanceKey{Address: a, Token: TokenTRX, Height: 1}] = big.NewInt(5)
+	}
+	if err := s.scan(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	// every address a transfer of the block touched, managed or not
+	if len(cache.invalidated) != 4 {
+		t.Fatalf("invalidated %v", cache.invalidated)
+	}
+	if keys := cache.keys(); len(keys) != 1 || keys[BalanceKey{Address: idle.String(), Token: TokenTRX, Height: 1}] != 5 {
+		t.Fatalf("cached %v", keys)
+	}
+}
+
+// fakeTokenNode answers the name, symbol and decimals calls of TRC20 contracts
+type fakeTokenNode struct {
+	api.WalletClient
+	decimals int64
+	empty    bool // answer as an address without a contract
+	calls    int
+}
+
+func (n *fakeTokenNode) TriggerConstantContract(ctx context.Context, in *core.TriggerSmartContract, opts ...grpc.CallOption) (*api.TransactionExtention, error) {
+	str := func(s string) []byte {
+		b := common.LeftPadBytes([]byte{32}, 32)
+		b = append(b, common.LeftPadBytes(big.NewInt(int64(len(s))).Bytes(), 32)...)
+		return append(b, common.RightPadBytes([]byte(s), 32)...)
+	}
+	n.calls++
+	if n.empty {
+		return &api.TransactionExtention{Result: &api.Return{Result: true}}, nil
+	}
+	var result []byte
+	switch sig := common.ToHex(in.Data[:4]); {
+	case sig == trc20NameSignature:
+		result = str("Tether USD")
+	case sig == trc20SymbolSignature:
+		result = str("USDT")
+	case sig == trc20DecimalsSignature:
+		result = common.LeftPadBytes(big.NewInt(n.decimals).Bytes(), 32)
+	}
+	return &api.TransactionExtention{Result: &api.Return{Result: true}, ConstantResult: [][]byte{result}}, nil
+}
+
+func TestTokenDecimals(t *testing.T) {
+	contract := newTestAddress(t).String()
+	cache := newMemBalanceCache()
+	cli := &TronCli{TronWalletCli: &fakeTokenNode{decimals: 6}, GrpcTimeout: time.Second}
+	uc := NewTrxUsecase(newMemTrxRepo(), cache, zap.NewNop(), cli, nopSigner{}, NewEventBus(), nil)
+	ctx := context.Background()
+
+	info, err := uc.GetTRC20Info(ctx, contract)
+	if err != nil || *info != (TokenInfo{Name: "Tether USD", Symbol: "USDT", Decimals: 6}) {
+		t.Fatalf("info %+v: %v", info, err)
+	}
+	for _, c := range []struct {
+		decimal uint
+		want    int32
+	}{{decimal: 18, want: 18}, {want: 6}} {
+		if d, err := uc.TokenDecimals(ctx, setting.Token{ContractAddr: contract, Decimal: c.decimal}); err != nil || d != c.want {
+			t.Fatalf("decimal %d: %d, %v", c.decimal, d, err)
+		}
+	}
+	if cache.loads != 1 {
+		t.Fatalf("loads %d", cache.loads)
+	}
+
+	// decimals are read once, even without a cache
+	node := &fakeTokenNode{decimals: 8}
+	cli.TronWalletCli = node
+	uc = NewTrxUsecase(newMemTrxRepo(), nil, zap.NewNop(), cli, nopSigner{}, NewEventBus(), nil)
+	for i := 0; i < 2; i++ {
+		if d, err := uc.TokenDecimals(ctx, setting.Token{ContractAddr: contract}); err != nil || d != 8 {
+			t.Fatalf("decimals %d: %v", d, err)
+		}
+	}
+	if node.calls != 3 {
+		t.Fatalf("node calls %d", node.calls)
+	}
+
+	// an address that is not a contract returns nothing
+	cli.TronWalletCli = &fakeTokenNode{empty: true}
+	if _, err := uc.TokenDecimals(ctx, setting.Token{ContractAddr: newTestAddress(t).String()}); err == nil {
+		t.Fatal("decimals of an address without a contract")
+	}
+}
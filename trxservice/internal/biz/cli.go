@@ -5,11 +5,11 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
 	"strconv"
 	"time"
-	"github.com/leondevpt/wallet/trxservice/global"
 	"unicode/utf8"
 
 	"github.com/fbsobreira/gotron-sdk/pkg/abi"
@@ -18,10 +18,13 @@ import (
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
 	trxapi "github.com/fbsobreira/gotron-sdk/pkg/proto/api"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	pb "github.com/leondevpt/wallet/trxservice/api/v1"
+	"github.com/leondevpt/wallet/trxservice/pkg/errcode"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
 const (
@@ -31,33 +34,80 @@ const (
 	trc20SymbolSignature         = "0x95d89b41"
 	trc20DecimalsSignature       = "0x313ce567"
 	trc20BalanceOf               = "0x70a08231"
+
+	// txExtentionEnergyUsedField is energy_used of TransactionExtention in java-tron's api.proto
+	txExtentionEnergyUsedField = 5
+
+	// walletMethodPrefix names methods of java-tron's Wallet service missing from gotron-sdk
+	walletMethodPrefix = "/protocol.Wallet/"
+)
+
+// Stake 2.0 contract types, gotron-sdk's enum stops before them
+const (
+	FreezeBalanceV2ContractType        core.Transaction_Contract_ContractType = 54
+	UnfreezeBalanceV2ContractType      core.Transaction_Contract_ContractType = 55
+	WithdrawExpireUnfreezeContractType core.Transaction_Contract_ContractType = 56
+	DelegateResourceContractType       core.Transaction_Contract_ContractType = 57
+	UnDelegateResourceContractType     core.Transaction_Contract_ContractType = 58
 )
 
+// ErrNoSolidityNode is returned by reads of solidified state when no solidity node is configured
+var ErrNoSolidityNode = errors.New("no solidity node configured")
+
 type TronCli struct {
-	Conn          *grpc.ClientConn
+	Conn          grpc.ClientConnInterface // for methods gotron-sdk lacks
 	TronWalletCli trxapi.WalletClient
-	ApiKey        string
-	GrpcTimeout   time.Duration
+	// TronSolidityCli reads solidified state, see HasSolidityNodes
+	SolidityConn    grpc.ClientConnInterface
+	TronSolidityCli trxapi.WalletSolidityClient
+	GrpcTimeout     time.Duration
 }
 
-func NewTronCli() *TronCli {
-	// 建立对应 scheme 的连接, 并且配置负载均衡
-	conn, err := grpc.Dial(fmt.Sprintf("%s:///%s", global.StaticSchema, global.TronNode), // "static:///tron_node"
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithDefaultServiceConfig(`{"loadBalancingPolicy":"round_robin"}`))
-	if err != nil {
-		panic(err)
-	}
-	cli := trxapi.NewWalletClient(conn)
-
+// NewTronCli new a client of the full nodes of pool and the solidity nodes of solidity
+func NewTronCli(pool *NodePool, solidity *SolidityNodePool) *TronCli {
 	defaultTimeout := 30 * time.Second
-	return &TronCli{TronWalletCli: cli, Conn: conn, GrpcTimeout: defaultTimeout}
+	return &TronCli{
+		Conn:            pool,
+		TronWalletCli:   trxapi.NewWalletClient(pool),
+		SolidityConn:    solidity,
+		TronSolidityCli: trxapi.NewWalletSolidityClient(solidity),
+		GrpcTimeout:     defaultTimeout,
+	}
 }
 
 func (t *TronCli) Stop() {
-	if t.Conn != nil {
-		t.Conn.Close()
+	if pool, ok := t.Conn.(*NodePool); ok {
+		pool.Close()
+	}
+	if pool, ok := t.SolidityConn.(*SolidityNodePool); ok {
+		pool.Close()
+	}
+}
+
+// HasSolidityNodes report whether solidity nodes are configured
+func (t *TronCli) HasSolidityNodes() bool {
+	if t.TronSolidityCli == nil {
+		return false
+	}
+	pool, ok := t.SolidityConn.(*SolidityNodePool)
+	return !ok || pool.Len() > 0
+}
+
+// HeadHeight return the head block of the full nodes as of their last probe, 0 when unknown
+func (t *TronCli) HeadHeight() int64 {
+	if pool, ok := t.Conn.(*NodePool); ok {
+		return pool.Height()
+	}
+	return 0
+}
+
+// SolidHeight return the latest solidified block as of the last probe of the solidity
+// nodes, 0 when unknown
+func (t *TronCli) SolidHeight() int64 {
+	if pool, ok := t.SolidityConn.(*SolidityNodePool); ok {
+		return pool.Height()
 	}
+	return 0
 }
 
 // SetTimeout for Client connections
@@ -65,12 +115,6 @@ func (c *TronCli) SetTimeout(timeout time.Duration) {
 	c.GrpcTimeout = timeout
 }
 
-// SetAPIKey enable API on connection
-func (c *TronCli) SetAPIKey(apiKey string) error {
-	c.ApiKey = apiKey
-	return nil
-}
-
 func (c TronCli) GetBalance(ctx context.Context, addr string) (*big.Int, error) {
 	var (
 		err     error
@@ -81,7 +125,7 @@ func (c TronCli) GetBalance(ctx context.Context, addr string) (*big.Int, error)
 		return nil, err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	ctx, cancel := c.getContext(ctx)
 	defer cancel()
 
 	acc, err := c.TronWalletCli.GetAccount(ctx, account)
@@ -95,11 +139,511 @@ func (c TronCli) GetBalance(ctx context.Context, addr string) (*big.Int, error)
 }
 
 func (c TronCli) GetTRC20TokenBalance(ctx context.Context, from, contractAddr string) (*big.Int, error) {
-	return c.TRC20ContractBalance(from, contractAddr)
+	return c.TRC20ContractBalance(ctx, from, contractAddr)
+}
+
+// Transfer build a TRX transfer transaction, amount in SUN
+func (c *TronCli) Transfer(ctx context.Context, from, toAddress string, amount int64) (*api.TransactionExtention, error) {
+	var err error
+
+	contract := &core.TransferContract{}
+	if contract.OwnerAddress, err = common.DecodeCheck(from); err != nil {
+		return nil, err
+	}
+	if contract.ToAddress, err = common.DecodeCheck(toAddress); err != nil {
+		return nil, err
+	}
+	contract.Amount = amount
+
+	ctx, cancel := c.getContext(ctx)
+	defer cancel()
+
+	tx, err := c.TronWalletCli.CreateTransaction2(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+	if proto.Size(tx) == 0 {
+		return nil, fmt.Errorf("bad transaction")
+	}
+	if tx.GetResult().GetCode() != 0 {
+		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	}
+	return tx, nil
+}
+
+// Broadcast signed transaction to the network
+func (c *TronCli) Broadcast(ctx context.Context, tx *core.Transaction) (*api.Return, error) {
+	ctx, cancel := c.getContext(ctx)
+	defer cancel()
+
+	result, err := c.TronWalletCli.BroadcastTransaction(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if !result.GetResult() || result.GetCode() != api.Return_SUCCESS {
+		code := result.GetCode()
+		if code == api.Return_SUCCESS {
+			code = api.Return_OTHER_ERROR // refused without a code
+		}
+		return result, &BroadcastError{Code: code, Message: string(result.GetMessage())}
+	}
+	return result, nil
+}
+
+// BroadcastError is a transaction the node refused, errcode.FromBroadcastCode tells
+// whether broadcasting it again may help
+type BroadcastError struct {
+	Code    api.ReturnResponseCode
+	Message string
+}
+
+func (e *BroadcastError) Error() string {
+	return fmt.Sprintf("result error(%s): %s", e.Code, e.Message)
+}
+
+// broadcastClass classify the refusal err of Broadcast by its code, the Return may carry
+// SUCCESS when the node refused without one. It is nil when err is no refusal.
+func broadcastClass(err error) *errcode.Error {
+	var be *BroadcastError
+	if !errors.As(err, &be) {
+		return nil
+	}
+	return errcode.FromBroadcastCode(be.Code)
+}
+
+// GetTransactionSignWeight return the permission of tx and the weight of its signatures
+func (c *TronCli) GetTransactionSignWeight(ctx context.Context, tx *core.Transaction) (*api.TransactionSignWeight, error) {
+	ctx, cancel := c.getContext(ctx)
+	defer cancel()
+
+	return c.TronWalletCli.GetTransactionSignWeight(ctx, tx)
+}
+
+// GetNowBlock return the latest block
+func (c *TronCli) GetNowBlock(ctx context.Context) (*api.BlockExtention, error) {
+	ctx, cancel := c.getContext(ctx)
+	defer cancel()
+
+	return c.TronWalletCli.GetNowBlock2(ctx, new(api.EmptyMessage))
+}
+
+// GetBlockByNum return block with transactions at height num
+func (c *TronCli) GetBlockByNum(ctx context.Context, num int64) (*api.BlockExtention, error) {
+	ctx, cancel := c.getContext(ctx)
+	defer cancel()
+
+	block, err := c.TronWalletCli.GetBlockByNum2(ctx, &api.NumberMessage{Num: num})
+	if err != nil {
+		return nil, err
+	}
+	if block.GetBlockHeader().GetRawData().GetNumber() != num {
+		return nil, fmt.Errorf("block %d not found", num)
+	}
+	return block, nil
+}
+
+// GetTransactionInfoByBlockNum return receipts and event logs of all transactions in block
+func (c *TronCli) GetTransactionInfoByBlockNum(ctx context.Context, num int64) ([]*core.TransactionInfo, error) {
+	ctx, cancel := c.getContext(ctx)
+	defer cancel()
+
+	list, err := c.TronWalletCli.GetTransactionInfoByBlockNum(ctx, &api.NumberMessage{Num: num})
+	if err != nil {
+		return nil, err
+	}
+	return list.GetTransactionInfo(), nil
+}
+
+// GetSolidTransactionInfoById return the receipt of txid once it is solidified, BlockNumber
+// is 0 until then. Without solidity nodes the receipt comes from the full nodes as soon as
+// txid is included, compare its block with GetSolidBlockNum.
+func (c *TronCli) GetSolidTransactionInfoById(ctx context.Context, txid string) (*core.TransactionInfo, error) {
+	if !c.HasSolidityNodes() {
+		return c.GetTransactionInfoById(ctx, txid)
+	}
+	id, err := hex.DecodeString(txid)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.getContext(ctx)
+	defer cancel()
+
+	return c.TronSolidityCli.GetTransactionInfoById(ctx, &api.BytesMessage{Value: id})
+}
+
+// GetTransactionInfoById return the receipt of txid, BlockNumber is 0 until it is included
+func (c *TronCli) GetTransactionInfoById(ctx context.Context, txid string) (*core.TransactionInfo, error) {
+	id, err := hex.DecodeString(txid)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.getContext(ctx)
+	defer cancel()
+
+	return c.TronWalletCli.GetTransactionInfoById(ctx, &api.BytesMessage{Value: id})
+}
+
+// GetSolidBlockNum return the number of the latest solidified block, the head of the solidity
+// nodes or the one known by a full node without them
+func (c *TronCli) GetSolidBlockNum(ctx context.Context) (int64, error) {
+	ctx, cancel := c.getContext(ctx)
+	defer cancel()
+
+	if c.HasSolidityNodes() {
+		block, err := c.TronSolidityCli.GetNowBlock2(ctx, new(api.EmptyMessage))
+		if err != nil {
+			return 0, err
+		}
+		return block.GetBlockHeader().GetRawData().GetNumber(), nil
+	}
+	info, err := c.TronWalletCli.GetNodeInfo(ctx, new(api.EmptyMessage))
+	if err != nil {
+		return 0, err
+	}
+	// formatted as "Num:%d,ID:%s"
+	var num int64
+	if _, err := fmt.Sscanf(info.GetSolidityBlock(), "Num:%d,", &num); err != nil {
+		return 0, fmt.Errorf("invalid solidity block %q: %v", info.GetSolidityBlock(), err)
+	}
+	return num, nil
+}
+
+// SolidBlockTime return the timestamp of the latest solidified block. A tx expiring before it
+// can no longer be included, and had it been it is in a solidified block already.
+func (c *TronCli) SolidBlockTime(ctx context.Context) (time.Time, error) {
+	num, err := c.GetSolidBlockNum(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	block, err := c.GetBlockByNum(ctx, num)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(block.GetBlockHeader().GetRawData().GetTimestamp()), nil
+}
+
+// AccountExists report whether addr is activated on chain
+func (c *TronCli) AccountExists(ctx context.Context, addr string) (bool, error) {
+	account := new(core.Account)
+	var err error
+	if account.Address, err = common.DecodeCheck(addr); err != nil {
+		return false, err
+	}
+	ctx, cancel := c.getContext(ctx)
+	defer cancel()
+
+	acc, err := c.TronWalletCli.GetAccount(ctx, account)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(acc.Address, account.Address), nil
+}
+
+// GetAccountBalance return the SUN of addr and whether it is activated, 0 when it is not
+func (c *TronCli) GetAccountBalance(ctx context.Context, addr string) (int64, bool, error) {
+	account := new(core.Account)
+	var err error
+	if account.Address, err = common.DecodeCheck(addr); err != nil {
+		return 0, false, err
+	}
+	ctx, cancel := c.getContext(ctx)
+	defer cancel()
+
+	acc, err := c.TronWalletCli.GetAccount(ctx, account)
+	if err != nil {
+		return 0, false, err
+	}
+	if !bytes.Equal(acc.Address, account.Address) {
+		return 0, false, nil
+	}
+	return acc.Balance, true, nil
+}
+
+// GetSolidAccountBalance is GetAccountBalance as of the latest solidified block
+func (c *TronCli) GetSolidAccountBalance(ctx context.Context, addr string) (int64, bool, error) {
+	if !c.HasSolidityNodes() {
+		return 0, false, ErrNoSolidityNode
+	}
+	account := new(core.Account)
+	var err error
+	if account.Address, err = common.DecodeCheck(addr); err != nil {
+		return 0, false, err
+	}
+	ctx, cancel := c.getContext(ctx)
+	defer cancel()
+
+	acc, err := c.TronSolidityCli.GetAccount(ctx, account)
+	if err != nil {
+		return 0, false, err
+	}
+	if !bytes.Equal(acc.Address, account.Address) {
+		return 0, false, nil
+	}
+	return acc.Balance, true, nil
+}
+
+// GetAccountResource return the bandwidth and energy limits and usage of addr
+func (c *TronCli) GetAccountResource(ctx context.Context, addr string) (*api.AccountResourceMessage, error) {
+	account := new(core.Account)
+	var err error
+	if account.Address, err = common.DecodeCheck(addr); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.getContext(ctx)
+	defer cancel()
+
+	return c.TronWalletCli.GetAccountResource(ctx, account)
+}
+
+// GetChainParameters return the chain parameters by key, e.g. getEnergyFee
+func (c *TronCli) GetChainParameters(ctx context.Context) (map[string]int64, error) {
+	ctx, cancel := c.getContext(ctx)
+	defer cancel()
+
+	list, err := c.TronWalletCli.GetChainParameters(ctx, new(api.EmptyMessage))
+	if err != nil {
+		return nil, err
+	}
+	params := make(map[string]int64, len(list.GetChainParameter()))
+	for _, p := range list.GetChainParameter() {
+		params[p.GetKey()] = p.GetValue()
+	}
+	return params, nil
+}
+
+// EnergyUsed return the energy a constant call reported to consume. gotron-sdk's
+// TransactionExtention predates the energy_used field, so it is read from unknown fields.
+func EnergyUsed(tx *api.TransactionExtention) (int64, bool) {
+	b := tx.ProtoReflect().GetUnknown()
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return 0, false
+		}
+		b = b[n:]
+		if num == txExtentionEnergyUsedField && typ == protowire.VarintType {
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return 0, false
+			}
+			return int64(v), true
+		}
+		if n = protowire.ConsumeFieldValue(num, typ, b); n < 0 {
+			return 0, false
+		}
+		b = b[n:]
+	}
+	return 0, false
+}
+
+// FreezeBalanceV2 build a Stake 2.0 freeze of amount SUN for resource
+func (c *TronCli) FreezeBalanceV2(ctx context.Context, from string, amount int64, resource core.ResourceCode) (*api.TransactionExtention, error) {
+	owner, err := common.DecodeCheck(from)
+	if err != nil {
+		return nil, err
+	}
+	return c.createCommonTransaction(ctx, FreezeBalanceV2ContractType, &pb.FreezeBalanceV2Contract{
+		OwnerAddress:  owner,
+		FrozenBalance: amount,
+		Resource:      int32(resource),
+	})
+}
+
+// UnfreezeBalanceV2 build a Stake 2.0 unfreeze, the TRX is withdrawable once the unfreeze expires
+func (c *TronCli) UnfreezeBalanceV2(ctx context.Context, from string, amount int64, resource core.ResourceCode) (*api.TransactionExtention, error) {
+	owner, err := common.DecodeCheck(from)
+	if err != nil {
+		return nil, err
+	}
+	return c.createCommonTransaction(ctx, UnfreezeBalanceV2ContractType, &pb.UnfreezeBalanceV2Contract{
+		OwnerAddress:    owner,
+		UnfreezeBalance: amount,
+		Resource:        int32(resource),
+	})
+}
+
+// WithdrawExpireUnfreeze build a withdrawal of all expired unfreezes of from
+func (c *TronCli) WithdrawExpireUnfreeze(ctx context.Context, from string) (*api.TransactionExtention, error) {
+	owner, err := common.DecodeCheck(from)
+	if err != nil {
+		return nil, err
+	}
+	return c.createCommonTransaction(ctx, WithdrawExpireUnfreezeContractType, &pb.WithdrawExpireUnfreezeContract{OwnerAddress: owner})
+}
+
+// DelegateResource build a delegation of the resource of amount staked SUN from from to to
+func (c *TronCli) DelegateResource(ctx context.Context, from, to string, amount int64, resource core.ResourceCode, lock bool) (*api.TransactionExtention, error) {
+	owner, err := common.DecodeCheck(from)
+	if err != nil {
+		return nil, err
+	}
+	receiver, err := common.DecodeCheck(to)
+	if err != nil {
+		return nil, err
+	}
+	return c.createCommonTransaction(ctx, DelegateResourceContractType, &pb.DelegateResourceContract{
+		OwnerAddress:    owner,
+		Resource:        int32(resource),
+		Balance:         amount,
+		ReceiverAddress: receiver,
+		Lock:            lock,
+	})
+}
+
+// UnDelegateResource build a reclaim of the resource of amount staked SUN delegated from from to to
+func (c *TronCli) UnDelegateResource(ctx context.Context, from, to string, amount int64, resource core.ResourceCode) (*api.TransactionExtention, error) {
+	owner, err := common.DecodeCheck(from)
+	if err != nil {
+		return nil, err
+	}
+	receiver, err := common.DecodeCheck(to)
+	if err != nil {
+		return nil, err
+	}
+	return c.createCommonTransaction(ctx, UnDelegateResourceContractType, &pb.UnDelegateResourceContract{
+		OwnerAddress:    owner,
+		Resource:        int32(resource),
+		Balance:         amount,
+		ReceiverAddress: receiver,
+	})
+}
+
+// stakeContracts are the stand-ins of tron_stake.proto by the name java-tron gives them. They
+// are registered in our own package and share java-tron's field numbers, so a parameter
+// converts between the two by type URL alone.
+var stakeContracts = map[protoreflect.FullName]protoreflect.MessageType{
+	"protocol.FreezeBalanceV2Contract":        (*pb.FreezeBalanceV2Contract)(nil).ProtoReflect().Type(),
+	"protocol.UnfreezeBalanceV2Contract":      (*pb.UnfreezeBalanceV2Contract)(nil).ProtoReflect().Type(),
+	"protocol.WithdrawExpireUnfreezeContract": (*pb.WithdrawExpireUnfreezeContract)(nil).ProtoReflect().Type(),
+	"protocol.DelegateResourceContract":       (*pb.DelegateResourceContract)(nil).ProtoReflect().Type(),
+	"protocol.UnDelegateResourceContract":     (*pb.UnDelegateResourceContract)(nil).ProtoReflect().Type(),
+}
+
+// newContractParameter wrap contract in an Any, stand-ins under java-tron's type URL
+func newContractParameter(contract proto.Message) (*anypb.Any, error) {
+	name := protoreflect.FullName("protocol." + contract.ProtoReflect().Descriptor().Name())
+	if _, ok := stakeContracts[name]; !ok {
+		return anypb.New(contract)
+	}
+	value, err := proto.Marshal(contract)
+	if err != nil {
+		return nil, err
+	}
+	return &anypb.Any{TypeUrl: "type.googleapis.com/" + string(name), Value: value}, nil
+}
+
+// unmarshalContractParameter unwrap a contract parameter, into its stand-in for the
+// contracts gotron-sdk does not know
+func unmarshalContractParameter(a *anypb.Any) (proto.Message, error) {
+	mt, ok := stakeContracts[a.MessageName()]
+	if !ok {
+		return a.UnmarshalNew()
+	}
+	m := mt.New().Interface()
+	return m, proto.Unmarshal(a.Value, m)
+}
+
+// createCommonTransaction let the node build a transaction of a contract gotron-sdk does not know
+func (c *TronCli) createCommonTransaction(ctx context.Context, typ core.Transaction_Contract_ContractType, contract proto.Message) (*api.TransactionExtention, error) {
+	param, err := newContractParameter(contract)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.getContext(ctx)
+	defer cancel()
+
+	tx, err := c.TronWalletCli.CreateCommonTransaction(ctx, &core.Transaction{RawData: &core.TransactionRaw{
+		Contract: []*core.Transaction_Contract{{Type: typ, Parameter: param}},
+	}})
+	if err != nil {
+		return nil, err
+	}
+	if proto.Size(tx) == 0 {
+		return nil, fmt.Errorf("bad transaction")
+	}
+	if tx.GetResult().GetCode() != 0 {
+		return nil, fmt.Errorf("%s", tx.GetResult().GetMessage())
+	}
+	return tx, nil
+}
+
+// GetAccount return the account of addr with its Stake 2.0 fields
+func (c *TronCli) GetAccount(ctx context.Context, addr string) (*core.Account, *pb.AccountStakeV2, error) {
+	account := new(core.Account)
+	var err error
+	if account.Address, err = common.DecodeCheck(addr); err != nil {
+		return nil, nil, err
+	}
+	ctx, cancel := c.getContext(ctx)
+	defer cancel()
+
+	acc, err := c.TronWalletCli.GetAccount(ctx, account)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !bytes.Equal(acc.Address, account.Address) {
+		return nil, nil, fmt.Errorf("account not found")
+	}
+	stake := new(pb.AccountStakeV2)
+	if err := proto.Unmarshal(acc.ProtoReflect().GetUnknown(), stake); err != nil {
+		return nil, nil, err
+	}
+	return acc, stake, nil
+}
+
+// GetDelegatedResourceAccountIndexV2 return the accounts addr delegates Stake 2.0 resources to and from
+func (c *TronCli) GetDelegatedResourceAccountIndexV2(ctx context.Context, addr string) (*core.DelegatedResourceAccountIndex, error) {
+	a, err := common.DecodeCheck(addr)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.getContext(ctx)
+	defer cancel()
+
+	index := new(core.DelegatedResourceAccountIndex)
+	if err := c.Conn.Invoke(ctx, walletMethodPrefix+"GetDelegatedResourceAccountIndexV2", &api.BytesMessage{Value: a}, index); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// GetDelegatedResourceV2 return the Stake 2.0 resources from delegates to to
+func (c *TronCli) GetDelegatedResourceV2(ctx context.Context, from, to []byte) ([]*core.DelegatedResource, error) {
+	ctx, cancel := c.getContext(ctx)
+	defer cancel()
+
+	list := new(api.DelegatedResourceList)
+	if err := c.Conn.Invoke(ctx, walletMethodPrefix+"GetDelegatedResourceV2", &api.DelegatedResourceMessage{FromAddress: from, ToAddress: to}, list); err != nil {
+		return nil, err
+	}
+	return list.GetDelegatedResource(), nil
 }
 
 // TRC20Call make cosntant calll
-func (c *TronCli) TRC20Call(from, contractAddress, data string, constant bool, feeLimit int64) (*api.TransactionExtention, error) {
+func (c *TronCli) TRC20Call(ctx context.Context, from, contractAddress, data string, constant bool, feeLimit int64) (*api.TransactionExtention, error) {
+	ct, err := trc20Contract(from, contractAddress, data)
+	if err != nil {
+		return nil, err
+	}
+	result := &api.TransactionExtention{}
+	if constant {
+		result, err = c.triggerConstantContract(ctx, ct)
+
+	} else {
+		result, err = c.triggerContract(ctx, ct, feeLimit)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if result.Result.Code > 0 {
+		return result, fmt.Errorf(string(result.Result.Message))
+	}
+	return result, nil
+
+}
+
+// trc20Contract build a call of contractAddress from from, an empty from is the zero address
+func trc20Contract(from, contractAddress, data string) (*core.TriggerSmartContract, error) {
 	var err error
 	fromDesc := address.HexToAddress("410000000000000000000000000000000000000000")
 	if len(from) > 0 {
@@ -116,39 +660,65 @@ func (c *TronCli) TRC20Call(from, contractAddress, data string, constant bool, f
 	if err != nil {
 		return nil, err
 	}
-	ct := &core.TriggerSmartContract{
+	return &core.TriggerSmartContract{
 		OwnerAddress:    fromDesc.Bytes(),
 		ContractAddress: contractDesc.Bytes(),
 		Data:            dataBytes,
-	}
-	result := &api.TransactionExtention{}
-	if constant {
-		result, err = c.triggerConstantContract(ct)
+	}, nil
+}
 
-	} else {
-		result, err = c.triggerContract(ct, feeLimit)
+// trc20BalanceOfData return the call data of balanceOf(addr)
+func trc20BalanceOfData(addr string) (string, error) {
+	addrB, err := address.Base58ToAddress(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid address %s: %v", addr, addr)
 	}
+	return trc20BalanceOf + "0000000000000000000000000000000000000000000000000000000000000000"[len(addrB.Hex())-2:] + addrB.Hex()[2:], nil
+}
+
+// TRC20ContractBalance get Address balance
+func (c *TronCli) TRC20ContractBalance(ctx context.Context, addr, contractAddress string) (*big.Int, error) {
+	req, err := trc20BalanceOfData(addr)
 	if err != nil {
 		return nil, err
 	}
-	if result.Result.Code > 0 {
-		return result, fmt.Errorf(string(result.Result.Message))
+	result, err := c.TRC20Call(ctx, "", contractAddress, req, true, 0)
+	if err != nil {
+		return nil, err
 	}
-	return result, nil
-
+	return c.parseTRC20Balance(result, addr, contractAddress)
 }
 
-// TRC20ContractBalance get Address balance
-func (c *TronCli) TRC20ContractBalance(addr, contractAddress string) (*big.Int, error) {
-	addrB, err := address.Base58ToAddress(addr)
+// GetSolidTRC20Balance is TRC20ContractBalance as of the latest solidified block
+func (c *TronCli) GetSolidTRC20Balance(ctx context.Context, addr, contractAddress string) (*big.Int, error) {
+	if !c.HasSolidityNodes() {
+		return nil, ErrNoSolidityNode
+	}
+	req, err := trc20BalanceOfData(addr)
+	if err != nil {
+		return nil, err
+	}
+	ct, err := trc20Contract("", contractAddress, req)
 	if err != nil {
-		return nil, fmt.Errorf("invalid address %s: %v", addr, addr)
+		return nil, err
 	}
-	req := trc20BalanceOf + "0000000000000000000000000000000000000000000000000000000000000000"[len(addrB.Hex())-2:] + addrB.Hex()[2:]
-	result, err := c.TRC20Call("", contractAddress, req, true, 0)
+	ctx, cancel := c.getContext(ctx)
+	defer cancel()
+
+	result, err := c.TronSolidityCli.TriggerConstantContract(ctx, ct)
 	if err != nil {
 		return nil, err
 	}
+	if result.GetResult().GetCode() > 0 {
+		return nil, fmt.Errorf(string(result.GetResult().GetMessage()))
+	}
+	return c.parseTRC20Balance(result, addr, contractAddress)
+}
+
+func (c *TronCli) parseTRC20Balance(result *api.TransactionExtention, addr, contractAddress string) (*big.Int, error) {
+	if len(result.GetConstantResult()) == 0 {
+		return nil, fmt.Errorf("contract address %s: no result", contractAddress)
+	}
 	data := common.ToHex(result.GetConstantResult()[0])
 	r, err := c.ParseTRC20NumericProperty(data)
 	if err != nil {
@@ -161,15 +731,32 @@ func (c *TronCli) TRC20ContractBalance(addr, contractAddress string) (*big.Int,
 }
 
 // TRC20Send send toke to address
-func (c *TronCli) TRC20Send(from, to, contract string, amount *big.Int, feeLimit int64) (*api.TransactionExtention, error) {
-	addrB, err := address.Base58ToAddress(to)
+func (c *TronCli) TRC20Send(ctx context.Context, from, to, contract string, amount *big.Int, feeLimit int64) (*api.TransactionExtention, error) {
+	req, err := trc20TransferData(to, amount)
+	if err != nil {
+		return nil, err
+	}
+	return c.TRC20Call(ctx, from, contract, req, false, feeLimit)
+}
+
+// TRC20EstimateSend simulate a token transfer, the result carries the energy it consumes
+func (c *TronCli) TRC20EstimateSend(ctx context.Context, from, to, contract string, amount *big.Int) (*api.TransactionExtention, error) {
+	req, err := trc20TransferData(to, amount)
 	if err != nil {
 		return nil, err
 	}
+	return c.TRC20Call(ctx, from, contract, req, true, 0)
+}
+
+func trc20TransferData(to string, amount *big.Int) (string, error) {
+	addrB, err := address.Base58ToAddress(to)
+	if err != nil {
+		return "", err
+	}
 	ab := common.LeftPadBytes(amount.Bytes(), 32)
 	req := trc20TransferMethodSignature + "0000000000000000000000000000000000000000000000000000000000000000"[len(addrB.Hex())-4:] + addrB.Hex()[4:]
 	req += common.Bytes2Hex(ab)
-	return c.TRC20Call(from, contract, req, false, feeLimit)
+	return req, nil
 }
 
 // ParseTRC20NumericProperty get number from data
@@ -220,37 +807,55 @@ func (g *TronCli) ParseTRC20StringProperty(data string) (string, error) {
 }
 
 // TRC20GetName get token name
-func (c *TronCli) TRC20GetName(contractAddress string) (string, error) {
-	result, err := c.TRC20Call("", contractAddress, trc20NameSignature, true, 0)
+func (c *TronCli) TRC20GetName(ctx context.Context, contractAddress string) (string, error) {
+	result, err := c.TRC20Call(ctx, "", contractAddress, trc20NameSignature, true, 0)
+	if err != nil {
+		return "", err
+	}
+	data, err := constantResult(result, contractAddress)
 	if err != nil {
 		return "", err
 	}
-	data := common.ToHex(result.GetConstantResult()[0])
 	return c.ParseTRC20StringProperty(data)
 }
 
 // TRC20GetSymbol get contract symbol
-func (c *TronCli) TRC20GetSymbol(contractAddress string) (string, error) {
-	result, err := c.TRC20Call("", contractAddress, trc20SymbolSignature, true, 0)
+func (c *TronCli) TRC20GetSymbol(ctx context.Context, contractAddress string) (string, error) {
+	result, err := c.TRC20Call(ctx, "", contractAddress, trc20SymbolSignature, true, 0)
+	if err != nil {
+		return "", err
+	}
+	data, err := constantResult(result, contractAddress)
 	if err != nil {
 		return "", err
 	}
-	data := common.ToHex(result.GetConstantResult()[0])
 	return c.ParseTRC20StringProperty(data)
 }
 
 // TRC20GetDecimals get contract decimals
-func (c *TronCli) TRC20GetDecimals(contractAddress string) (*big.Int, error) {
-	result, err := c.TRC20Call("", contractAddress, trc20DecimalsSignature, true, 0)
+func (c *TronCli) TRC20GetDecimals(ctx context.Context, contractAddress string) (*big.Int, error) {
+	result, err := c.TRC20Call(ctx, "", contractAddress, trc20DecimalsSignature, true, 0)
+	if err != nil {
+		return nil, err
+	}
+	data, err := constantResult(result, contractAddress)
 	if err != nil {
 		return nil, err
 	}
-	data := common.ToHex(result.GetConstantResult()[0])
 	return c.ParseTRC20NumericProperty(data)
 }
 
+// constantResult return the hex of what a constant call returned, an error when it returned
+// nothing, e.g. the address is not a contract or has no such method
+func constantResult(result *api.TransactionExtention, contractAddress string) (string, error) {
+	if len(result.GetConstantResult()) == 0 || len(result.GetConstantResult()[0]) == 0 {
+		return "", fmt.Errorf("contract address %s: no result", contractAddress)
+	}
+	return common.ToHex(result.GetConstantResult()[0]), nil
+}
+
 // TriggerConstantContract and return tx result
-func (c *TronCli) TriggerConstantContract(from, contractAddress, method, jsonString string) (*api.TransactionExtention, error) {
+func (c *TronCli) TriggerConstantContract(ctx context.Context, from, contractAddress, method, jsonString string) (*api.TransactionExtention, error) {
 	var err error
 	fromDesc := address.HexToAddress("410000000000000000000000000000000000000000")
 	if len(from) > 0 {
@@ -280,19 +885,19 @@ func (c *TronCli) TriggerConstantContract(from, contractAddress, method, jsonStr
 		Data:            dataBytes,
 	}
 
-	return c.triggerConstantContract(ct)
+	return c.triggerConstantContract(ctx, ct)
 }
 
 // triggerConstantContract and return tx result
-func (c *TronCli) triggerConstantContract(ct *core.TriggerSmartContract) (*api.TransactionExtention, error) {
-	ctx, cancel := c.getContext()
+func (c *TronCli) triggerConstantContract(ctx context.Context, ct *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	ctx, cancel := c.getContext(ctx)
 	defer cancel()
 
 	return c.TronWalletCli.TriggerConstantContract(ctx, ct)
 }
 
 // TriggerContract and return tx result
-func (c *TronCli) TriggerContract(from, contractAddress, method, jsonString string,
+func (c *TronCli) TriggerContract(ctx context.Context, from, contractAddress, method, jsonString string,
 	feeLimit, tAmount int64, tTokenID string, tTokenAmount int64) (*api.TransactionExtention, error) {
 	fromDesc, err := address.Base58ToAddress(from)
 	if err != nil {
@@ -330,12 +935,12 @@ func (c *TronCli) TriggerContract(from, contractAddress, method, jsonString stri
 		}
 	}
 
-	return c.triggerContract(ct, feeLimit)
+	return c.triggerContract(ctx, ct, feeLimit)
 }
 
 // triggerContract and return tx result
-func (c *TronCli) triggerContract(ct *core.TriggerSmartContract, feeLimit int64) (*api.TransactionExtention, error) {
-	ctx, cancel := c.getContext()
+func (c *TronCli) triggerContract(ctx context.Context, ct *core.TriggerSmartContract, feeLimit int64) (*api.TransactionExtention, error) {
+	ctx, cancel := c.getContext(ctx)
 	defer cancel()
 
 	tx, err := c.TronWalletCli.TriggerConstantContract(ctx, ct)
@@ -368,10 +973,8 @@ func (c *TronCli) UpdateHash(tx *api.TransactionExtention) error {
 	return nil
 }
 
-func (c *TronCli) getContext() (context.Context, context.CancelFunc) {
-	ctx, cancel := context.WithTimeout(context.Background(), c.GrpcTimeout)
-	if len(c.ApiKey) > 0 {
-		ctx = metadata.AppendToOutgoingContext(ctx, "TRON-PRO-API-KEY", c.ApiKey)
-	}
-	return ctx, cancel
+// getContext bound a call by GrpcTimeout, or the earlier deadline of ctx. The node pool
+// attaches the API key.
+func (c *TronCli) getContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, c.GrpcTimeout)
 }
@@ -0,0 +1,213 @@
+package biz
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"go.uber.org/zap"
+)
+
+const (
+	energyTopUpActor = "energy_topup"
+
+	AuditActionDelegate       = "delegate"
+	AuditActionDelegateFailed = "delegate_failed"
+	AuditActionPoolExhausted  = "pool_exhausted"
+
+	sunPerTRX = 1000000
+	// minDelegateSun is the smallest delegation java-tron accepts
+	minDelegateSun = sunPerTRX
+)
+
+// EnergyController keeps hot wallets supplied with energy delegated from staking accounts,
+// so transfers do not burn TRX
+type EnergyController struct {
+	uc    *TrxUsecase
+	audit AuditRepo
+	log   *zap.Logger
+	now   func() time.Time
+
+	mu         sync.Mutex
+	cfg        setting.EnergyTopUp
+	lastAction map[string]time.Time // last delegation per hot wallet
+	lastAlert  map[string]time.Time
+}
+
+// NewEnergyController new an energy top-up controller, its policy follows config reloads.
+func NewEnergyController(uc *TrxUsecase, audit AuditRepo, cfg *setting.Config, logger *zap.Logger) *EnergyController {
+	c := &EnergyController{
+		uc:         uc,
+		audit:      audit,
+		log:        logger,
+		now:        time.Now,
+		lastAction: make(map[string]time.Time),
+		lastAlert:  make(map[string]time.Time),
+	}
+	c.reload(cfg)
+	setting.OnChange(c.reload)
+	return c
+}
+
+// reload copy the policy, the watcher rewrites cfg in place
+func (c *EnergyController) reload(cfg *setting.Config) {
+	policy := cfg.EnergyTopUp
+	policy.Policies = append([]setting.EnergyPolicy(nil), cfg.EnergyTopUp.Policies...)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cfg = policy
+}
+
+func (c *EnergyController) config() setting.EnergyTopUp {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cfg
+}
+
+// Run check the hot wallets until ctx is done, rounds are skipped while switched off
+func (c *EnergyController) Run(ctx context.Context) error {
+	for {
+		if cfg := c.config(); cfg.Enable {
+			for _, p := range cfg.Policies {
+				if ctx.Err() != nil {
+					return nil
+				}
+				if err := c.check(ctx, p); err != nil {
+					c.log.Sugar().Errorw("EnergyController", "address", p.Address, "err", err)
+				}
+			}
+		}
+		interval := time.Duration(c.config().Interval) * time.Second
+		if interval <= 0 {
+			interval = 30 * time.Second
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// check top up the energy of p.Address if it dropped below the threshold
+func (c *EnergyController) check(ctx context.Context, p setting.EnergyPolicy) error {
+	cfg := c.config()
+	now := c.now()
+	c.mu.Lock()
+	last := c.lastAction[p.Address]
+	c.mu.Unlock()
+	// a delegation shows in the limits only once included, do not delegate twice meanwhile
+	if now.Sub(last) < seconds(cfg.Cooldown, time.Minute) {
+		return nil
+	}
+
+	res, err := c.uc.cli.GetAccountResource(ctx, p.Address)
+	if err != nil {
+		return err
+	}
+	available := nonNegative(res.GetEnergyLimit() - res.GetEnergyUsed())
+	if available >= p.Threshold {
+		return nil
+	}
+	target := p.Target
+	if target <= p.Threshold {
+		target = 2 * p.Threshold
+	}
+	amount, err := stakeForEnergy(target-available, res.GetTotalEnergyLimit(), res.GetTotalEnergyWeight())
+	if err != nil {
+		return err
+	}
+	detail := map[string]interface{}{
+		"available":       available,
+		"threshold":       p.Threshold,
+		"target":          target,
+		"staking_account": p.StakingAccount,
+		"amount":          amount,
+	}
+
+	_, stake, err := c.uc.cli.GetAccount(ctx, p.StakingAccount)
+	if err != nil {
+		return err
+	}
+	var pool int64
+	for _, f := range stake.GetFrozenV2() {
+		if core.ResourceCode(f.GetType()) == core.ResourceCode_ENERGY {
+			pool += f.GetAmount()
+		}
+	}
+	if pool < amount {
+		detail["pool"] = pool
+		c.alert(ctx, p, cfg, detail)
+		return nil
+	}
+
+	tx, err := c.uc.DelegateResource(ctx, p.StakingAccount, p.Address, amount, core.ResourceCode_ENERGY, p.Lock)
+	if err != nil {
+		detail["err"] = err.Error()
+		c.record(ctx, AuditActionDelegateFailed, p.Address, "", detail)
+		return err
+	}
+	c.mu.Lock()
+	c.lastAction[p.Address] = now
+	c.mu.Unlock()
+	txid := hex.EncodeToString(tx.Txid)
+	c.log.Sugar().Infow("energy delegated", "address", p.Address, "from", p.StakingAccount, "amount", amount, "available", available, "txid", txid)
+	c.record(ctx, AuditActionDelegate, p.Address, txid, detail)
+	return nil
+}
+
+// alert report an exhausted staking pool, at most once per AlertInterval for an address
+func (c *EnergyController) alert(ctx context.Context, p setting.EnergyPolicy, cfg setting.EnergyTopUp, detail map[string]interface{}) {
+	now := c.now()
+	c.mu.Lock()
+	if now.Sub(c.lastAlert[p.Address]) < seconds(cfg.AlertInterval, 10*time.Minute) {
+		c.mu.Unlock()
+		return
+	}
+	c.lastAlert[p.Address] = now
+	c.mu.Unlock()
+	c.log.Sugar().Errorw("ALERT staking pool exhausted, energy not topped up", "address", p.Address, "staking_account", p.StakingAccount,
+		"pool", detail["pool"], "amount", detail["amount"])
+	c.record(ctx, AuditActionPoolExhausted, p.Address, "", detail)
+}
+
+// record write an audit log entry, failing to do so does not undo the action
+func (c *EnergyController) record(ctx context.Context, action, addr, txid string, detail map[string]interface{}) {
+	b, _ := json.Marshal(detail)
+	l := &AuditLog{Actor: energyTopUpActor, Action: action, Address: addr, Txid: txid, Detail: string(b)}
+	if err := c.audit.CreateAuditLog(ctx, l); err != nil {
+		c.log.Sugar().Errorw("CreateAuditLog", "action", action, "address", addr, "txid", txid, "err", err)
+	}
+}
+
+// stakeForEnergy return the SUN to delegate for energy, the network shares totalLimit energy
+// among totalWeight staked TRX
+func stakeForEnergy(energy, totalLimit, totalWeight int64) (int64, error) {
+	if totalLimit <= 0 || totalWeight <= 0 {
+		return 0, fmt.Errorf("invalid network energy limit %d or weight %d", totalLimit, totalWeight)
+	}
+	n := new(big.Int).Mul(big.NewInt(energy), big.NewInt(totalWeight))
+	n.Mul(n, big.NewInt(sunPerTRX))
+	limit := big.NewInt(totalLimit)
+	n.Add(n, new(big.Int).Sub(limit, big.NewInt(1))).Quo(n, limit)
+	if !n.IsInt64() {
+		return 0, fmt.Errorf("energy %d out of range", energy)
+	}
+	if n.Int64() < minDelegateSun {
+		return minDelegateSun, nil
+	}
+	return n.Int64(), nil
+}
+
+func seconds(n int, fallback time.Duration) time.Duration {
+	if n <= 0 {
+		return fallback
+	}
+	return time.Duration(n) * time.Second
+}
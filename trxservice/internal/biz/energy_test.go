@@ -0,0 +1,148 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	pb "github.com/leondevpt/wallet/trxservice/api/v1"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"go.uber.org/zap"
+)
+
+type memAuditRepo struct {
+	mu   sync.Mutex
+	logs []*AuditLog
+}
+
+func (r *memAuditRepo) CreateAuditLog(ctx context.Context, l *AuditLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	l.ID = int64(len(r.logs) + 1)
+	r.logs = append(r.logs, l)
+	return nil
+}
+
+func TestEnergyController(t *testing.T) {
+	hot, pool := newTestAddress(t), newTestAddress(t)
+	w := &standInWallet{
+		stake: &pb.AccountStakeV2{FrozenV2: []*pb.AccountStakeV2_FreezeV2{
+			{Type: int32(core.ResourceCode_ENERGY), Amount: 10000 * sunPerTRX},
+		}},
+		// 10 energy per staked TRX, 20000 of 65000 left
+		resources: map[string]*api.AccountResourceMessage{string(hot.Bytes()): {
+			EnergyLimit: 65000, EnergyUsed: 45000, TotalEnergyLimit: 1000, TotalEnergyWeight: 100,
+		}},
+	}
+	uc := newTestStakeUsecase(t, w)
+	audit := &memAuditRepo{}
+	cfg := &setting.Config{}
+	cfg.EnergyTopUp.Policies = []setting.EnergyPolicy{{
+		Address: hot.String(), StakingAccount: pool.String(), Threshold: 30000, Target: 80000,
+	}}
+	c := NewEnergyController(uc, audit, cfg, zap.NewNop())
+	now := time.Unix(1666000000, 0)
+	c.now = func() time.Time { return now }
+	check := func() {
+		t.Helper()
+		for _, p := range c.config().Policies {
+			if err := c.check(context.Background(), p); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// below the threshold, delegate 60000 energy worth of stake
+	check()
+	if len(w.created) != 1 || len(w.broadcast) != 1 || w.created[0].Type != DelegateResourceContractType {
+		t.Fatalf("created %d, broadcast %d", len(w.created), len(w.broadcast))
+	}
+	param, err := unmarshalContractParameter(w.created[0].Parameter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	delegate := param.(*pb.DelegateResourceContract)
+	if delegate.Balance != 6000*sunPerTRX || delegate.Resource != int32(core.ResourceCode_ENERGY) {
+		t.Fatalf("delegate %v", delegate)
+	}
+	if len(audit.logs) != 1 || audit.logs[0].Action != AuditActionDelegate || audit.logs[0].Txid == "" {
+		t.Fatalf("audit %+v", audit.logs)
+	}
+
+	// within the cooldown the limits may not show the delegation yet
+	check()
+	if len(w.created) != 1 {
+		t.Fatalf("delegated again within the cooldown")
+	}
+
+	// the reloaded policy wants more than the pool holds
+	now = now.Add(2 * time.Minute)
+	cfg.EnergyTopUp.Policies[0].Target = 1100000
+	c.reload(cfg)
+	check()
+	check()
+	if len(w.created) != 1 {
+		t.Fatalf("delegated from an exhausted pool")
+	}
+	if len(audit.logs) != 2 || audit.logs[1].Action != AuditActionPoolExhausted {
+		t.Fatalf("audit %+v, want one alert", audit.logs)
+	}
+	var detail struct{ Pool, Amount int64 }
+	if err := json.Unmarshal([]byte(audit.logs[1].Detail), &detail); err != nil {
+		t.Fatal(err)
+	}
+	if detail.Pool != 10000*sunPerTRX || detail.Amount != 108000*sunPerTRX {
+		t.Fatalf("alert detail %s", audit.logs[1].Detail)
+	}
+}
+
+func TestEnergyControllerFollowsEnable(t *testing.T) {
+	hot, pool := newTestAddress(t), newTestAddress(t)
+	w := &standInWallet{
+		stake: &pb.AccountStakeV2{FrozenV2: []*pb.AccountStakeV2_FreezeV2{
+			{Type: int32(core.ResourceCode_ENERGY), Amount: 10000 * sunPerTRX},
+		}},
+		resources: map[string]*api.AccountResourceMessage{string(hot.Bytes()): {
+			EnergyLimit: 65000, EnergyUsed: 45000, TotalEnergyLimit: 1000, TotalEnergyWeight: 100,
+		}},
+	}
+	audit := &memAuditRepo{}
+	cfg := &setting.Config{}
+	cfg.EnergyTopUp.Interval = 1
+	cfg.EnergyTopUp.Policies = []setting.EnergyPolicy{{
+		Address: hot.String(), StakingAccount: pool.String(), Threshold: 30000, Target: 80000,
+	}}
+	c := NewEnergyController(newTestStakeUsecase(t, w), audit, cfg, zap.NewNop())
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		_ = c.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+	delegated := func() int {
+		audit.mu.Lock()
+		defer audit.mu.Unlock()
+		return len(audit.logs)
+	}
+
+	// started switched off, it acts once a reload switches it on
+	time.Sleep(100 * time.Millisecond)
+	if n := delegated(); n != 0 {
+		t.Fatalf("%d actions while disabled", n)
+	}
+	cfg.EnergyTopUp.Enable = true
+	c.reload(cfg)
+	for deadline := time.Now().Add(3 * time.Second); delegated() == 0; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("no top-up after enabling")
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package biz
+
+import "sync"
+
+// EventBus fans out tx events to in-process subscribers. Events are persisted
+// before they are published, consumers that must not miss any replay them from the repo.
+type EventBus struct {
+	mu   sync.RWMutex
+	next int
+	subs map[int]func(e *TxEvent)
+}
+
+// NewEventBus new a tx event bus.
+func NewEventBus() *EventBus {
+	return &EventBus{subs: make(map[int]func(e *TxEvent))}
+}
+
+// Subscribe register fn for every event published after the call and return a
+// func to unsubscribe. fn runs on the publisher's goroutine and must not block.
+func (b *EventBus) Subscribe(fn func(e *TxEvent)) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := b.next
+	b.next++
+	b.subs[id] = fn
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subs, id)
+	}
+}
+
+// Publish deliver events to all subscribers in order
+func (b *EventBus) Publish(events ...*TxEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, e := range events {
+		for _, fn := range b.subs {
+			fn(e)
+		}
+	}
+}
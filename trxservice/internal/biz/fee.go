@@ -0,0 +1,129 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// maxResultSizeInTx is what java-tron adds to the size of a tx for its result
+	maxResultSizeInTx = 64
+	signatureSize     = 65
+	// feeLimitMargin is the extra percent of energy cost a fee limit allows for
+	feeLimitMargin = 20
+
+	paramEnergyFee              = "getEnergyFee"
+	paramTransactionFee         = "getTransactionFee"
+	paramCreateAccountFee       = "getCreateAccountFee"
+	paramCreateNewAccountFeeSys = "getCreateNewAccountFeeInSystemContract"
+)
+
+// ErrFeeLimitExceeded is returned when the estimated fee limit is above the configured ceiling
+var ErrFeeLimitExceeded = errors.New("estimated fee limit exceeds the ceiling")
+
+// FeeEstimate is the expected cost of a transfer, amounts in SUN
+type FeeEstimate struct {
+	Energy          int64 // energy the contract call consumes, 0 for TRX
+	Bandwidth       int64 // bytes of the signed transaction
+	FreeBandwidth   int64 // daily free bandwidth left to the sender
+	StakedBandwidth int64 // bandwidth from staked TRX left to the sender
+	StakedEnergy    int64 // energy from staked TRX left to the sender
+	EnergyFee       int64 // SUN per energy
+	NewAccount      bool  // the recipient is not activated, the transfer pays for activation
+	Burn            int64 // TRX burned for what staked and free resources do not cover
+	FeeLimit        int64 // safe fee limit of the contract call, 0 for TRX
+}
+
+// EstimateFee estimate the cost of sending amount from from to to. contractAddr is
+// empty for TRX, amount is in SUN or in the token's smallest unit.
+func (t *TrxUsecase) EstimateFee(ctx context.Context, from, to, contractAddr string, amount *big.Int) (*FeeEstimate, error) {
+	params, err := t.cli.GetChainParameters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range []string{paramEnergyFee, paramTransactionFee} {
+		if _, ok := params[key]; !ok {
+			return nil, fmt.Errorf("chain parameter %s not found", key)
+		}
+	}
+	res, err := t.cli.GetAccountResource(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	est := &FeeEstimate{
+		FreeBandwidth:   nonNegative(res.GetFreeNetLimit() - res.GetFreeNetUsed()),
+		StakedBandwidth: nonNegative(res.GetNetLimit() - res.GetNetUsed()),
+		StakedEnergy:    nonNegative(res.GetEnergyLimit() - res.GetEnergyUsed()),
+		EnergyFee:       params[paramEnergyFee],
+	}
+
+	if contractAddr == "" {
+		if !amount.IsInt64() {
+			return nil, fmt.Errorf("invalid amount %s", amount)
+		}
+		tx, err := t.cli.Transfer(ctx, from, to, amount.Int64())
+		if err != nil {
+			return nil, err
+		}
+		exists, err := t.cli.AccountExists(ctx, to)
+		if err != nil {
+			return nil, err
+		}
+		est.NewAccount = !exists
+		est.Bandwidth = txBandwidth(tx.Transaction.GetRawData())
+	} else {
+		tx, err := t.cli.TRC20EstimateSend(ctx, from, to, contractAddr, amount)
+		if err != nil {
+			return nil, err
+		}
+		energy, ok := EnergyUsed(tx)
+		if !ok {
+			return nil, fmt.Errorf("node did not report the energy used by the call")
+		}
+		est.Energy = energy
+		est.FeeLimit = (energy*est.EnergyFee*(100+feeLimitMargin) + 99) / 100
+		// the broadcast transaction carries the fee limit
+		raw := proto.Clone(tx.Transaction.GetRawData()).(*core.TransactionRaw)
+		raw.FeeLimit = est.FeeLimit
+		est.Bandwidth = txBandwidth(raw)
+	}
+
+	est.Burn = est.bandwidthBurn(params) + nonNegative(est.Energy-est.StakedEnergy)*est.EnergyFee
+	return est, nil
+}
+
+// bandwidthBurn follow java-tron's bandwidth processor: staked bandwidth first, then the
+// free allowance, otherwise every byte is paid in TRX. Activating an account can not use
+// the free allowance and costs a flat fee instead.
+func (e *FeeEstimate) bandwidthBurn(params map[string]int64) int64 {
+	switch {
+	case e.NewAccount:
+		burn := params[paramCreateNewAccountFeeSys]
+		if e.StakedBandwidth < e.Bandwidth {
+			burn += params[paramCreateAccountFee]
+		}
+		return burn
+	case e.StakedBandwidth >= e.Bandwidth, e.FreeBandwidth >= e.Bandwidth:
+		return 0
+	default:
+		return e.Bandwidth * params[paramTransactionFee]
+	}
+}
+
+// txBandwidth return the bandwidth bytes of a transaction with raw data raw once signed by one key
+func txBandwidth(raw *core.TransactionRaw) int64 {
+	signed := &core.Transaction{RawData: raw, Signature: [][]byte{make([]byte, signatureSize)}}
+	return int64(proto.Size(signed)) + maxResultSizeInTx
+}
+
+func nonNegative(n int64) int64 {
+	if n < 0 {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,165 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	protov1 "github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// fakeFeeNode is a WalletClient answering the calls a fee estimate makes
+type fakeFeeNode struct {
+	api.WalletClient
+	resource  *api.AccountResourceMessage
+	activated map[string]bool
+	energy    int64 // reported by constant calls
+	balance   int64 // TRC20 balance of every address
+	broadcast *core.Transaction
+}
+
+func (n *fakeFeeNode) GetChainParameters(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*core.ChainParameters, error) {
+	return &core.ChainParameters{ChainParameter: []*core.ChainParameters_ChainParameter{
+		{Key: paramEnergyFee, Value: 420},
+		{Key: paramTransactionFee, Value: 1000},
+		{Key: paramCreateAccountFee, Value: 100000},
+		{Key: paramCreateNewAccountFeeSys, Value: 1000000},
+	}}, nil
+}
+
+func (n *fakeFeeNode) GetAccountResource(ctx context.Context, in *core.Account, opts ...grpc.CallOption) (*api.AccountResourceMessage, error) {
+	return n.resource, nil
+}
+
+func (n *fakeFeeNode) GetAccount(ctx context.Context, in *core.Account, opts ...grpc.CallOption) (*core.Account, error) {
+	if n.activated[string(in.Address)] {
+		return &core.Account{Address: in.Address}, nil
+	}
+	return &core.Account{}, nil
+}
+
+func (n *fakeFeeNode) CreateTransaction2(ctx context.Context, in *core.TransferContract, opts ...grpc.CallOption) (*api.TransactionExtention, error) {
+	return &api.TransactionExtention{Transaction: newUnsignedTx(core.Transaction_Contract_TransferContract, in), Result: &api.Return{Result: true}}, nil
+}
+
+func (n *fakeFeeNode) TriggerConstantContract(ctx context.Context, in *core.TriggerSmartContract, opts ...grpc.CallOption) (*api.TransactionExtention, error) {
+	res := &api.TransactionExtention{
+		Transaction:    newUnsignedTx(core.Transaction_Contract_TriggerSmartContract, in),
+		ConstantResult: [][]byte{common.LeftPadBytes(big.NewInt(n.balance).Bytes(), 32)},
+		Result:         &api.Return{Result: true},
+	}
+	b := protowire.AppendTag(nil, txExtentionEnergyUsedField, protowire.VarintType)
+	res.ProtoReflect().SetUnknown(protowire.AppendVarint(b, uint64(n.energy)))
+	return res, nil
+}
+
+func (n *fakeFeeNode) BroadcastTransaction(ctx context.Context, in *core.Transaction, opts ...grpc.CallOption) (*api.Return, error) {
+	n.broadcast = in
+	return &api.Return{Result: true}, nil
+}
+
+func newUnsignedTx(typ core.Transaction_Contract_ContractType, c protov1.Message) *core.Transaction {
+	param, _ := anypb.New(protov1.MessageV2(c))
+	return &core.Transaction{RawData: &core.TransactionRaw{
+		Contract:   []*core.Transaction_Contract{{Type: typ, Parameter: param}},
+		Expiration: 1666000060000,
+		Timestamp:  1666000000000,
+	}}
+}
+
+type nopSigner struct{}
+
+func (nopSigner) Sign(ctx context.Context, tx *core.Transaction) (*core.Transaction, error) {
+	tx.Signature = [][]byte{bytes.Repeat([]byte{1}, signatureSize)}
+	return tx, nil
+}
+
+func newTestFeeUsecase(node *fakeFeeNode) *TrxUsecase {
+	cli := &TronCli{TronWalletCli: node, GrpcTimeout: time.Second}
+	return NewTrxUsecase(newMemTrxRepo(), nil, zap.NewNop(), cli, nopSigner{}, NewEventBus(), nil)
+}
+
+func TestEstimateFeeTRX(t *testing.T) {
+	from, to := newTestAddress(t), newTestAddress(t)
+	node := &fakeFeeNode{
+		resource:  &api.AccountResourceMessage{FreeNetLimit: 1500, FreeNetUsed: 1400},
+		activated: map[string]bool{},
+	}
+	uc := newTestFeeUsecase(node)
+
+	// the free allowance left is too small, the recipient must be activated
+	est, err := uc.EstimateFee(context.Background(), from.String(), to.String(), "", big.NewInt(1000000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !est.NewAccount || est.Energy != 0 || est.FeeLimit != 0 || est.FreeBandwidth != 100 {
+		t.Fatalf("estimate %+v", est)
+	}
+	if est.Burn != 1100000 {
+		t.Fatalf("burn %d, want activation fees 1100000", est.Burn)
+	}
+
+	node.activated[string(to.Bytes())] = true
+	est, err = uc.EstimateFee(context.Background(), from.String(), to.String(), "", big.NewInt(1000000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if est.Bandwidth <= est.FreeBandwidth || est.Burn != est.Bandwidth*1000 {
+		t.Fatalf("estimate %+v, want every byte burned", est)
+	}
+
+	node.resource.FreeNetUsed = 0
+	if est, err = uc.EstimateFee(context.Background(), from.String(), to.String(), "", big.NewInt(1000000)); err != nil || est.Burn != 0 {
+		t.Fatalf("estimate %+v %v, want free bandwidth to cover it", est, err)
+	}
+}
+
+func TestEstimateFeeTRC20(t *testing.T) {
+	from, to, usdt := newTestAddress(t), newTestAddress(t), newTestAddress(t)
+	node := &fakeFeeNode{
+		resource: &api.AccountResourceMessage{NetLimit: 1000, EnergyLimit: 10000, EnergyUsed: 2000},
+		energy:   29631,
+		balance:  100,
+	}
+	uc := newTestFeeUsecase(node)
+
+	est, err := uc.EstimateFee(context.Background(), from.String(), to.String(), usdt.String(), big.NewInt(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if est.Energy != 29631 || est.StakedEnergy != 8000 || est.StakedBandwidth != 1000 {
+		t.Fatalf("estimate %+v", est)
+	}
+	// staked bandwidth covers the bytes
+	if want := int64((29631 - 8000) * 420); est.Burn != want {
+		t.Fatalf("burn %d, want %d", est.Burn, want)
+	}
+	if want := (int64(29631)*420*120 + 99) / 100; est.FeeLimit != want {
+		t.Fatalf("fee limit %d, want %d", est.FeeLimit, want)
+	}
+
+	// without a fee limit the transfer uses the estimate, within the ceiling
+	tx, err := uc.TransferTRC20(context.Background(), "USDT", from.String(), to.String(), usdt.String(), big.NewInt(100), 0, 30000000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := node.broadcast.GetRawData().GetFeeLimit(); got != est.FeeLimit || tx.Transaction != node.broadcast {
+		t.Fatalf("broadcast fee limit %d, want %d", got, est.FeeLimit)
+	}
+
+	node.broadcast = nil
+	_, err = uc.TransferTRC20(context.Background(), "USDT", from.String(), to.String(), usdt.String(), big.NewInt(100), 0, 10000000)
+	if !errors.Is(err, ErrFeeLimitExceeded) || node.broadcast != nil {
+		t.Fatalf("err %v, want %v before broadcast", err, ErrFeeLimitExceeded)
+	}
+}
@@ -0,0 +1,113 @@
+package biz
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+)
+
+const (
+	bip44Purpose = 44
+	tronCoinType = 195
+)
+
+// HDWallet derives TRON addresses along m/44'/195'/account'/0/index
+type HDWallet struct {
+	master   *hdkeychain.ExtendedKey
+	accounts map[uint32]*hdkeychain.ExtendedKey
+}
+
+// NewHDWallet load extended keys from config. MasterKey is an xprv at m and can
+// derive any account, AccountKeys are xpub/xprv at m/44'/195'/account'.
+func NewHDWallet(cfg *setting.Config) (*HDWallet, error) {
+	w := &HDWallet{accounts: make(map[uint32]*hdkeychain.ExtendedKey)}
+	if cfg.HDWallet.MasterKey != "" {
+		key, err := hdkeychain.NewKeyFromString(cfg.HDWallet.MasterKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid master key: %v", err)
+		}
+		if !key.IsPrivate() || key.Depth() != 0 {
+			return nil, fmt.Errorf("master key must be a root xprv")
+		}
+		w.master = key
+	}
+	for k, v := range cfg.HDWallet.AccountKeys {
+		account, err := strconv.ParseUint(k, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid account %s: %v", k, err)
+		}
+		key, err := hdkeychain.NewKeyFromString(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key of account %s: %v", k, err)
+		}
+		if key.Depth() != 3 {
+			return nil, fmt.Errorf("key of account %s must be at m/44'/195'/account'", k)
+		}
+		w.accounts[uint32(account)] = key
+	}
+	return w, nil
+}
+
+// Path return the BIP44 path of a deposit address
+func (w *HDWallet) Path(account, index uint32) string {
+	return fmt.Sprintf("m/%d'/%d'/%d'/0/%d", bip44Purpose, tronCoinType, account, index)
+}
+
+// Derive the deposit address of account at index
+func (w *HDWallet) Derive(account, index uint32) (string, error) {
+	key, err := w.derive(account, index)
+	if err != nil {
+		return "", err
+	}
+	pub, err := key.ECPubKey()
+	if err != nil {
+		return "", err
+	}
+	return address.PubkeyToAddress(*pub.ToECDSA()).String(), nil
+}
+
+// Signer return a signer for the deposit address of account at index, the account
+// needs an xprv
+func (w *HDWallet) Signer(account, index uint32) (Signer, error) {
+	key, err := w.derive(account, index)
+	if err != nil {
+		return nil, err
+	}
+	priv, err := key.ECPrivKey()
+	if err != nil {
+		return nil, fmt.Errorf("no private key of account %d: %v", account, err)
+	}
+	return newKeySigner(priv.ToECDSA()), nil
+}
+
+func (w *HDWallet) derive(account, index uint32) (*hdkeychain.ExtendedKey, error) {
+	key, err := w.accountKey(account)
+	if err != nil {
+		return nil, err
+	}
+	// external chain
+	if key, err = key.Derive(0); err != nil {
+		return nil, err
+	}
+	return key.Derive(index)
+}
+
+func (w *HDWallet) accountKey(account uint32) (*hdkeychain.ExtendedKey, error) {
+	if key, ok := w.accounts[account]; ok {
+		return key, nil
+	}
+	if w.master == nil {
+		return nil, fmt.Errorf("no extended key configured for account %d", account)
+	}
+	key := w.master
+	var err error
+	for _, i := range []uint32{bip44Purpose, tronCoinType, account} {
+		if key, err = key.Derive(hdkeychain.HardenedKeyStart + i); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
@@ -0,0 +1,72 @@
+package biz
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/keys/hd"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+)
+
+func TestHDWalletDerive(t *testing.T) {
+	seed := bytes.Repeat([]byte{0x5a}, 32)
+	master, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accountKey := master
+	for _, i := range []uint32{44, 195, 0} {
+		if accountKey, err = accountKey.Derive(hdkeychain.HardenedKeyStart + i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	xpub, err := accountKey.Neuter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fromMaster, err := NewHDWallet(&setting.Config{HDWallet: setting.HDWallet{MasterKey: master.String()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromXpub, err := NewHDWallet(&setting.Config{HDWallet: setting.HDWallet{AccountKeys: map[string]string{"0": xpub.String()}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret, chainCode := hd.ComputeMastersFromSeed(seed, []byte("Bitcoin seed"))
+	for index := uint32(0); index < 3; index++ {
+		priv, err := hd.DerivePrivateKeyForPath(btcec.S256(), secret, chainCode, fmt.Sprintf("44'/195'/0'/0/%d", index))
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := crypto.ToECDSA(priv[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := address.PubkeyToAddress(key.PublicKey).String()
+
+		for name, w := range map[string]*HDWallet{"master": fromMaster, "xpub": fromXpub} {
+			got, err := w.Derive(0, index)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("%s: index %d got %s, want %s", name, index, got, want)
+			}
+		}
+	}
+
+	if _, err := fromXpub.Derive(1, 0); err == nil {
+		t.Error("expect error for account without extended key")
+	}
+	if got := fromMaster.Path(2, 7); got != "m/44'/195'/2'/0/7" {
+		t.Errorf("got path %s", got)
+	}
+}
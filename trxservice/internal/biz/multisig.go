@@ -0,0 +1,413 @@
+package biz
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/leondevpt/wallet/trxservice/pkg/errcode"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	multisigActor = "multisig"
+
+	AuditActionMultisigCreate   = "multisig_create"
+	AuditActionMultisigSign     = "multisig_sign"
+	AuditActionMultisigSend     = "multisig_send"
+	AuditActionMultisigExpiring = "multisig_expiring"
+	AuditActionMultisigExpired  = "multisig_expired"
+
+	// maxTxExpiration is the furthest java-tron accepts the expiration of a tx
+	maxTxExpiration = 24 * time.Hour
+)
+
+var (
+	// ErrMultisigClosed is returned when a signature is added to a transaction no longer collecting them
+	ErrMultisigClosed = errors.New("multisig transaction is not pending")
+	// ErrMultisigSigned is returned when a key signs a transaction twice
+	ErrMultisigSigned = errors.New("multisig transaction already signed by the key")
+	// ErrInvalidSignature is returned when a signature is not of a key of the permission
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+type MultisigStatus string
+
+const (
+	MultisigStatusPending  MultisigStatus = "pending"  // collecting signatures
+	MultisigStatusApproved MultisigStatus = "approved" // the threshold is reached, not broadcast yet
+	MultisigStatusSent     MultisigStatus = "sent"
+	MultisigStatusExpired  MultisigStatus = "expired"
+	MultisigStatusFailed   MultisigStatus = "failed" // rejected by the node
+)
+
+// MultisigTx is a transfer from an account with a weighted permission, sent once its
+// signatures reach the threshold of the permission
+type MultisigTx struct {
+	ID           int64           `gorm:"primaryKey" json:"id"`
+	Txid         string          `gorm:"size:64;not null;uniqueIndex" json:"txid"`
+	Owner        string          `gorm:"size:34;not null;index" json:"owner"`
+	PermissionID int32           `gorm:"not null" json:"permission_id"` // 0 owner, 2 and up active
+	To           string          `gorm:"size:34;not null" json:"to"`
+	Token        string          `gorm:"size:32;not null" json:"token"`
+	Contract     string          `gorm:"size:34" json:"contract"`                   // empty for TRX
+	Amount       decimal.Decimal `gorm:"type:decimal(65,0);not null" json:"amount"` // in the token's smallest unit
+	FeeLimit     int64           `gorm:"not null" json:"fee_limit"`                 // SUN, ceiling of the estimated fee limit
+	RawData      string          `gorm:"type:text;not null" json:"raw_data"`        // hex of the raw data the keys sign
+	Threshold    int64           `gorm:"not null" json:"threshold"`
+	Weight       int64           `gorm:"not null" json:"weight"` // of the signatures so far
+	Status       MultisigStatus  `gorm:"size:16;not null;index:idx_status_expires" json:"status"`
+	Expiring     bool            `gorm:"not null" json:"expiring"` // flagged as about to expire
+	ExpiresAt    time.Time       `gorm:"index:idx_status_expires" json:"expires_at"`
+	LastError    string          `gorm:"size:255" json:"last_error"`
+	Version      int64           `gorm:"not null" json:"-"` // bumped by every update
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// MultisigSignature is the signature of one key of the permission
+type MultisigSignature struct {
+	ID         int64     `gorm:"primaryKey" json:"id"`
+	MultisigID int64     `gorm:"not null;uniqueIndex:uk_multisig_signer" json:"multisig_id"`
+	Signer     string    `gorm:"size:34;not null;uniqueIndex:uk_multisig_signer" json:"signer"`
+	Signature  string    `gorm:"size:130;not null" json:"signature"` // hex
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// MultisigRepo persists multisig transactions and their signatures
+type MultisigRepo interface {
+	CreateMultisigTx(ctx context.Context, m *MultisigTx) error
+	// GetMultisigTx return a transaction and its signatures, ErrNotFound if there is none
+	GetMultisigTx(ctx context.Context, id int64) (*MultisigTx, []*MultisigSignature, error)
+	// ListMultisigTxs return up to limit transactions of owner, of every owner when it is
+	// empty, in one of statuses, newest first
+	ListMultisigTxs(ctx context.Context, owner string, statuses []MultisigStatus, limit int) ([]*MultisigTx, error)
+	// AddMultisigSignature store sig and the updated m atomically. It returns
+	// ErrMultisigSigned when the key signed already and ErrNotFound when m was updated
+	// since it was read.
+	AddMultisigSignature(ctx context.Context, m *MultisigTx, sig *MultisigSignature) error
+	// UpdateMultisigTx persist m if nobody updated it since it was read and bump its
+	// Version. It returns ErrNotFound otherwise.
+	UpdateMultisigTx(ctx context.Context, m *MultisigTx) error
+}
+
+// MultisigUsecase builds transfers of multisig accounts, collects the signatures of the
+// approvers and broadcasts them once the threshold is reached
+type MultisigUsecase struct {
+	uc    *TrxUsecase
+	repo  MultisigRepo
+	audit AuditRepo
+	cfg   *setting.Config
+	log   *zap.Logger
+	now   func() time.Time
+}
+
+// NewMultisigUsecase new a multisig usecase.
+func NewMultisigUsecase(uc *TrxUsecase, repo MultisigRepo, audit AuditRepo, cfg *setting.Config, logger *zap.Logger) *MultisigUsecase {
+	return &MultisigUsecase{uc: uc, repo: repo, audit: audit, cfg: cfg, log: logger, now: time.Now}
+}
+
+// Enabled report whether pending transactions are checked in the background
+func (m *MultisigUsecase) Enabled() bool {
+	return m.cfg.Multisig.Enable
+}
+
+// Create build the transfer of tx with its permission and an expiration long enough for
+// the approvers, and store it for them to sign
+func (m *MultisigUsecase) Create(ctx context.Context, tx *MultisigTx) (*MultisigTx, error) {
+	ext, err := m.uc.buildTransfer(ctx, tx.Owner, tx.To, tx.Contract, tx.Amount.BigInt(), 0, tx.FeeLimit)
+	if err != nil {
+		return nil, err
+	}
+	raw := ext.Transaction.GetRawData()
+	for _, c := range raw.GetContract() {
+		c.PermissionId = tx.PermissionID
+	}
+	tx.ExpiresAt = m.now().Add(m.expiration()).Truncate(time.Millisecond)
+	if err := extendExpiration(ext, tx.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	// the node reports the threshold, or an error when the permission does not exist
+	weight, err := m.uc.cli.GetTransactionSignWeight(ctx, ext.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	switch weight.GetResult().GetCode() {
+	case api.TransactionSignWeight_Result_ENOUGH_PERMISSION, api.TransactionSignWeight_Result_NOT_ENOUGH_PERMISSION:
+	default:
+		return nil, fmt.Errorf("permission %d of %s: %s", tx.PermissionID, tx.Owner, weight.GetResult().GetMessage())
+	}
+
+	b, err := proto.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	tx.Txid, tx.RawData = hex.EncodeToString(ext.Txid), hex.EncodeToString(b)
+	tx.Threshold = weight.GetPermission().GetThreshold()
+	tx.Status = MultisigStatusPending
+	if err := m.repo.CreateMultisigTx(ctx, tx); err != nil {
+		return nil, err
+	}
+	m.record(ctx, AuditActionMultisigCreate, tx, "")
+	m.log.Sugar().Infow("multisig created", "id", tx.ID, "owner", tx.Owner, "permission", tx.PermissionID, "txid", tx.Txid,
+		"threshold", tx.Threshold, "expires_at", tx.ExpiresAt)
+	return tx, nil
+}
+
+// Get return a transaction and its signatures
+func (m *MultisigUsecase) Get(ctx context.Context, id int64) (*MultisigTx, []*MultisigSignature, error) {
+	return m.repo.GetMultisigTx(ctx, id)
+}
+
+// List return the transactions of owner in one of statuses, pending ones when statuses is empty
+func (m *MultisigUsecase) List(ctx context.Context, owner string, statuses []MultisigStatus, limit int) ([]*MultisigTx, error) {
+	if len(statuses) == 0 {
+		statuses = []MultisigStatus{MultisigStatusPending}
+	}
+	return m.repo.ListMultisigTxs(ctx, owner, statuses, limit)
+}
+
+// Sign add the signature of an approver over the txid of transaction id. The node checks
+// it belongs to the permission, and the transaction is broadcast once the threshold is reached.
+func (m *MultisigUsecase) Sign(ctx context.Context, id int64, signature []byte) (*MultisigTx, []*MultisigSignature, error) {
+	tx, sigs, err := m.repo.GetMultisigTx(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tx.Status != MultisigStatusPending || !m.now().Before(tx.ExpiresAt) {
+		return nil, nil, ErrMultisigClosed
+	}
+	signed, err := tx.transaction(sigs)
+	if err != nil {
+		return nil, nil, err
+	}
+	signer, err := signerAddress(signed, signature)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	for _, s := range sigs {
+		if s.Signer == signer.String() {
+			return nil, nil, ErrMultisigSigned
+		}
+	}
+	signed.Signature = append(signed.Signature, signature)
+
+	weight, err := m.uc.cli.GetTransactionSignWeight(ctx, signed)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch weight.GetResult().GetCode() {
+	case api.TransactionSignWeight_Result_ENOUGH_PERMISSION:
+		tx.Status = MultisigStatusApproved
+	case api.TransactionSignWeight_Result_NOT_ENOUGH_PERMISSION:
+	default:
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidSignature, weight.GetResult().GetMessage())
+	}
+	tx.Weight = weight.GetCurrentWeight()
+	sig := &MultisigSignature{MultisigID: tx.ID, Signer: signer.String(), Signature: hex.EncodeToString(signature)}
+	tx.UpdatedAt = m.now()
+	if err := m.repo.AddMultisigSignature(ctx, tx, sig); err != nil {
+		return nil, nil, err
+	}
+	sigs = append(sigs, sig)
+	m.record(ctx, AuditActionMultisigSign, tx, sig.Signer)
+	m.log.Sugar().Infow("multisig signed", "id", tx.ID, "signer", sig.Signer, "weight", tx.Weight, "threshold", tx.Threshold)
+
+	if tx.Status == MultisigStatusApproved {
+		if err := m.broadcast(ctx, tx, signed); err != nil {
+			// the job broadcasts it again
+			m.log.Sugar().Errorw("multisig broadcast", "id", tx.ID, "txid", tx.Txid, "err", err)
+		}
+	}
+	return tx, sigs, nil
+}
+
+// Run flag pending transactions about to expire, expire them and broadcast approved ones
+// until ctx is done
+func (m *MultisigUsecase) Run(ctx context.Context) error {
+	interval := time.Duration(m.cfg.Multisig.Interval) * time.Second
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	for {
+		if err := m.check(ctx); err != nil {
+			m.log.Sugar().Errorw("MultisigUsecase", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (m *MultisigUsecase) check(ctx context.Context) error {
+	list, err := m.repo.ListMultisigTxs(ctx, "", []MultisigStatus{MultisigStatusPending, MultisigStatusApproved}, 1000)
+	if err != nil {
+		return err
+	}
+	now := m.now()
+	warnBefore := time.Duration(m.cfg.Multisig.WarnBefore) * time.Second
+	for _, tx := range list {
+		if ctx.Err() != nil {
+			return nil
+		}
+		var err error
+		switch {
+		case tx.Status == MultisigStatusApproved:
+			err = m.rebroadcast(ctx, tx)
+		case !now.Before(tx.ExpiresAt):
+			tx.Status = MultisigStatusExpired
+			m.record(ctx, AuditActionMultisigExpired, tx, "")
+			m.log.Sugar().Warnw("multisig expired", "id", tx.ID, "owner", tx.Owner, "txid", tx.Txid, "weight", tx.Weight,
+				"threshold", tx.Threshold)
+			err = m.update(ctx, tx)
+		case !tx.Expiring && now.Add(warnBefore).After(tx.ExpiresAt):
+			tx.Expiring = true
+			m.record(ctx, AuditActionMultisigExpiring, tx, "")
+			m.log.Sugar().Warnw("multisig about to expire", "id", tx.ID, "owner", tx.Owner, "txid", tx.Txid,
+				"weight", tx.Weight, "threshold", tx.Threshold, "expires_at", tx.ExpiresAt)
+			err = m.update(ctx, tx)
+		}
+		if err != nil {
+			m.log.Sugar().Errorw("multisig", "id", tx.ID, "txid", tx.Txid, "err", err)
+		}
+	}
+	return nil
+}
+
+// rebroadcast send an approved transaction the node could not take before
+func (m *MultisigUsecase) rebroadcast(ctx context.Context, tx *MultisigTx) error {
+	if m.now().Sub(tx.UpdatedAt) < rebroadcastAfter {
+		return nil
+	}
+	_, sigs, err := m.repo.GetMultisigTx(ctx, tx.ID)
+	if err != nil {
+		return err
+	}
+	signed, err := tx.transaction(sigs)
+	if err != nil {
+		return err
+	}
+	return m.broadcast(ctx, tx, signed)
+}
+
+// broadcast send an approved transaction. When the node can not be reached it stays
+// approved and is sent again later.
+func (m *MultisigUsecase) broadcast(ctx context.Context, tx *MultisigTx, signed *core.Transaction) error {
+	ret, err := m.uc.cli.Broadcast(ctx, signed)
+	switch {
+	case err == nil || ret.GetCode() == api.Return_DUP_TRANSACTION_ERROR:
+		return m.sent(ctx, tx)
+	case ret == nil:
+		tx.LastError = truncate(err.Error(), 255)
+		if uerr := m.update(ctx, tx); uerr != nil {
+			return uerr
+		}
+		return err
+	}
+	switch errcode.FromBroadcastCode(ret.GetCode()) {
+	case errcode.BroadcastUnavailable:
+		return err
+	case errcode.BroadcastExpired:
+		// an earlier broadcast may have been included before it expired
+		info, ierr := m.uc.cli.GetTransactionInfoById(ctx, tx.Txid)
+		if ierr != nil {
+			return ierr
+		}
+		if info.GetBlockNumber() > 0 {
+			return m.sent(ctx, tx)
+		}
+		tx.Status = MultisigStatusExpired
+	default:
+		tx.Status = MultisigStatusFailed
+	}
+	tx.LastError = truncate(err.Error(), 255)
+	m.log.Sugar().Errorw("multisig rejected", "id", tx.ID, "txid", tx.Txid, "status", tx.Status, "err", err)
+	return m.update(ctx, tx)
+}
+
+// sent mark tx as sent and track it like any other withdrawal
+func (m *MultisigUsecase) sent(ctx context.Context, tx *MultisigTx) error {
+	tx.Status, tx.LastError = MultisigStatusSent, ""
+	if err := m.update(ctx, tx); err != nil {
+		return err
+	}
+	txid, err := hex.DecodeString(tx.Txid)
+	if err != nil {
+		return err
+	}
+	m.record(ctx, AuditActionMultisigSend, tx, "")
+	m.log.Sugar().Infow("multisig sent", "id", tx.ID, "owner", tx.Owner, "to", tx.To, "token", tx.Token, "amount", tx.Amount,
+		"txid", tx.Txid)
+	expiresAt := tx.ExpiresAt
+	m.uc.recordWithdrawal(ctx, &api.TransactionExtention{Txid: txid}, &Tx{
+		Token: tx.Token, Contract: tx.Contract, From: tx.Owner, To: tx.To, Amount: tx.Amount, ExpiresAt: &expiresAt,
+	})
+	return nil
+}
+
+func (m *MultisigUsecase) update(ctx context.Context, tx *MultisigTx) error {
+	tx.UpdatedAt = m.now()
+	return m.repo.UpdateMultisigTx(ctx, tx)
+}
+
+func (m *MultisigUsecase) expiration() time.Duration {
+	d := time.Duration(m.cfg.Multisig.Expiration) * time.Second
+	switch {
+	case d <= 0:
+		return time.Hour
+	case d > maxTxExpiration:
+		// leave room for the head block lagging behind our clock
+		return maxTxExpiration - time.Minute
+	}
+	return d
+}
+
+// record write an audit log entry, failing to do so does not undo the action
+func (m *MultisigUsecase) record(ctx context.Context, action string, tx *MultisigTx, signer string) {
+	b, _ := json.Marshal(map[string]interface{}{
+		"id":         tx.ID,
+		"permission": tx.PermissionID,
+		"to":         tx.To,
+		"token":      tx.Token,
+		"amount":     tx.Amount,
+		"signer":     signer,
+		"weight":     tx.Weight,
+		"threshold":  tx.Threshold,
+		"expires_at": tx.ExpiresAt,
+	})
+	l := &AuditLog{Actor: multisigActor, Action: action, Address: tx.Owner, Txid: tx.Txid, Detail: string(b)}
+	if err := m.audit.CreateAuditLog(ctx, l); err != nil {
+		m.log.Sugar().Errorw("CreateAuditLog", "action", action, "address", tx.Owner, "txid", tx.Txid, "err", err)
+	}
+}
+
+// transaction return the transaction of m carrying sigs
+func (m *MultisigTx) transaction(sigs []*MultisigSignature) (*core.Transaction, error) {
+	b, err := hex.DecodeString(m.RawData)
+	if err != nil {
+		return nil, err
+	}
+	tx := &core.Transaction{RawData: new(core.TransactionRaw)}
+	if err := proto.Unmarshal(b, tx.RawData); err != nil {
+		return nil, err
+	}
+	for _, s := range sigs {
+		sig, err := hex.DecodeString(s.Signature)
+		if err != nil {
+			return nil, err
+		}
+		tx.Signature = append(tx.Signature, sig)
+	}
+	return tx, nil
+}
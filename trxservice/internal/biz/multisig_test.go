@@ -0,0 +1,277 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+type memMultisigRepo struct {
+	mu   sync.Mutex
+	txs  []*MultisigTx
+	sigs []*MultisigSignature
+}
+
+func (r *memMultisigRepo) CreateMultisigTx(ctx context.Context, m *MultisigTx) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m.ID = int64(len(r.txs) + 1)
+	c := *m
+	r.txs = append(r.txs, &c)
+	return nil
+}
+
+func (r *memMultisigRepo) GetMultisigTx(ctx context.Context, id int64) (*MultisigTx, []*MultisigSignature, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id <= 0 || id > int64(len(r.txs)) {
+		return nil, nil, ErrNotFound
+	}
+	var sigs []*MultisigSignature
+	for _, s := range r.sigs {
+		if s.MultisigID == id {
+			c := *s
+			sigs = append(sigs, &c)
+		}
+	}
+	m := *r.txs[id-1]
+	return &m, sigs, nil
+}
+
+func (r *memMultisigRepo) ListMultisigTxs(ctx context.Context, owner string, statuses []MultisigStatus, limit int) ([]*MultisigTx, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var list []*MultisigTx
+	for i := len(r.txs) - 1; i >= 0 && len(list) < limit; i-- {
+		m := r.txs[i]
+		if owner != "" && m.Owner != owner {
+			continue
+		}
+		for _, st := range statuses {
+			if m.Status == st {
+				c := *m
+				list = append(list, &c)
+			}
+		}
+	}
+	return list, nil
+}
+
+func (r *memMultisigRepo) AddMultisigSignature(ctx context.Context, m *MultisigTx, sig *MultisigSignature) error {
+	r.mu.Lock()
+	for _, s := range r.sigs {
+		if s.MultisigID == sig.MultisigID && s.Signer == sig.Signer {
+			r.mu.Unlock()
+			return ErrMultisigSigned
+		}
+	}
+	r.mu.Unlock()
+	if err := r.UpdateMultisigTx(ctx, m); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sig.ID = int64(len(r.sigs) + 1)
+	c := *sig
+	r.sigs = append(r.sigs, &c)
+	return nil
+}
+
+func (r *memMultisigRepo) UpdateMultisigTx(ctx context.Context, m *MultisigTx) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := r.txs[m.ID-1]
+	if stored.Version != m.Version {
+		return ErrNotFound
+	}
+	m.Version++
+	*stored = *m
+	return nil
+}
+
+// fakeMultisigNode weighs signatures against a permission 2 with a key of weight 1 per address
+type fakeMultisigNode struct {
+	*fakeSweepNode
+	threshold int64
+	keys      map[string]bool
+}
+
+func (n *fakeMultisigNode) GetTransactionSignWeight(ctx context.Context, in *core.Transaction, opts ...grpc.CallOption) (*api.TransactionSignWeight, error) {
+	fail := func(msg string) (*api.TransactionSignWeight, error) {
+		return &api.TransactionSignWeight{Result: &api.TransactionSignWeight_Result{
+			Code: api.TransactionSignWeight_Result_PERMISSION_ERROR, Message: msg,
+		}}, nil
+	}
+	if in.RawData.Contract[0].PermissionId != 2 {
+		return fail("permission isn't exit")
+	}
+	res := &api.TransactionSignWeight{Permission: &core.Permission{Id: 2, Threshold: n.threshold}, Result: &api.TransactionSignWeight_Result{}}
+	seen := make(map[string]bool)
+	for _, sig := range in.Signature {
+		signer, err := signerAddress(in, sig)
+		if err != nil {
+			return nil, err
+		}
+		if !n.keys[signer.String()] {
+			return fail(signer.String() + " is not contained in permission")
+		}
+		if seen[signer.String()] {
+			return fail(signer.String() + " has signed twice!")
+		}
+		seen[signer.String()] = true
+		res.ApprovedList = append(res.ApprovedList, signer.Bytes())
+		res.CurrentWeight++
+	}
+	if res.CurrentWeight < n.threshold {
+		res.Result.Code = api.TransactionSignWeight_Result_NOT_ENOUGH_PERMISSION
+	}
+	return res, nil
+}
+
+func newTestApprover(t *testing.T, n *fakeMultisigNode) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != nil {
+		n.keys[address.PubkeyToAddress(key.PublicKey).String()] = true
+	}
+	return key
+}
+
+func approve(t *testing.T, tx *MultisigTx, key *ecdsa.PrivateKey) []byte {
+	t.Helper()
+	txid, err := hex.DecodeString(tx.Txid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := crypto.Sign(txid, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig
+}
+
+func TestMultisig(t *testing.T) {
+	owner, to := newTestAddress(t), newTestAddress(t)
+	node := &fakeMultisigNode{
+		fakeSweepNode: &fakeSweepNode{balances: map[string]int64{string(owner.Bytes()): 1000 * sunPerTRX}},
+		threshold:     2,
+		keys:          make(map[string]bool),
+	}
+	keys := []*ecdsa.PrivateKey{newTestApprover(t, node), newTestApprover(t, node), newTestApprover(t, node)}
+	cli := &TronCli{TronWalletCli: node, GrpcTimeout: time.Second}
+	uc := NewTrxUsecase(newMemTrxRepo(), nil, zap.NewNop(), cli, nopSigner{}, NewEventBus(), nil)
+	repo, audit := &memMultisigRepo{}, &memAuditRepo{}
+	cfg := &setting.Config{}
+	cfg.Multisig.WarnBefore = 600
+	m := NewMultisigUsecase(uc, repo, audit, cfg, zap.NewNop())
+	now := time.UnixMilli(1666000000000)
+	m.now = func() time.Time { return now }
+	ctx := context.Background()
+	newTx := func(permission int32) *MultisigTx {
+		return &MultisigTx{Owner: owner.String(), PermissionID: permission, To: to.String(), Token: TokenTRX,
+			Amount: decimal.NewFromInt(5 * sunPerTRX)}
+	}
+
+	if _, err := m.Create(ctx, newTx(3)); err == nil {
+		t.Fatal("created with a permission the account does not have")
+	}
+	tx, err := m.Create(ctx, newTx(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Status != MultisigStatusPending || tx.Threshold != 2 || !tx.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("created %+v", tx)
+	}
+	unsigned, err := tx.transaction(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txid, _ := txHash(unsigned)
+	if hex.EncodeToString(txid) != tx.Txid || unsigned.RawData.Contract[0].PermissionId != 2 ||
+		unsigned.RawData.Expiration != tx.ExpiresAt.UnixMilli() {
+		t.Fatalf("raw data %v of txid %s", unsigned.RawData, tx.Txid)
+	}
+
+	// a key outside the permission and a second signature of the same key are refused
+	if _, _, err := m.Sign(ctx, tx.ID, approve(t, tx, newTestApprover(t, nil))); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("outsider signed: %v", err)
+	}
+	if tx, _, err = m.Sign(ctx, tx.ID, approve(t, tx, keys[0])); err != nil {
+		t.Fatal(err)
+	}
+	if tx.Status != MultisigStatusPending || tx.Weight != 1 {
+		t.Fatalf("signed once %+v", tx)
+	}
+	if _, _, err := m.Sign(ctx, tx.ID, approve(t, tx, keys[0])); !errors.Is(err, ErrMultisigSigned) {
+		t.Fatalf("signed twice: %v", err)
+	}
+
+	// flagged once as it gets close to its expiration
+	now = tx.ExpiresAt.Add(-5 * time.Minute)
+	for i := 0; i < 2; i++ {
+		if err := m.check(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if tx, _, _ = m.Get(ctx, tx.ID); !tx.Expiring || len(audit.logs) != 3 || audit.logs[2].Action != AuditActionMultisigExpiring {
+		t.Fatalf("not flagged %+v, audit logs %d", tx, len(audit.logs))
+	}
+	pending, err := m.List(ctx, owner.String(), nil, 10)
+	if err != nil || len(pending) != 1 || pending[0].ID != tx.ID {
+		t.Fatalf("pending %v: %v", pending, err)
+	}
+
+	// the threshold is reached while the node is down, the job broadcasts it later
+	node.down = true
+	tx, sigs, err := m.Sign(ctx, tx.ID, approve(t, tx, keys[2]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Status != MultisigStatusApproved || tx.Weight != 2 || len(sigs) != 2 || len(node.broadcast) != 0 {
+		t.Fatalf("approved %+v", tx)
+	}
+	node.down = false
+	now = now.Add(rebroadcastAfter)
+	if err := m.check(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if tx, _, _ = m.Get(ctx, tx.ID); tx.Status != MultisigStatusSent || len(node.broadcast) != 1 {
+		t.Fatalf("sent %+v", tx)
+	}
+	if sent := node.broadcast[0]; len(sent.Signature) != 2 || !bytes.Equal(sent.Signature[1], approve(t, tx, keys[2])) {
+		t.Fatalf("broadcast signatures %x", sent.Signature)
+	}
+
+	// nobody signs the next one in time
+	late, err := m.Create(ctx, newTx(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now = late.ExpiresAt
+	if err := m.check(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if late, _, _ = m.Get(ctx, late.ID); late.Status != MultisigStatusExpired {
+		t.Fatalf("late %+v", late)
+	}
+	if _, _, err := m.Sign(ctx, late.ID, approve(t, late, keys[1])); !errors.Is(err, ErrMultisigClosed) {
+		t.Fatalf("signed an expired tx: %v", err)
+	}
+}
@@ -0,0 +1,574 @@
+package biz
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultProbeInterval = 5 * time.Second
+	defaultProbeTimeout  = 3 * time.Second
+	defaultMaxNodeLag    = 10
+
+	apiKeyHeader = "TRON-PRO-API-KEY"
+)
+
+// Backends of tron.nodes
+const (
+	NodeBackendGRPC = "grpc"
+	NodeBackendHTTP = "http"
+)
+
+// NodePool spreads calls over the TRON full nodes of app.node_addr, round robin over the
+// healthy ones. Run probes the head block of every node: nodes that can not be reached,
+// answer slower than max_latency or lag more than max_lag blocks behind the best one are
+// left out until they recover. When no node is healthy calls go to all of them. Each node
+// has its own rate limit and circuit breaker, calls refused as unavailable or throttled
+// are retried on the next node. The node list and the TLS and API keys of tron.nodes
+// follow config reloads.
+type NodePool struct {
+	name  string
+	addrs func(*setting.Config) []string
+	head  func(ctx context.Context, cc grpc.ClientConnInterface) (*api.BlockExtention, error)
+	log   *zap.Logger
+	wake  chan struct{} // probe at once, e.g. after a reload
+	next  uint64
+
+	mu      sync.RWMutex
+	cfg     setting.Tron
+	nodes   []*poolNode // in config order
+	healthy []*poolNode
+}
+
+var _ grpc.ClientConnInterface = (*NodePool)(nil)
+
+type poolNode struct {
+	addr string
+	node setting.TronNode // dialled with
+	conn nodeConn
+
+	limit   tokenBucket
+	breaker breaker
+
+	// from the last probe
+	healthy bool
+	height  int64
+	latency time.Duration
+}
+
+// nodeConn carries the calls of TronCli to a node: a gRPC connection, or an httpConn
+// translating them to the node's HTTP API
+type nodeConn interface {
+	grpc.ClientConnInterface
+	Close() error
+}
+
+// NodeStatus is the state of a node as of its last probe
+type NodeStatus struct {
+	Addr    string
+	Healthy bool
+	Height  int64
+	Latency time.Duration
+}
+
+// SolidityNodePool is the pool of the solidity nodes of app.solidity_addr, serving only
+// solidified state
+type SolidityNodePool struct {
+	*NodePool
+}
+
+// NewNodePool new a pool of the configured full nodes. Nodes are not healthy until probed.
+func NewNodePool(cfg *setting.Config, logger *zap.Logger) *NodePool {
+	return newNodePool("full", cfg, func(cfg *setting.Config) []string { return cfg.App.Node_Addr },
+		func(ctx context.Context, cc grpc.ClientConnInterface) (*api.BlockExtention, error) {
+			return api.NewWalletClient(cc).GetNowBlock2(ctx, new(api.EmptyMessage))
+		}, logger)
+}
+
+// NewSolidityNodePool new a pool of the configured solidity nodes, their head is the
+// latest solidified block
+func NewSolidityNodePool(cfg *setting.Config, logger *zap.Logger) *SolidityNodePool {
+	return &SolidityNodePool{newNodePool("solidity", cfg, func(cfg *setting.Config) []string { return cfg.App.Solidity_Addr },
+		func(ctx context.Context, cc grpc.ClientConnInterface) (*api.BlockExtention, error) {
+			return api.NewWalletSolidityClient(cc).GetNowBlock2(ctx, new(api.EmptyMessage))
+		}, logger)}
+}
+
+func newNodePool(name string, cfg *setting.Config, addrs func(*setting.Config) []string,
+	head func(ctx context.Context, cc grpc.ClientConnInterface) (*api.BlockExtention, error), logger *zap.Logger) *NodePool {
+	p := &NodePool{
+		name:  name,
+		addrs: addrs,
+		head:  head,
+		log:   logger,
+		wake:  make(chan struct{}, 1),
+	}
+	p.reload(cfg)
+	setting.OnChange(p.reload)
+	return p
+}
+
+// nodeConfig return the tron.nodes entry of addr, plaintext without API key when there is none
+func nodeConfig(cfg setting.Tron, addr string) setting.TronNode {
+	for _, n := range cfg.Nodes {
+		if n.Addr == addr {
+			return n
+		}
+	}
+	return setting.TronNode{Addr: addr}
+}
+
+// dial connect to a node with its backend, over TLS when configured. Its calls carry its API key.
+func (p *NodePool) dial(node setting.TronNode) (nodeConn, error) {
+	tlsCfg, err := nodeTLS(node)
+	if err != nil {
+		return nil, err
+	}
+	var keys *apiKeys
+	if len(node.APIKeys) > 0 {
+		keys = &apiKeys{pool: p.name, addr: node.Addr, keys: node.APIKeys, log: p.log}
+	}
+	switch node.Backend {
+	case NodeBackendGRPC, "":
+		creds := insecure.NewCredentials()
+		if tlsCfg != nil {
+			creds = credentials.NewTLS(tlsCfg)
+		}
+		opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+		if keys != nil {
+			opts = append(opts, grpc.WithChainUnaryInterceptor(keys.unary), grpc.WithChainStreamInterceptor(keys.stream))
+		}
+		return grpc.Dial(node.Addr, opts...)
+	case NodeBackendHTTP:
+		return newHTTPConn(node.Addr, tlsCfg, keys), nil
+	}
+	return nil, fmt.Errorf("unknown backend %q", node.Backend)
+}
+
+// nodeTLS return the TLS config of node, nil for plaintext
+func nodeTLS(node setting.TronNode) (*tls.Config, error) {
+	if !node.TLS && node.CAFile == "" {
+		return nil, nil
+	}
+	cfg := &tls.Config{ServerName: node.ServerName, MinVersion: tls.VersionTLS12}
+	if node.CAFile != "" {
+		pem, err := os.ReadFile(node.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate in %s", node.CAFile)
+		}
+	}
+	return cfg, nil
+}
+
+// reload dial the nodes added to the config, redial those whose tron.nodes entry changed and
+// close the removed ones
+func (p *NodePool) reload(cfg *setting.Config) {
+	addrs := make(map[string]bool)
+	p.mu.Lock()
+	existing := make(map[string]*poolNode, len(p.nodes))
+	for _, n := range p.nodes {
+		existing[n.addr] = n
+	}
+	var nodes, redialled []*poolNode
+	for _, addr := range p.addrs(cfg) {
+		if addrs[addr] {
+			continue
+		}
+		addrs[addr] = true
+		node := nodeConfig(cfg.Tron, addr)
+		n, ok := existing[addr]
+		if ok && reflect.DeepEqual(n.node, node) {
+			nodes = append(nodes, n)
+			delete(existing, addr)
+			continue
+		}
+		conn, err := p.dial(node)
+		if err != nil {
+			p.log.Sugar().Errorw("tron node", "pool", p.name, "addr", addr, "err", err)
+			continue
+		}
+		nodes = append(nodes, &poolNode{addr: addr, node: node, conn: conn})
+		if ok {
+			redialled = append(redialled, n)
+			delete(existing, addr)
+			p.log.Sugar().Infow("tron node redialled", "pool", p.name, "addr", addr, "backend", node.Backend, "tls", node.TLS,
+				"api_keys", len(node.APIKeys))
+			continue
+		}
+		p.log.Sugar().Infow("tron node added", "pool", p.name, "addr", addr, "backend", node.Backend, "tls", node.TLS,
+			"api_keys", len(node.APIKeys))
+	}
+	for _, n := range nodes {
+		n.limit.set(nodeRate(cfg.Tron, n.node))
+	}
+	p.cfg = cfg.Tron
+	p.nodes = nodes
+	p.healthy = healthyNodes(nodes)
+	p.mu.Unlock()
+
+	for _, n := range existing {
+		p.log.Sugar().Infow("tron node removed", "pool", p.name, "addr", n.addr)
+		nodeBreakerOpen.DeleteLabelValues(p.name, n.addr)
+		n.conn.Close()
+	}
+	for _, n := range redialled {
+		n.conn.Close()
+	}
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// apiKeys attach an API key to every call of a node and move on to its next key when the
+// node refuses the current one
+type apiKeys struct {
+	pool, addr string
+	keys       []string
+	cur        uint32
+	log        *zap.Logger
+}
+
+func (k *apiKeys) unary(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return k.do(ctx, func(ctx context.Context) error {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	})
+}
+
+// do make call with the current key in the outgoing metadata of ctx, again with the next
+// keys while the node refuses them
+func (k *apiKeys) do(ctx context.Context, call func(ctx context.Context) error) error {
+	var err error
+	// refused calls never reached the node, so are safe to send again with another key
+	for range k.keys {
+		i := atomic.LoadUint32(&k.cur)
+		err = call(metadata.AppendToOutgoingContext(ctx, apiKeyHeader, k.keys[i%uint32(len(k.keys))]))
+		if !keyRefused(err) {
+			return err
+		}
+		k.rotate(i, err)
+	}
+	return err
+}
+
+func (k *apiKeys) stream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+	streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	i := atomic.LoadUint32(&k.cur)
+	s, err := streamer(metadata.AppendToOutgoingContext(ctx, apiKeyHeader, k.keys[i%uint32(len(k.keys))]), desc, cc, method, opts...)
+	if keyRefused(err) {
+		k.rotate(i, err)
+	}
+	return s, err
+}
+
+// rotate move on from key i, once when concurrent calls are refused together
+func (k *apiKeys) rotate(i uint32, err error) {
+	if atomic.CompareAndSwapUint32(&k.cur, i, i+1) {
+		k.log.Sugar().Warnw("tron api key refused", "pool", k.pool, "addr", k.addr, "key", i%uint32(len(k.keys)),
+			"next", (i+1)%uint32(len(k.keys)), "err", err)
+	}
+}
+
+// keyRefused report whether err is a node or its gateway refusing the API key: invalid
+// (401, 403) or out of quota (429)
+func keyRefused(err error) bool {
+	switch status.Code(err) {
+	case codes.Unauthenticated, codes.PermissionDenied, codes.ResourceExhausted:
+		return true
+	case codes.Unavailable:
+		// a gateway answering HTTP 429, see grpc-go's HTTP status mapping
+		return strings.Contains(status.Convert(err).Message(), "429")
+	}
+	return false
+}
+
+func healthyNodes(nodes []*poolNode) []*poolNode {
+	var healthy []*poolNode
+	for _, n := range nodes {
+		if n.healthy {
+			healthy = append(healthy, n)
+		}
+	}
+	return healthy
+}
+
+// Invoke send a unary call to the next node, again to the following ones while they are
+// unavailable or throttle it and the deadline of ctx leaves time for a retry
+func (p *NodePool) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	cfg := p.config()
+	retries := cfg.Retries
+	if retries == 0 {
+		retries = defaultCallRetries
+	}
+	for attempt := 0; ; attempt++ {
+		err := p.invoke(ctx, cfg, method, args, reply, opts...)
+		if attempt >= retries || !retryableCall(err) {
+			return err
+		}
+		backoff := retryBackoff(cfg, attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < backoff {
+			return err
+		}
+		nodeRetries.WithLabelValues(p.name, status.Code(err).String()).Inc()
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+	}
+}
+
+func (p *NodePool) invoke(ctx context.Context, cfg setting.Tron, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	n, err := p.pick()
+	if err != nil {
+		return err
+	}
+	if err := p.wait(ctx, n); err != nil {
+		n.breaker.release()
+		return err
+	}
+	call := ctx
+	if cfg.CallTimeout > 0 {
+		var cancel context.CancelFunc
+		call, cancel = context.WithTimeout(ctx, time.Duration(cfg.CallTimeout)*time.Millisecond)
+		defer cancel()
+	}
+	start := time.Now()
+	err = n.conn.Invoke(call, method, args, reply, opts...)
+	nodeCallSeconds.WithLabelValues(p.name, n.addr).Observe(time.Since(start).Seconds())
+	nodeCalls.WithLabelValues(p.name, n.addr, status.Code(err).String()).Inc()
+	p.record(ctx, cfg, n, err)
+	return err
+}
+
+// NewStream open a stream to the next node
+func (p *NodePool) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	n, err := p.pick()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.wait(ctx, n); err != nil {
+		n.breaker.release()
+		return nil, err
+	}
+	s, err := n.conn.NewStream(ctx, desc, method, opts...)
+	p.record(ctx, p.config(), n, err)
+	return s, err
+}
+
+// pick the next node whose breaker lets a call through
+func (p *NodePool) pick() (*poolNode, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	nodes := p.healthy
+	if len(nodes) == 0 {
+		nodes = p.nodes
+	}
+	if len(nodes) == 0 {
+		return nil, status.Errorf(codes.Unavailable, "no tron %s node configured", p.name)
+	}
+	now := time.Now()
+	next := atomic.AddUint64(&p.next, 1)
+	for i := range nodes {
+		n := nodes[(next+uint64(i))%uint64(len(nodes))]
+		if n.breaker.allow(now) {
+			return n, nil
+		}
+	}
+	return nil, status.Errorf(codes.Unavailable, "every tron %s node is cooling down after failures", p.name)
+}
+
+// wait for the rate limit of n
+func (p *NodePool) wait(ctx context.Context, n *poolNode) error {
+	waited, err := n.limit.wait(ctx)
+	if waited > 0 {
+		nodeRateLimitSeconds.WithLabelValues(p.name, n.addr).Observe(waited.Seconds())
+	}
+	return err
+}
+
+// record the outcome of a call to n in its breaker. A call cut short by the deadline or
+// cancellation of ctx, rather than by the call timeout, says nothing about the node.
+func (p *NodePool) record(ctx context.Context, cfg setting.Tron, n *poolNode, err error) {
+	switch status.Code(err) {
+	case codes.DeadlineExceeded, codes.Canceled:
+		if ctx.Err() != nil {
+			n.breaker.release()
+			return
+		}
+	}
+	opened, closed := n.breaker.record(nodeFailed(err), cfg, time.Now())
+	switch {
+	case opened:
+		p.log.Sugar().Warnw("tron node breaker open", "pool", p.name, "addr", n.addr, "err", err)
+		nodeBreakerOpen.WithLabelValues(p.name, n.addr).Set(1)
+		nodeBreakerTrips.WithLabelValues(p.name, n.addr).Inc()
+	case closed:
+		p.log.Sugar().Infow("tron node breaker closed", "pool", p.name, "addr", n.addr)
+		nodeBreakerOpen.WithLabelValues(p.name, n.addr).Set(0)
+	}
+}
+
+// Len return the number of configured nodes
+func (p *NodePool) Len() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.nodes)
+}
+
+// Nodes return the state of every node
+func (p *NodePool) Nodes() []NodeStatus {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	nodes := make([]NodeStatus, len(p.nodes))
+	for i, n := range p.nodes {
+		nodes[i] = NodeStatus{Addr: n.addr, Healthy: n.healthy, Height: n.height, Latency: n.latency}
+	}
+	return nodes
+}
+
+// Height return the highest head block of the healthy nodes as of the last probe, 0 until
+// one is healthy
+func (p *NodePool) Height() int64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	var best int64
+	for _, n := range p.healthy {
+		if n.height > best {
+			best = n.height
+		}
+	}
+	return best
+}
+
+// Run probe the nodes until ctx is done
+func (p *NodePool) Run(ctx context.Context) error {
+	for {
+		p.probe(ctx)
+		interval := time.Duration(p.config().ProbeInterval) * time.Second
+		if interval <= 0 {
+			interval = defaultProbeInterval
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		case <-p.wake:
+		}
+	}
+}
+
+func (p *NodePool) config() setting.Tron {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.cfg
+}
+
+type probeResult struct {
+	height  int64
+	latency time.Duration
+	err     error
+}
+
+// probe ask every node for its head block at once and leave out the unhealthy ones. Probes
+// bypass the rate limits, a node busy with calls is not unhealthy.
+func (p *NodePool) probe(ctx context.Context) {
+	p.mu.RLock()
+	cfg, nodes := p.cfg, append([]*poolNode(nil), p.nodes...)
+	p.mu.RUnlock()
+	timeout := time.Duration(cfg.ProbeTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = defaultProbeTimeout
+	}
+	maxLag := cfg.MaxLag
+	if maxLag <= 0 {
+		maxLag = defaultMaxNodeLag
+	}
+
+	results := make([]probeResult, len(nodes))
+	var wg sync.WaitGroup
+	for i, n := range nodes {
+		wg.Add(1)
+		go func(r *probeResult, n *poolNode) {
+			defer wg.Done()
+			c, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			start := time.Now()
+			block, err := p.head(c, n.conn)
+			r.latency = time.Since(start)
+			if err == nil && block.GetBlockHeader() == nil {
+				err = errors.New("no head block")
+			}
+			r.height, r.err = block.GetBlockHeader().GetRawData().GetNumber(), err
+		}(&results[i], n)
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+
+	var best int64
+	for _, r := range results {
+		if r.err == nil && r.height > best {
+			best = r.height
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, n := range nodes {
+		r := results[i]
+		var reason interface{}
+		switch {
+		case r.err != nil:
+			reason = r.err
+		case best-r.height > maxLag:
+			reason = "lagging"
+		case cfg.MaxLatency > 0 && r.latency > time.Duration(cfg.MaxLatency)*time.Millisecond:
+			reason = "slow"
+		}
+		healthy := reason == nil
+		if healthy && !n.healthy {
+			p.log.Sugar().Infow("tron node healthy", "pool", p.name, "addr", n.addr, "height", r.height, "latency", r.latency)
+		} else if !healthy && n.healthy {
+			p.log.Sugar().Warnw("tron node left out", "pool", p.name, "addr", n.addr, "height", r.height, "best", best, "latency", r.latency,
+				"reason", reason)
+		}
+		n.healthy, n.height, n.latency = healthy, r.height, r.latency
+	}
+	// nodes removed meanwhile are not in p.nodes any more
+	p.healthy = healthyNodes(p.nodes)
+}
+
+// Close close the connections to the nodes
+func (p *NodePool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, n := range p.nodes {
+		n.conn.Close()
+	}
+	p.nodes, p.healthy = nil, nil
+}
@@ -0,0 +1,295 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	protov1 "github.com/golang/protobuf/proto"
+	pb "github.com/leondevpt/wallet/trxservice/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxHTTPReply bounds a reply of the HTTP API, a full block of transactions stays well below
+const maxHTTPReply = 64 << 20
+
+// httpConn sends the gRPC calls TronCli makes to the HTTP API of a full or solidity node,
+// /wallet/getaccount for /protocol.Wallet/GetAccount and so on. Calls without an HTTP
+// route fail as Unimplemented.
+type httpConn struct {
+	base   string
+	client *http.Client
+	keys   *apiKeys // nil without API keys
+}
+
+var _ nodeConn = (*httpConn)(nil)
+
+// httpRoute is the endpoint of a call under /wallet/ or /walletsolidity/
+type httpRoute struct {
+	path string
+	// request return the path and body of the call, encodeJSON of req to path by default
+	request func(req proto.Message) (string, interface{}, error)
+	// reply decode the JSON reply, decodeJSON of an object by default
+	reply func(v interface{}, reply proto.Message) error
+}
+
+var httpServices = map[string]string{
+	"protocol.Wallet":         "/wallet/",
+	"protocol.WalletSolidity": "/walletsolidity/",
+}
+
+var httpRoutes = map[string]httpRoute{
+	"GetAccount":                         {path: "getaccount", reply: accountReply},
+	"GetAccountResource":                 {path: "getaccountresource"},
+	"CreateTransaction2":                 {path: "createtransaction"},
+	"CreateCommonTransaction":            {request: commonTransactionRequest},
+	"BroadcastTransaction":               {path: "broadcasthex", request: broadcastRequest},
+	"GetTransactionSignWeight":           {path: "getsignweight"},
+	"GetNowBlock2":                       {path: "getnowblock"},
+	"GetBlockByNum2":                     {path: "getblockbynum"},
+	"GetTransactionInfoById":             {path: "gettransactioninfobyid"},
+	"GetTransactionInfoByBlockNum":       {path: "gettransactioninfobyblocknum", reply: listReply("transactionInfo")},
+	"GetNodeInfo":                        {path: "getnodeinfo", reply: nodeInfoReply},
+	"GetChainParameters":                 {path: "getchainparameters"},
+	"TriggerConstantContract":            {path: "triggerconstantcontract"},
+	"GetDelegatedResourceAccountIndexV2": {path: "getdelegatedresourceaccountindexv2"},
+	"GetDelegatedResourceV2":             {path: "getdelegatedresourcev2"},
+}
+
+// the HTTP API has an endpoint per contract type where gRPC has CreateCommonTransaction
+var commonTransactionPaths = map[core.Transaction_Contract_ContractType]string{
+	FreezeBalanceV2ContractType:        "freezebalancev2",
+	UnfreezeBalanceV2ContractType:      "unfreezebalancev2",
+	WithdrawExpireUnfreezeContractType: "withdrawexpireunfreeze",
+	DelegateResourceContractType:       "delegateresource",
+	UnDelegateResourceContractType:     "undelegateresource",
+}
+
+// newHTTPConn new a connection to the HTTP API at addr, a URL or a host:port
+func newHTTPConn(addr string, tlsCfg *tls.Config, keys *apiKeys) *httpConn {
+	base := strings.TrimSuffix(addr, "/")
+	if !strings.Contains(base, "://") {
+		if tlsCfg != nil {
+			base = "https://" + base
+		} else {
+			base = "http://" + base
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsCfg != nil {
+		transport.TLSClientConfig = tlsCfg
+	}
+	return &httpConn{base: base, client: &http.Client{Transport: transport}, keys: keys}
+}
+
+// Invoke send the call to its HTTP endpoint, with the API keys of the node
+func (c *httpConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	// the contracts of gotron-sdk are generated for the v1 API
+	req, resp := protov1.MessageV2(args), protov1.MessageV2(reply)
+	if c.keys == nil {
+		return c.invoke(ctx, method, req, resp)
+	}
+	return c.keys.do(ctx, func(ctx context.Context) error {
+		return c.invoke(ctx, method, req, resp)
+	})
+}
+
+// NewStream fail, the HTTP API has no streams
+func (c *httpConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, status.Errorf(codes.Unimplemented, "%s over http", method)
+}
+
+func (c *httpConn) Close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
+
+func (c *httpConn) invoke(ctx context.Context, method string, req, reply proto.Message) error {
+	// method is /package.Service/Method
+	parts := strings.Split(method, "/")
+	if len(parts) != 3 {
+		return status.Errorf(codes.Unimplemented, "%s over http", method)
+	}
+	prefix, ok := httpServices[parts[1]]
+	route, found := httpRoutes[parts[2]]
+	if !ok || !found {
+		return status.Errorf(codes.Unimplemented, "%s over http", method)
+	}
+
+	path, body, err := c.request(route, req)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "%s over http: %v", method, err)
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "%s over http: %v", method, err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.base+prefix+path, bytes.NewReader(data))
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		if key := md.Get(apiKeyHeader); len(key) > 0 {
+			httpReq.Header.Set(apiKeyHeader, key[0])
+		}
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	defer resp.Body.Close()
+	data, err = io.ReadAll(io.LimitReader(resp.Body, maxHTTPReply))
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	if resp.StatusCode != http.StatusOK {
+		return httpStatusError(resp.StatusCode, data)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return status.Errorf(codes.Internal, "%s over http: %v", method, err)
+	}
+	// java-tron answers failures with 200 and {"Error": "..."}
+	if obj, ok := v.(map[string]interface{}); ok && obj["Error"] != nil {
+		msg := fmt.Sprint(obj["Error"])
+		if ext, ok := reply.(*api.TransactionExtention); ok {
+			// as the gRPC builders report a contract the node refused
+			ext.Result = &api.Return{Code: api.Return_OTHER_ERROR, Message: []byte(msg)}
+			return nil
+		}
+		return status.Error(codes.Unknown, msg)
+	}
+	decode := route.reply
+	if decode == nil {
+		decode = objectReply
+	}
+	if err := decode(v, reply); err != nil {
+		return status.Errorf(codes.Internal, "%s over http: %v", method, err)
+	}
+	return nil
+}
+
+func (c *httpConn) request(route httpRoute, req proto.Message) (string, interface{}, error) {
+	if route.request != nil {
+		return route.request(req)
+	}
+	body, err := encodeJSON(req.ProtoReflect())
+	return route.path, body, err
+}
+
+// httpStatusError map the HTTP status of a node or its gateway to the gRPC code the node
+// pool acts on
+func httpStatusError(code int, body []byte) error {
+	c := codes.Unknown
+	switch {
+	case code == http.StatusUnauthorized:
+		c = codes.Unauthenticated
+	case code == http.StatusForbidden:
+		c = codes.PermissionDenied
+	case code == http.StatusTooManyRequests:
+		c = codes.ResourceExhausted
+	case code == http.StatusNotFound:
+		c = codes.Unimplemented
+	case code == http.StatusBadGateway, code == http.StatusServiceUnavailable, code == http.StatusGatewayTimeout:
+		c = codes.Unavailable
+	case code >= 500:
+		c = codes.Internal
+	}
+	if len(body) > 256 {
+		body = body[:256]
+	}
+	return status.Errorf(c, "http %d: %s", code, bytes.TrimSpace(body))
+}
+
+func objectReply(v interface{}, reply proto.Message) error {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("reply is not an object")
+	}
+	return decodeJSON(obj, reply.ProtoReflect())
+}
+
+// listReply decode a reply that is a JSON array as the list field of reply
+func listReply(field string) func(v interface{}, reply proto.Message) error {
+	return func(v interface{}, reply proto.Message) error {
+		if _, ok := v.([]interface{}); !ok {
+			return objectReply(v, reply)
+		}
+		return decodeJSON(map[string]interface{}{field: v}, reply.ProtoReflect())
+	}
+}
+
+// accountReply decode an account with its Stake 2.0 fields, kept unknown as gRPC does
+func accountReply(v interface{}, reply proto.Message) error {
+	if err := objectReply(v, reply); err != nil {
+		return err
+	}
+	stake := new(pb.AccountStakeV2)
+	if err := objectReply(v, stake); err != nil {
+		return err
+	}
+	b, err := proto.Marshal(stake)
+	if err != nil {
+		return err
+	}
+	m := reply.ProtoReflect()
+	m.SetUnknown(append(m.GetUnknown(), b...))
+	return nil
+}
+
+// nodeInfoReply keep only the solidified block of the node info, the rest of it is the
+// node's own JSON rather than the protobuf one
+func nodeInfoReply(v interface{}, reply proto.Message) error {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("reply is not an object")
+	}
+	return decodeJSON(map[string]interface{}{"solidityBlock": obj["solidityBlock"]}, reply.ProtoReflect())
+}
+
+// broadcastRequest send the transaction as protobuf, its JSON would lose fields the node
+// does not print back
+func broadcastRequest(req proto.Message) (string, interface{}, error) {
+	b, err := proto.Marshal(req)
+	if err != nil {
+		return "", nil, err
+	}
+	return "broadcasthex", map[string]interface{}{"transaction": hex.EncodeToString(b)}, nil
+}
+
+func commonTransactionRequest(req proto.Message) (string, interface{}, error) {
+	contracts := req.(*core.Transaction).GetRawData().GetContract()
+	if len(contracts) != 1 {
+		return "", nil, fmt.Errorf("%d contracts", len(contracts))
+	}
+	path, ok := commonTransactionPaths[contracts[0].Type]
+	if !ok {
+		return "", nil, fmt.Errorf("no endpoint for %s", contracts[0].Type)
+	}
+	contract, err := unmarshalContractParameter(contracts[0].GetParameter())
+	if err != nil {
+		return "", nil, err
+	}
+	body, err := encodeJSON(contract.ProtoReflect())
+	return path, body, err
+}
@@ -0,0 +1,646 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	protov1 "github.com/golang/protobuf/proto"
+	pb "github.com/leondevpt/wallet/trxservice/api/v1"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// standInChain is the state a stand-in node serves over either backend in the backend
+// contract tests
+type standInChain struct {
+	owner, receiver, token []byte
+	balance, solidBalance  int64
+	frozenEnergy           int64
+	tokenBalance           int64
+	energyUsed             int64
+	head                   *api.BlockExtention // block 10 with a transfer
+	solidHead              *api.BlockExtention // block 9
+	info                   *core.TransactionInfo
+}
+
+func newStandInChain(t *testing.T) *standInChain {
+	c := &standInChain{
+		owner:        newTestAddress(t).Bytes(),
+		receiver:     newTestAddress(t).Bytes(),
+		token:        newTestAddress(t).Bytes(),
+		balance:      7 * sunPerTRX,
+		solidBalance: 5 * sunPerTRX,
+		frozenEnergy: 3 * sunPerTRX,
+		tokenBalance: 42,
+		energyUsed:   13045,
+	}
+	transfer, err := c.transfer(&core.TransferContract{OwnerAddress: c.owner, ToAddress: c.receiver, Amount: sunPerTRX})
+	if err != nil {
+		t.Fatal(err)
+	}
+	transfer.Transaction.Signature = [][]byte{bytes.Repeat([]byte{1}, 65)}
+	transfer.Transaction.Ret = []*core.Transaction_Result{{ContractRet: core.Transaction_Result_SUCCESS}}
+	block := func(num int64, txs ...*api.TransactionExtention) *api.BlockExtention {
+		return &api.BlockExtention{
+			Blockid:      append(big.NewInt(num).FillBytes(make([]byte, 8)), bytes.Repeat([]byte{byte(num)}, 24)...),
+			BlockHeader:  &core.BlockHeader{RawData: &core.BlockHeaderRaw{Number: num, Timestamp: 1666000000000 + num*3000, Version: 27}},
+			Transactions: txs,
+		}
+	}
+	c.head, c.solidHead = block(10, transfer), block(9)
+	sig, _ := common.FromHex(trc20TransferEventSignature)
+	topics := [][]byte{sig, common.LeftPadBytes(c.owner[1:], 32), common.LeftPadBytes(c.receiver[1:], 32)}
+	c.info = &core.TransactionInfo{
+		Id:             transfer.Txid,
+		Fee:            1100000,
+		BlockNumber:    10,
+		BlockTimeStamp: 1666000030000,
+		ContractResult: [][]byte{{}},
+		Receipt:        &core.ResourceReceipt{NetFee: 100000, Result: core.Transaction_Result_SUCCESS},
+		Log:            []*core.TransactionInfo_Log{{Address: c.token[1:], Topics: topics, Data: common.LeftPadBytes([]byte{5}, 32)}},
+	}
+	return c
+}
+
+// transfer build the transaction of a contract the way a node does
+func (c *standInChain) transfer(contract protov1.Message) (*api.TransactionExtention, error) {
+	typ := core.Transaction_Contract_TransferContract
+	switch contract.(type) {
+	case *core.TriggerSmartContract:
+		typ = core.Transaction_Contract_TriggerSmartContract
+	case *pb.FreezeBalanceV2Contract:
+		typ = FreezeBalanceV2ContractType
+	}
+	param, err := newContractParameter(protov1.MessageV2(contract))
+	if err != nil {
+		return nil, err
+	}
+	tx := &core.Transaction{RawData: &core.TransactionRaw{
+		RefBlockBytes: []byte{0, 10},
+		RefBlockHash:  bytes.Repeat([]byte{10}, 8),
+		Expiration:    1666000060000,
+		Timestamp:     1666000000000,
+		Contract:      []*core.Transaction_Contract{{Type: typ, Parameter: param}},
+	}}
+	txid, err := txHash(tx)
+	if err != nil {
+		return nil, err
+	}
+	return &api.TransactionExtention{Transaction: tx, Txid: txid, Result: &api.Return{Result: true}}, nil
+}
+
+func (c *standInChain) account(addr []byte, solid bool) (*core.Account, error) {
+	if !bytes.Equal(addr, c.owner) {
+		return &core.Account{}, nil
+	}
+	acc := &core.Account{Address: c.owner, Balance: c.balance}
+	if solid {
+		acc.Balance = c.solidBalance
+	}
+	stake, err := proto.Marshal(&pb.AccountStakeV2{FrozenV2: []*pb.AccountStakeV2_FreezeV2{{}, {Type: int32(core.ResourceCode_ENERGY), Amount: c.frozenEnergy}}})
+	acc.ProtoReflect().SetUnknown(stake)
+	return acc, err
+}
+
+func (c *standInChain) broadcast(tx *core.Transaction) *api.Return {
+	if len(tx.GetSignature()) == 0 {
+		return &api.Return{Code: api.Return_SIGERROR, Message: []byte("validate signature error")}
+	}
+	return &api.Return{Result: true}
+}
+
+func (c *standInChain) trigger(ct *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	ext, err := c.transfer(ct)
+	if err != nil {
+		return nil, err
+	}
+	ext.ConstantResult = [][]byte{common.LeftPadBytes(big.NewInt(c.tokenBalance).Bytes(), 32)}
+	return ext, nil
+}
+
+func (c *standInChain) signWeight(tx *core.Transaction) (*api.TransactionSignWeight, error) {
+	txid, err := txHash(tx)
+	if err != nil {
+		return nil, err
+	}
+	return &api.TransactionSignWeight{
+		Permission: &core.Permission{Type: core.Permission_Active, Id: 2, PermissionName: "active", Threshold: 2,
+			Keys: []*core.Key{{Address: c.owner, Weight: 1}, {Address: c.receiver, Weight: 1}}},
+		ApprovedList:  [][]byte{c.owner},
+		CurrentWeight: 1,
+		Result:        &api.TransactionSignWeight_Result{Code: api.TransactionSignWeight_Result_NOT_ENOUGH_PERMISSION},
+		Transaction:   &api.TransactionExtention{Transaction: tx, Txid: txid, Result: &api.Return{Result: true}},
+	}, nil
+}
+
+// chainWallet serves the chain over gRPC
+type chainWallet struct {
+	api.UnimplementedWalletServer
+	c *standInChain
+}
+
+func (s *chainWallet) GetAccount(ctx context.Context, in *core.Account) (*core.Account, error) {
+	return s.c.account(in.Address, false)
+}
+
+func (s *chainWallet) GetAccountResource(ctx context.Context, in *core.Account) (*api.AccountResourceMessage, error) {
+	return &api.AccountResourceMessage{FreeNetLimit: 600, EnergyLimit: 1000, EnergyUsed: 10, AssetNetUsed: map[string]int64{"1000001": 5}}, nil
+}
+
+func (s *chainWallet) CreateTransaction2(ctx context.Context, in *core.TransferContract) (*api.TransactionExtention, error) {
+	if in.Amount <= 0 {
+		return &api.TransactionExtention{Result: &api.Return{Code: api.Return_CONTRACT_VALIDATE_ERROR, Message: []byte("Amount must be greater than 0.")}}, nil
+	}
+	return s.c.transfer(in)
+}
+
+func (s *chainWallet) CreateCommonTransaction(ctx context.Context, in *core.Transaction) (*api.TransactionExtention, error) {
+	contract, err := unmarshalContractParameter(in.RawData.Contract[0].Parameter)
+	if err != nil {
+		return nil, err
+	}
+	return s.c.transfer(protov1.MessageV1(contract))
+}
+
+func (s *chainWallet) BroadcastTransaction(ctx context.Context, in *core.Transaction) (*api.Return, error) {
+	return s.c.broadcast(in), nil
+}
+
+func (s *chainWallet) GetTransactionSignWeight(ctx context.Context, in *core.Transaction) (*api.TransactionSignWeight, error) {
+	return s.c.signWeight(in)
+}
+
+func (s *chainWallet) GetNowBlock2(ctx context.Context, in *api.EmptyMessage) (*api.BlockExtention, error) {
+	return s.c.head, nil
+}
+
+func (s *chainWallet) GetBlockByNum2(ctx context.Context, in *api.NumberMessage) (*api.BlockExtention, error) {
+	if in.Num != s.c.head.BlockHeader.RawData.Number {
+		return &api.BlockExtention{}, nil
+	}
+	return s.c.head, nil
+}
+
+func (s *chainWallet) GetTransactionInfoById(ctx context.Context, in *api.BytesMessage) (*core.TransactionInfo, error) {
+	if !bytes.Equal(in.Value, s.c.info.Id) {
+		return &core.TransactionInfo{}, nil
+	}
+	return s.c.info, nil
+}
+
+func (s *chainWallet) GetTransactionInfoByBlockNum(ctx context.Context, in *api.NumberMessage) (*api.TransactionInfoList, error) {
+	return &api.TransactionInfoList{TransactionInfo: []*core.TransactionInfo{s.c.info}}, nil
+}
+
+func (s *chainWallet) GetChainParameters(ctx context.Context, in *api.EmptyMessage) (*core.ChainParameters, error) {
+	return &core.ChainParameters{ChainParameter: []*core.ChainParameters_ChainParameter{{Key: "getEnergyFee", Value: 420}}}, nil
+}
+
+func (s *chainWallet) TriggerConstantContract(ctx context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+	ext, err := s.c.trigger(in)
+	if err != nil {
+		return nil, err
+	}
+	b := protowire.AppendTag(nil, txExtentionEnergyUsedField, protowire.VarintType)
+	ext.ProtoReflect().SetUnknown(protowire.AppendVarint(b, uint64(s.c.energyUsed)))
+	return ext, nil
+}
+
+func (s *chainWallet) GetNodeInfo(ctx context.Context, in *api.EmptyMessage) (*core.NodeInfo, error) {
+	return &core.NodeInfo{SolidityBlock: "Num:9,ID:0000000000000009"}, nil
+}
+
+type chainSolidity struct {
+	api.UnimplementedWalletSolidityServer
+	c *standInChain
+}
+
+func (s *chainSolidity) GetAccount(ctx context.Context, in *core.Account) (*core.Account, error) {
+	return s.c.account(in.Address, true)
+}
+
+func (s *chainSolidity) GetNowBlock2(ctx context.Context, in *api.EmptyMessage) (*api.BlockExtention, error) {
+	return s.c.solidHead, nil
+}
+
+func (c *standInChain) serveGRPC(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	api.RegisterWalletServer(s, &chainWallet{c: c})
+	api.RegisterWalletSolidityServer(s, &chainSolidity{c: c})
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	return lis.Addr().String()
+}
+
+// the stand-in HTTP API renders its JSON by hand, the way java-tron prints it
+
+func txJSON(ext *api.TransactionExtention) map[string]interface{} {
+	raw, _ := proto.Marshal(ext.Transaction.RawData)
+	tx := map[string]interface{}{
+		"visible": false,
+		"txID":    hex.EncodeToString(ext.Txid),
+		"raw_data": map[string]interface{}{
+			"contract":        []interface{}{map[string]interface{}{"type": ext.Transaction.RawData.Contract[0].Type.String()}},
+			"ref_block_bytes": hex.EncodeToString(ext.Transaction.RawData.RefBlockBytes),
+			"expiration":      ext.Transaction.RawData.Expiration,
+		},
+		"raw_data_hex": hex.EncodeToString(raw),
+	}
+	var sigs []interface{}
+	for _, sig := range ext.Transaction.Signature {
+		sigs = append(sigs, hex.EncodeToString(sig))
+	}
+	if sigs != nil {
+		tx["signature"] = sigs
+	}
+	if len(ext.Transaction.Ret) > 0 {
+		tx["ret"] = []interface{}{map[string]interface{}{"contractRet": ext.Transaction.Ret[0].ContractRet.String()}}
+	}
+	return tx
+}
+
+func blockJSON(b *api.BlockExtention) map[string]interface{} {
+	txs := []interface{}{}
+	for _, tx := range b.Transactions {
+		txs = append(txs, txJSON(tx))
+	}
+	raw := b.BlockHeader.RawData
+	block := map[string]interface{}{
+		"blockID": hex.EncodeToString(b.Blockid),
+		"block_header": map[string]interface{}{
+			"raw_data":          map[string]interface{}{"number": raw.Number, "timestamp": raw.Timestamp, "version": raw.Version},
+			"witness_signature": "00",
+		},
+	}
+	if len(txs) > 0 {
+		block["transactions"] = txs
+	}
+	return block
+}
+
+func infoJSON(info *core.TransactionInfo) map[string]interface{} {
+	var logs []interface{}
+	for _, l := range info.Log {
+		var topics []interface{}
+		for _, topic := range l.Topics {
+			topics = append(topics, hex.EncodeToString(topic))
+		}
+		logs = append(logs, map[string]interface{}{"address": hex.EncodeToString(l.Address), "topics": topics, "data": hex.EncodeToString(l.Data)})
+	}
+	return map[string]interface{}{
+		"id":             hex.EncodeToString(info.Id),
+		"fee":            info.Fee,
+		"blockNumber":    info.BlockNumber,
+		"blockTimeStamp": info.BlockTimeStamp,
+		"contractResult": []interface{}{""},
+		"receipt":        map[string]interface{}{"net_fee": info.Receipt.NetFee, "result": info.Receipt.Result.String()},
+		"log":            logs,
+	}
+}
+
+func (c *standInChain) serveHTTP(t *testing.T) string {
+	t.Helper()
+	mux := http.NewServeMux()
+	handle := func(path string, fn func(req map[string]interface{}) (interface{}, error)) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			var req map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			reply, err := fn(req)
+			if err != nil {
+				reply = map[string]interface{}{"Error": err.Error()}
+			}
+			json.NewEncoder(w).Encode(reply)
+		})
+	}
+	decode := func(req map[string]interface{}, key string) []byte {
+		b, _ := hex.DecodeString(fmt.Sprint(req[key]))
+		return b
+	}
+	number := func(req map[string]interface{}, key string) int64 {
+		n, _ := req[key].(float64)
+		return int64(n)
+	}
+	accountJSON := func(req map[string]interface{}, solid bool) (interface{}, error) {
+		acc, err := c.account(decode(req, "address"), solid)
+		if err != nil || len(acc.Address) == 0 {
+			return map[string]interface{}{}, err
+		}
+		return map[string]interface{}{
+			"address":          hex.EncodeToString(acc.Address),
+			"balance":          acc.Balance,
+			"create_time":      1665000000000,
+			"frozenV2":         []interface{}{map[string]interface{}{}, map[string]interface{}{"type": "ENERGY", "amount": c.frozenEnergy}, map[string]interface{}{"type": "TRON_POWER"}},
+			"account_resource": map[string]interface{}{"energy_window_size": 28800},
+		}, nil
+	}
+
+	handle("/wallet/getaccount", func(req map[string]interface{}) (interface{}, error) { return accountJSON(req, false) })
+	handle("/walletsolidity/getaccount", func(req map[string]interface{}) (interface{}, error) { return accountJSON(req, true) })
+	handle("/wallet/getaccountresource", func(req map[string]interface{}) (interface{}, error) {
+		return map[string]interface{}{"freeNetLimit": 600, "EnergyLimit": 1000, "EnergyUsed": 10,
+			"assetNetUsed": []interface{}{map[string]interface{}{"key": "1000001", "value": 5}}}, nil
+	})
+	handle("/wallet/createtransaction", func(req map[string]interface{}) (interface{}, error) {
+		if number(req, "amount") <= 0 {
+			return nil, errors.New("class org.tron.core.exception.ContractValidateException : Amount must be greater than 0.")
+		}
+		ext, err := c.transfer(&core.TransferContract{OwnerAddress: decode(req, "owner_address"), ToAddress: decode(req, "to_address"), Amount: number(req, "amount")})
+		if err != nil {
+			return nil, err
+		}
+		return txJSON(ext), nil
+	})
+	handle("/wallet/freezebalancev2", func(req map[string]interface{}) (interface{}, error) {
+		ext, err := c.transfer(&pb.FreezeBalanceV2Contract{OwnerAddress: decode(req, "owner_address"), FrozenBalance: number(req, "frozen_balance"),
+			Resource: int32(number(req, "resource"))})
+		if err != nil {
+			return nil, err
+		}
+		return txJSON(ext), nil
+	})
+	handle("/wallet/broadcasthex", func(req map[string]interface{}) (interface{}, error) {
+		tx := new(core.Transaction)
+		if err := proto.Unmarshal(decode(req, "transaction"), tx); err != nil {
+			return nil, err
+		}
+		txid, _ := txHash(tx)
+		ret := c.broadcast(tx)
+		if !ret.Result {
+			return map[string]interface{}{"result": false, "code": ret.Code.String(), "txid": hex.EncodeToString(txid), "message": hex.EncodeToString(ret.Message)}, nil
+		}
+		return map[string]interface{}{"result": true, "txid": hex.EncodeToString(txid)}, nil
+	})
+	handle("/wallet/getsignweight", func(req map[string]interface{}) (interface{}, error) {
+		// java-tron reads the contract of raw_data by its type_url
+		raw, _ := req["raw_data"].(map[string]interface{})
+		contracts, _ := raw["contract"].([]interface{})
+		if len(contracts) != 1 {
+			return nil, errors.New("no contract")
+		}
+		param, _ := contracts[0].(map[string]interface{})["parameter"].(map[string]interface{})
+		value, _ := param["value"].(map[string]interface{})
+		if param["type_url"] != "type.googleapis.com/protocol.TransferContract" || value["owner_address"] != hex.EncodeToString(c.owner) {
+			return nil, fmt.Errorf("contract %v", param)
+		}
+		rawHex, _ := hex.DecodeString(fmt.Sprint(req["raw_data_hex"]))
+		tx := &core.Transaction{RawData: new(core.TransactionRaw)}
+		if err := proto.Unmarshal(rawHex, tx.RawData); err != nil {
+			return nil, err
+		}
+		w, err := c.signWeight(tx)
+		if err != nil {
+			return nil, err
+		}
+		var keys []interface{}
+		for _, k := range w.Permission.Keys {
+			keys = append(keys, map[string]interface{}{"address": hex.EncodeToString(k.Address), "weight": k.Weight})
+		}
+		return map[string]interface{}{
+			"permission":     map[string]interface{}{"type": "Active", "id": 2, "permission_name": "active", "threshold": 2, "keys": keys},
+			"current_weight": w.CurrentWeight,
+			"result":         map[string]interface{}{"code": w.Result.Code.String()},
+			"approved_list":  []interface{}{hex.EncodeToString(c.owner)},
+			"transaction":    map[string]interface{}{"result": map[string]interface{}{"result": true}, "txid": hex.EncodeToString(w.Transaction.Txid), "transaction": txJSON(w.Transaction)},
+		}, nil
+	})
+	handle("/wallet/getnowblock", func(req map[string]interface{}) (interface{}, error) { return blockJSON(c.head), nil })
+	handle("/walletsolidity/getnowblock", func(req map[string]interface{}) (interface{}, error) { return blockJSON(c.solidHead), nil })
+	handle("/wallet/getblockbynum", func(req map[string]interface{}) (interface{}, error) {
+		if number(req, "num") != c.head.BlockHeader.RawData.Number {
+			return map[string]interface{}{}, nil
+		}
+		return blockJSON(c.head), nil
+	})
+	handle("/wallet/gettransactioninfobyid", func(req map[string]interface{}) (interface{}, error) {
+		if !bytes.Equal(decode(req, "value"), c.info.Id) {
+			return map[string]interface{}{}, nil
+		}
+		return infoJSON(c.info), nil
+	})
+	handle("/wallet/gettransactioninfobyblocknum", func(req map[string]interface{}) (interface{}, error) {
+		return []interface{}{infoJSON(c.info)}, nil
+	})
+	handle("/wallet/getchainparameters", func(req map[string]interface{}) (interface{}, error) {
+		return map[string]interface{}{"chainParameter": []interface{}{
+			map[string]interface{}{"key": "getEnergyFee", "value": 420}, map[string]interface{}{"key": "getAllowCreationOfContracts"}}}, nil
+	})
+	handle("/wallet/triggerconstantcontract", func(req map[string]interface{}) (interface{}, error) {
+		ext, err := c.trigger(&core.TriggerSmartContract{OwnerAddress: decode(req, "owner_address"), ContractAddress: decode(req, "contract_address"),
+			Data: decode(req, "data")})
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"result":          map[string]interface{}{"result": true},
+			"energy_used":     c.energyUsed,
+			"constant_result": []interface{}{hex.EncodeToString(ext.ConstantResult[0])},
+			"transaction":     txJSON(ext),
+		}, nil
+	})
+	handle("/wallet/getnodeinfo", func(req map[string]interface{}) (interface{}, error) {
+		return map[string]interface{}{"beginSyncNum": 1, "block": "Num:10,ID:000000000000000a", "solidityBlock": "Num:9,ID:0000000000000009",
+			"machineInfo": map[string]interface{}{"threadCount": 42}}, nil
+	})
+
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s.URL
+}
+
+// testBackendContract check the calls TronCli makes give the same results over every backend
+func testBackendContract(t *testing.T, c *standInChain, cli *TronCli) {
+	ctx := context.Background()
+	owner, receiver, token := base58Addr(c.owner), base58Addr(c.receiver), base58Addr(c.token)
+
+	// accounts
+	if balance, activated, err := cli.GetAccountBalance(ctx, owner); err != nil || balance != c.balance || !activated {
+		t.Fatalf("balance %d %v: %v", balance, activated, err)
+	}
+	if _, activated, err := cli.GetAccountBalance(ctx, receiver); err != nil || activated {
+		t.Fatalf("new account activated %v: %v", activated, err)
+	}
+	if balance, _, err := cli.GetSolidAccountBalance(ctx, owner); err != nil || balance != c.solidBalance {
+		t.Fatalf("solid balance %d: %v", balance, err)
+	}
+	if _, stake, err := cli.GetAccount(ctx, owner); err != nil || len(stake.FrozenV2) < 2 || stake.FrozenV2[1].Type != int32(core.ResourceCode_ENERGY) ||
+		stake.FrozenV2[1].Amount != c.frozenEnergy {
+		t.Fatalf("stake %v: %v", stake, err)
+	}
+	if res, err := cli.GetAccountResource(ctx, owner); err != nil || res.EnergyLimit != 1000 || res.EnergyUsed != 10 || res.AssetNetUsed["1000001"] != 5 {
+		t.Fatalf("resource %v: %v", res, err)
+	}
+	if params, err := cli.GetChainParameters(ctx); err != nil || params["getEnergyFee"] != 420 {
+		t.Fatalf("params %v: %v", params, err)
+	}
+
+	// transfers
+	tx, err := cli.Transfer(ctx, owner, receiver, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transfer := new(core.TransferContract)
+	if txid, _ := txHash(tx.Transaction); !bytes.Equal(txid, tx.Txid) || unmarshalContract(tx.Transaction.RawData.Contract[0], transfer) != nil ||
+		transfer.Amount != 5 || !bytes.Equal(transfer.ToAddress, c.receiver) {
+		t.Fatalf("transfer %v", tx)
+	}
+	if _, err := cli.Transfer(ctx, owner, receiver, 0); err == nil {
+		t.Fatal("built a transfer of nothing")
+	}
+	var rejected *BroadcastError
+	if _, err := cli.Broadcast(ctx, tx.Transaction); !errors.As(err, &rejected) || rejected.Code != api.Return_SIGERROR {
+		t.Fatalf("broadcast unsigned: %v", err)
+	}
+	weight, err := cli.GetTransactionSignWeight(ctx, tx.Transaction)
+	if err != nil || weight.Permission.Threshold != 2 || weight.CurrentWeight != 1 || len(weight.ApprovedList) != 1 ||
+		weight.Result.Code != api.TransactionSignWeight_Result_NOT_ENOUGH_PERMISSION || !bytes.Equal(weight.Transaction.Txid, tx.Txid) {
+		t.Fatalf("weight %v: %v", weight, err)
+	}
+	tx.Transaction.Signature = [][]byte{bytes.Repeat([]byte{1}, 65)}
+	if _, err := cli.Broadcast(ctx, tx.Transaction); err != nil {
+		t.Fatal(err)
+	}
+
+	// blocks and receipts
+	want := c.head.Transactions[0]
+	for _, get := range []func() (*api.BlockExtention, error){
+		func() (*api.BlockExtention, error) { return cli.GetNowBlock(ctx) },
+		func() (*api.BlockExtention, error) { return cli.GetBlockByNum(ctx, 10) },
+	} {
+		block, err := get()
+		if err != nil || block.BlockHeader.RawData.Number != 10 || !bytes.Equal(block.Blockid, c.head.Blockid) || len(block.Transactions) != 1 {
+			t.Fatalf("block %v: %v", block, err)
+		}
+		got := block.Transactions[0]
+		if !bytes.Equal(got.Txid, want.Txid) || !proto.Equal(got.Transaction, want.Transaction) || !contractSucceeded(got.Transaction) {
+			t.Fatalf("block transaction %v", got)
+		}
+	}
+	if _, err := cli.GetBlockByNum(ctx, 11); err == nil {
+		t.Fatal("got a block beyond the head")
+	}
+	info, err := cli.GetTransactionInfoById(ctx, hex.EncodeToString(want.Txid))
+	if err != nil || !proto.Equal(info, c.info) {
+		t.Fatalf("receipt %v: %v", info, err)
+	}
+	if info, err := cli.GetTransactionInfoById(ctx, hex.EncodeToString(tx.Txid)); err != nil || info.BlockNumber != 0 {
+		t.Fatalf("receipt of a pending tx %v: %v", info, err)
+	}
+	infos, err := cli.GetTransactionInfoByBlockNum(ctx, 10)
+	if err != nil || len(infos) != 1 || !proto.Equal(infos[0], c.info) {
+		t.Fatalf("receipts %v: %v", infos, err)
+	}
+	if num, err := cli.GetSolidBlockNum(ctx); err != nil || num != 9 {
+		t.Fatalf("solid block %d: %v", num, err)
+	}
+
+	// contracts
+	if balance, err := cli.TRC20ContractBalance(ctx, owner, token); err != nil || balance.Int64() != c.tokenBalance {
+		t.Fatalf("token balance %v: %v", balance, err)
+	}
+	est, err := cli.TRC20EstimateSend(ctx, owner, receiver, token, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if used, ok := EnergyUsed(est); !ok || used != c.energyUsed {
+		t.Fatalf("energy used %d %v", used, ok)
+	}
+
+	// Stake 2.0
+	freeze, err := cli.FreezeBalanceV2(ctx, owner, 10*sunPerTRX, core.ResourceCode_ENERGY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := freeze.Transaction.RawData.Contract[0]
+	param, err := unmarshalContractParameter(created.Parameter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contract, ok := param.(*pb.FreezeBalanceV2Contract); !ok || created.Type != FreezeBalanceV2ContractType ||
+		contract.FrozenBalance != 10*sunPerTRX || contract.Resource != int32(core.ResourceCode_ENERGY) {
+		t.Fatalf("freeze %v", freeze.Transaction.RawData)
+	}
+}
+
+func base58Addr(b []byte) string {
+	return common.EncodeCheck(b)
+}
+
+func TestBackendContract(t *testing.T) {
+	c := newStandInChain(t)
+	for backend, serve := range map[string]func(*testing.T) string{NodeBackendGRPC: c.serveGRPC, NodeBackendHTTP: c.serveHTTP} {
+		t.Run(backend, func(t *testing.T) {
+			addr := serve(t)
+			cfg := &setting.Config{
+				App:  setting.App{Node_Addr: []string{addr}, Solidity_Addr: []string{addr}},
+				Tron: setting.Tron{Nodes: []setting.TronNode{{Addr: addr, Backend: backend}}},
+			}
+			cli := NewTronCli(NewNodePool(cfg, zap.NewNop()), NewSolidityNodePool(cfg, zap.NewNop()))
+			defer cli.Stop()
+			cli.SetTimeout(5 * time.Second)
+			testBackendContract(t, c, cli)
+		})
+	}
+}
+
+func TestHTTPConnErrors(t *testing.T) {
+	var calls int
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch {
+		case r.Header.Get(apiKeyHeader) == "revoked":
+			http.Error(w, "invalid api key", http.StatusUnauthorized)
+		case calls == 2:
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+		default:
+			fmt.Fprint(w, `{"Error": "class java.lang.NullPointerException : null"}`)
+		}
+	}))
+	defer s.Close()
+	cfg := &setting.Config{
+		App:  setting.App{Node_Addr: []string{s.URL}},
+		Tron: setting.Tron{RetryBackoff: 1, Nodes: []setting.TronNode{{Addr: s.URL, Backend: NodeBackendHTTP, APIKeys: []string{"revoked", "good"}}}},
+	}
+	p := NewNodePool(cfg, zap.NewNop())
+	defer p.Close()
+	ctx := context.Background()
+	wallet := api.NewWalletClient(p)
+
+	// refused key rotated, unavailable node retried, then the node's own error
+	if _, err := wallet.GetNowBlock2(ctx, new(api.EmptyMessage)); status.Code(err) != codes.Unknown || calls != 3 {
+		t.Fatalf("%d calls: %v", calls, err)
+	}
+	// builders report it as a refused contract
+	ext, err := wallet.CreateTransaction2(ctx, new(core.TransferContract))
+	if err != nil || ext.Result.Code != api.Return_OTHER_ERROR {
+		t.Fatalf("built %v: %v", ext, err)
+	}
+	if _, err := wallet.ListWitnesses(ctx, new(api.EmptyMessage)); status.Code(err) != codes.Unimplemented {
+		t.Fatalf("no route: %v", err)
+	}
+}
@@ -0,0 +1,320 @@
+package biz
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// The JSON of java-tron's HTTP API is close to protojson but for bytes in hex, field names
+// as they come and transactions carrying their raw data as raw_data_hex. encodeJSON and
+// decodeJSON translate between it and the gRPC messages.
+
+// encodeJSON render m the way java-tron's HTTP API reads it, addresses in hex
+func encodeJSON(m protoreflect.Message) (map[string]interface{}, error) {
+	if a, ok := m.Interface().(*anypb.Any); ok {
+		// contract parameters, e.g. of a transaction checked by getsignweight
+		value, err := unmarshalContractParameter(a)
+		if err != nil {
+			return nil, err
+		}
+		obj, err := encodeJSON(value.ProtoReflect())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type_url": a.TypeUrl, "value": obj}, nil
+	}
+	obj := make(map[string]interface{})
+	var err error
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		var value interface{}
+		switch {
+		case fd.IsList():
+			list := make([]interface{}, v.List().Len())
+			for i := range list {
+				if list[i], err = encodeValue(fd, v.List().Get(i)); err != nil {
+					break
+				}
+			}
+			value = list
+		case fd.IsMap():
+			fields := make(map[string]interface{})
+			v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+				fields[k.String()], err = encodeValue(fd.MapValue(), v)
+				return err == nil
+			})
+			value = fields
+		default:
+			value, err = encodeValue(fd, v)
+		}
+		obj[string(fd.Name())] = value
+		return err == nil
+	})
+	if tx, ok := m.Interface().(*core.Transaction); ok && err == nil && tx.RawData != nil {
+		// as java-tron prints it, the exact bytes the txid hashes
+		raw, err := proto.Marshal(tx.RawData)
+		if err != nil {
+			return nil, err
+		}
+		obj["raw_data_hex"] = hex.EncodeToString(raw)
+	}
+	return obj, err
+}
+
+func encodeValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) (interface{}, error) {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return encodeJSON(v.Message())
+	case protoreflect.BytesKind:
+		return hex.EncodeToString(v.Bytes()), nil
+	case protoreflect.EnumKind:
+		if e := fd.Enum().Values().ByNumber(v.Enum()); e != nil {
+			return string(e.Name()), nil
+		}
+		return int32(v.Enum()), nil
+	}
+	return v.Interface(), nil
+}
+
+// decodeJSON set m from obj, a JSON object of java-tron's HTTP API decoded with UseNumber.
+// Field names match regardless of case, unknown ones are skipped.
+func decodeJSON(obj map[string]interface{}, m protoreflect.Message) error {
+	fields := m.Descriptor().Fields()
+	switch m.Descriptor().FullName() {
+	case "protocol.Transaction":
+		// raw_data in JSON holds contracts by name, the protobuf bytes are exact
+		if s, ok := obj["raw_data_hex"].(string); ok {
+			raw, err := hex.DecodeString(s)
+			if err != nil {
+				return fmt.Errorf("raw_data_hex: %v", err)
+			}
+			if err := proto.Unmarshal(raw, m.Mutable(fields.ByName("raw_data")).Message().Interface()); err != nil {
+				return fmt.Errorf("raw_data_hex: %v", err)
+			}
+			obj = without(obj, "raw_data")
+		}
+	case "protocol.TransactionExtention":
+		// blocks and transaction builders give bare transactions
+		if _, ok := obj["transaction"]; !ok && (obj["raw_data_hex"] != nil || obj["txID"] != nil) {
+			if err := decodeJSON(obj, m.Mutable(fields.ByName("transaction")).Message()); err != nil {
+				return err
+			}
+			return decodeJSON(map[string]interface{}{"txid": obj["txID"], "result": map[string]interface{}{"result": true}}, m)
+		}
+		// energy_used is newer than gotron-sdk's TransactionExtention, see EnergyUsed
+		if n, ok := obj["energy_used"].(json.Number); ok {
+			used, err := n.Int64()
+			if err != nil {
+				return fmt.Errorf("energy_used: %v", err)
+			}
+			b := protowire.AppendTag(m.GetUnknown(), txExtentionEnergyUsedField, protowire.VarintType)
+			m.SetUnknown(protowire.AppendVarint(b, uint64(used)))
+		}
+	}
+
+	for key, v := range obj {
+		fd := jsonField(fields, key)
+		if fd == nil || v == nil {
+			continue
+		}
+		if err := decodeField(m, fd, v); err != nil {
+			return fmt.Errorf("%s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+func without(obj map[string]interface{}, key string) map[string]interface{} {
+	c := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		if k != key {
+			c[k] = v
+		}
+	}
+	return c
+}
+
+// jsonField find the field key names, java-tron mixes blockID, NetUsed and owner_address
+func jsonField(fields protoreflect.FieldDescriptors, key string) protoreflect.FieldDescriptor {
+	if fd := fields.ByName(protoreflect.Name(key)); fd != nil {
+		return fd
+	}
+	if fd := fields.ByJSONName(key); fd != nil {
+		return fd
+	}
+	for i := 0; i < fields.Len(); i++ {
+		if fd := fields.Get(i); strings.EqualFold(string(fd.Name()), key) {
+			return fd
+		}
+	}
+	return nil
+}
+
+func decodeField(m protoreflect.Message, fd protoreflect.FieldDescriptor, v interface{}) error {
+	switch {
+	case fd.IsList():
+		items, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("not a list")
+		}
+		list := m.Mutable(fd).List()
+		for _, item := range items {
+			value, err := decodeValue(fd, item, list.NewElement)
+			if err != nil {
+				return err
+			}
+			list.Append(value)
+		}
+	case fd.IsMap():
+		items, err := mapEntries(v)
+		if err != nil {
+			return err
+		}
+		fields := m.Mutable(fd).Map()
+		for k, item := range items {
+			if item == nil {
+				continue
+			}
+			key, err := decodeValue(fd.MapKey(), k, nil)
+			if err != nil {
+				return err
+			}
+			value, err := decodeValue(fd.MapValue(), item, fields.NewValue)
+			if err != nil {
+				return err
+			}
+			fields.Set(key.MapKey(), value)
+		}
+	case fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("not an object")
+		}
+		return decodeJSON(obj, m.Mutable(fd).Message())
+	default:
+		value, err := decodeValue(fd, v, nil)
+		if err != nil {
+			return err
+		}
+		m.Set(fd, value)
+	}
+	return nil
+}
+
+// mapEntries return the entries of a map field, java-tron prints them as a list of key
+// value pairs
+func mapEntries(v interface{}) (map[string]interface{}, error) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return v, nil
+	case []interface{}:
+		entries := make(map[string]interface{}, len(v))
+		for _, e := range v {
+			entry, ok := e.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("map entry %v not an object", e)
+			}
+			entries[fmt.Sprint(entry["key"])] = entry["value"]
+		}
+		return entries, nil
+	}
+	return nil, fmt.Errorf("not a map")
+}
+
+// decodeValue convert a JSON value to the kind of fd, messages are made by newMessage
+func decodeValue(fd protoreflect.FieldDescriptor, v interface{}, newMessage func() protoreflect.Value) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("not an object")
+		}
+		value := newMessage()
+		return value, decodeJSON(obj, value.Message())
+	case protoreflect.BoolKind:
+		b, ok := v.(bool)
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("%v not a bool", v)
+		}
+		return protoreflect.ValueOfBool(b), nil
+	case protoreflect.StringKind:
+		s, ok := v.(string)
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("%v not a string", v)
+		}
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BytesKind:
+		s, ok := v.(string)
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("%v not a hex string", v)
+		}
+		b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		return protoreflect.ValueOfBytes(b), nil
+	case protoreflect.EnumKind:
+		if s, ok := v.(string); ok {
+			if e := fd.Enum().Values().ByName(protoreflect.Name(s)); e != nil {
+				return protoreflect.ValueOfEnum(e.Number()), nil
+			}
+		}
+		n, err := jsonInt(v, 32)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("%v not a %s", v, fd.Enum().Name())
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), nil
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		n, ok := v.(json.Number)
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("%v not a number", v)
+		}
+		f, err := n.Float64()
+		if fd.Kind() == protoreflect.FloatKind {
+			return protoreflect.ValueOfFloat32(float32(f)), err
+		}
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := jsonInt(v, 32)
+		if err != nil {
+			// the resources of tron_stake.proto are ResourceCode held as int32
+			if code, ok := core.ResourceCode_value[fmt.Sprint(v)]; ok {
+				return protoreflect.ValueOfInt32(code), nil
+			}
+			if v == "TRON_POWER" {
+				// newer than gotron-sdk's ResourceCode
+				return protoreflect.ValueOfInt32(2), nil
+			}
+		}
+		return protoreflect.ValueOfInt32(int32(n)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := jsonInt(v, 64)
+		return protoreflect.ValueOfInt64(n), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(fmt.Sprint(v), 10, 32)
+		return protoreflect.ValueOfUint32(uint32(n)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(fmt.Sprint(v), 10, 64)
+		return protoreflect.ValueOfUint64(n), err
+	}
+	return protoreflect.Value{}, fmt.Errorf("unsupported field kind %s", fd.Kind())
+}
+
+// jsonInt parse a number, or a string of one as map keys and large numbers come
+func jsonInt(v interface{}, bits int) (int64, error) {
+	switch v := v.(type) {
+	case json.Number:
+		return strconv.ParseInt(v.String(), 10, bits)
+	case string:
+		return strconv.ParseInt(v, 10, bits)
+	}
+	return 0, fmt.Errorf("%v not a number", v)
+}
@@ -0,0 +1,212 @@
+package biz
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultCallRetries     = 2
+	defaultRetryBackoff    = 100 * time.Millisecond
+	maxRetryBackoff        = 5 * time.Second
+	defaultBreakerFailures = 5
+	defaultBreakerCooldown = 30 * time.Second
+)
+
+var (
+	nodeCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "tron_node_calls_total",
+		Help: "Calls sent to a TRON node by gRPC status code.",
+	}, []string{"pool", "addr", "code"})
+	nodeCallSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "tron_node_call_seconds",
+		Help: "Time a TRON node took to answer a call.",
+	}, []string{"pool", "addr"})
+	nodeRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "tron_node_retries_total",
+		Help: "Calls sent again after a node answered unavailable or throttled.",
+	}, []string{"pool", "code"})
+	nodeRateLimitSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "tron_node_rate_limit_wait_seconds",
+		Help: "Time a call waited for the rate limit of its node.",
+	}, []string{"pool", "addr"})
+	nodeBreakerOpen = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "tron_node_breaker_open",
+		Help: "1 while the circuit breaker of a node keeps it out.",
+	}, []string{"pool", "addr"})
+	nodeBreakerTrips = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "tron_node_breaker_trips_total",
+		Help: "Times the circuit breaker of a node opened.",
+	}, []string{"pool", "addr"})
+)
+
+func init() {
+	prometheus.MustRegister(nodeCalls, nodeCallSeconds, nodeRetries, nodeRateLimitSeconds, nodeBreakerOpen, nodeBreakerTrips)
+}
+
+// tokenBucket limits the calls to a node to rate per second with bursts of burst calls
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // no limit when 0
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// nodeRate return the rate limit and burst of node
+func nodeRate(cfg setting.Tron, node setting.TronNode) (float64, int) {
+	rate := node.RateLimit
+	if rate <= 0 {
+		rate = cfg.RateLimit
+	}
+	burst := cfg.RateBurst
+	if burst <= 0 {
+		burst = int(math.Ceil(rate))
+	}
+	return rate, burst
+}
+
+func (b *tokenBucket) set(rate float64, burst int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.rate != rate || b.burst != float64(burst) {
+		b.rate, b.burst, b.tokens, b.last = rate, float64(burst), float64(burst), time.Now()
+	}
+}
+
+// wait take a token, waiting for it as long as ctx allows
+func (b *tokenBucket) wait(ctx context.Context) (time.Duration, error) {
+	b.mu.Lock()
+	if b.rate <= 0 {
+		b.mu.Unlock()
+		return 0, nil
+	}
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	delay := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mu.Unlock()
+	if delay <= 0 {
+		return 0, nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		b.putBack()
+		return 0, status.Error(codes.DeadlineExceeded, "deadline exceeded waiting for the rate limit of tron node")
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		b.putBack()
+		return 0, status.FromContextError(ctx.Err()).Err()
+	case <-timer.C:
+		return delay, nil
+	}
+}
+
+func (b *tokenBucket) putBack() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+// breaker takes a node out for a cool-down once its calls failed too often in a row. After
+// the cool-down a single trial call either closes it or opens it again.
+type breaker struct {
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time // zero while closed
+	trial     bool      // a trial call is in flight
+}
+
+// allow report whether a call may go to the node, claiming the trial call after a cool-down
+func (b *breaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.openUntil.IsZero() {
+		return true
+	}
+	if now.Before(b.openUntil) || b.trial {
+		return false
+	}
+	b.trial = true
+	return true
+}
+
+// release give up an allowed call that was not sent
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+}
+
+// record the outcome of an allowed call, report whether it opened or closed the breaker
+func (b *breaker) record(failed bool, cfg setting.Tron, now time.Time) (opened, closed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+	if !failed {
+		closed = !b.openUntil.IsZero()
+		b.failures, b.openUntil = 0, time.Time{}
+		return false, closed
+	}
+	b.failures++
+	threshold := cfg.BreakerFailures
+	if threshold <= 0 {
+		threshold = defaultBreakerFailures
+	}
+	if b.failures < threshold && b.openUntil.IsZero() {
+		return false, false
+	}
+	cooldown := time.Duration(cfg.BreakerCooldown) * time.Second
+	if cooldown <= 0 {
+		cooldown = defaultBreakerCooldown
+	}
+	opened = b.openUntil.IsZero() || !now.Before(b.openUntil)
+	b.openUntil = now.Add(cooldown)
+	return opened, false
+}
+
+// nodeFailed report whether err tells the node is down, throttling or too slow for the call
+// timeout, as opposed to it answering the call with an error
+func nodeFailed(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// retryableCall report whether a call may be sent again: the node was unreachable or
+// throttled it, so did not process it
+func retryableCall(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	}
+	return false
+}
+
+// retryBackoff return the jittered wait before retry attempt, 0 based
+func retryBackoff(cfg setting.Tron, attempt int) time.Duration {
+	backoff := time.Duration(cfg.RetryBackoff) * time.Millisecond
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+	backoff <<= attempt
+	if backoff <= 0 || backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	// half fixed, half random, so callers throttled together do not come back together
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
@@ -0,0 +1,443 @@
+package biz
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeHeadNode serves its head block height over gRPC and counts the calls it gets
+type fakeHeadNode struct {
+	api.UnimplementedWalletServer
+	addr string
+
+	mu     sync.Mutex
+	height int64
+	calls  int
+	errs   []error       // answered to the next calls
+	delay  time.Duration // before each answer
+}
+
+func (n *fakeHeadNode) GetNowBlock2(ctx context.Context, in *api.EmptyMessage) (*api.BlockExtention, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.calls++
+	if n.delay > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		case <-time.After(n.delay):
+		}
+	}
+	if len(n.errs) > 0 {
+		err := n.errs[0]
+		n.errs = n.errs[1:]
+		return nil, err
+	}
+	return &api.BlockExtention{BlockHeader: &core.BlockHeader{RawData: &core.BlockHeaderRaw{Number: n.height}}}, nil
+}
+
+func (n *fakeHeadNode) set(height int64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.height, n.calls = height, 0
+}
+
+func (n *fakeHeadNode) fail(errs ...error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.errs, n.calls = errs, 0
+}
+
+func (n *fakeHeadNode) slow(delay time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.delay, n.calls = delay, 0
+}
+
+func (n *fakeHeadNode) count() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.calls
+}
+
+func startHeadNode(t *testing.T, height int64) *fakeHeadNode {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := &fakeHeadNode{addr: lis.Addr().String(), height: height}
+	s := grpc.NewServer()
+	api.RegisterWalletServer(s, n)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	return n
+}
+
+// unreachableAddr return an address nothing listens on
+func unreachableAddr(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+	return addr
+}
+
+func healthyAddrs(p *NodePool) map[string]bool {
+	healthy := make(map[string]bool)
+	for _, n := range p.Nodes() {
+		if n.Healthy {
+			healthy[n.Addr] = true
+		}
+	}
+	return healthy
+}
+
+func TestNodePool(t *testing.T) {
+	a, b, c := startHeadNode(t, 100), startHeadNode(t, 95), startHeadNode(t, 80)
+	down := unreachableAddr(t)
+	cfg := &setting.Config{App: setting.App{Node_Addr: []string{a.addr, b.addr, c.addr, down}}, Tron: setting.Tron{ProbeTimeout: 1}}
+	p := NewNodePool(cfg, zap.NewNop())
+	defer p.Close()
+	ctx := context.Background()
+	wallet := api.NewWalletClient(p)
+
+	if p.Height() != 0 {
+		t.Fatalf("height %d before a probe", p.Height())
+	}
+	p.probe(ctx)
+	if healthy := healthyAddrs(p); len(healthy) != 2 || !healthy[a.addr] || !healthy[b.addr] || p.Height() != 100 {
+		t.Fatalf("healthy %v at %d", healthy, p.Height())
+	}
+	a.set(100)
+	b.set(95)
+	c.set(80)
+	for i := 0; i < 10; i++ {
+		if _, err := wallet.GetNowBlock2(ctx, new(api.EmptyMessage)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if a.count() != 5 || b.count() != 5 || c.count() != 0 {
+		t.Fatalf("calls %d %d %d", a.count(), b.count(), c.count())
+	}
+
+	// a lagging node is taken back once it catches up
+	c.set(99)
+	p.probe(ctx)
+	if healthy := healthyAddrs(p); len(healthy) != 3 || !healthy[c.addr] {
+		t.Fatalf("healthy %v", healthy)
+	}
+
+	// reloaded: a is dropped and closed, d waits for its probe
+	aConn := p.nodes[0].conn.(*grpc.ClientConn)
+	d := startHeadNode(t, 101)
+	cfg.App.Node_Addr = []string{b.addr, c.addr, down, d.addr}
+	p.reload(cfg)
+	if aConn.GetState() != connectivity.Shutdown {
+		t.Fatalf("removed node is %s", aConn.GetState())
+	}
+	if healthy := healthyAddrs(p); len(healthy) != 2 || healthy[d.addr] {
+		t.Fatalf("healthy %v", healthy)
+	}
+	p.probe(ctx)
+	if healthy := healthyAddrs(p); len(healthy) != 3 || !healthy[d.addr] {
+		t.Fatalf("healthy %v", healthy)
+	}
+
+	// calls go to every node when none is healthy
+	cfg.App.Node_Addr = []string{down}
+	p.reload(cfg)
+	p.probe(ctx)
+	if _, err := wallet.GetNowBlock2(ctx, new(api.EmptyMessage)); status.Code(err) != codes.Unavailable {
+		t.Fatalf("called an unreachable node: %v", err)
+	}
+	cfg.App.Node_Addr = nil
+	p.reload(cfg)
+	if _, err := wallet.GetNowBlock2(ctx, new(api.EmptyMessage)); status.Code(err) != codes.Unavailable {
+		t.Fatalf("called no node: %v", err)
+	}
+}
+
+// fakeKeyNode serves over TLS the calls carrying an API key it accepts, refusing revoked
+// keys and keys out of quota as TronGrid does
+type fakeKeyNode struct {
+	api.UnimplementedWalletServer
+	refused map[string]codes.Code
+
+	mu   sync.Mutex
+	keys []string // of each call
+}
+
+func (n *fakeKeyNode) GetNowBlock2(ctx context.Context, in *api.EmptyMessage) (*api.BlockExtention, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	key := md.Get(apiKeyHeader)
+	n.mu.Lock()
+	n.keys = append(n.keys, key...)
+	n.mu.Unlock()
+	if len(key) != 1 {
+		return nil, status.Errorf(codes.Unauthenticated, "%d api keys", len(key))
+	}
+	if code, ok := n.refused[key[0]]; ok {
+		return nil, status.Error(code, "api key refused")
+	}
+	return &api.BlockExtention{BlockHeader: &core.BlockHeader{RawData: &core.BlockHeaderRaw{Number: 1}}}, nil
+}
+
+func (n *fakeKeyNode) calls() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	keys := n.keys
+	n.keys = nil
+	return keys
+}
+
+// newTestCert return a self signed certificate for host and the file of its PEM
+func newTestCert(t *testing.T, host string) (tls.Certificate, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: host},
+		DNSNames:              []string{host},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, file
+}
+
+func TestNodePoolTLSAndAPIKeys(t *testing.T) {
+	cert, caFile := newTestCert(t, "tron.test")
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := &fakeKeyNode{refused: map[string]codes.Code{"revoked": codes.Unauthenticated, "spent": codes.ResourceExhausted}}
+	s := grpc.NewServer(grpc.Creds(credentials.NewServerTLSFromCert(&cert)))
+	api.RegisterWalletServer(s, n)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	addr := lis.Addr().String()
+	node := setting.TronNode{Addr: addr, TLS: true, CAFile: caFile, ServerName: "tron.test", APIKeys: []string{"revoked", "good"}}
+	cfg := &setting.Config{App: setting.App{Node_Addr: []string{addr}}, Tron: setting.Tron{Nodes: []setting.TronNode{node}}}
+	p := NewNodePool(cfg, zap.NewNop())
+	defer p.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	wallet := api.NewWalletClient(p)
+
+	// the revoked key is dropped for good after its first refusal
+	for i := 0; i < 2; i++ {
+		if _, err := wallet.GetNowBlock2(ctx, new(api.EmptyMessage)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if keys := n.calls(); len(keys) != 3 || keys[0] != "revoked" || keys[1] != "good" || keys[2] != "good" {
+		t.Fatalf("keys %v", keys)
+	}
+
+	// new keys are picked up on reload, the call fails once every key is refused
+	node.APIKeys = []string{"spent", "revoked"}
+	cfg.Tron.Nodes = []setting.TronNode{node}
+	p.reload(cfg)
+	if _, err := wallet.GetNowBlock2(ctx, new(api.EmptyMessage)); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("all keys refused: %v", err)
+	}
+	if keys := n.calls(); len(keys) != 2 || keys[0] != "spent" || keys[1] != "revoked" {
+		t.Fatalf("keys %v", keys)
+	}
+
+	// a certificate not issued for the server name is not trusted
+	node.ServerName = "other.test"
+	cfg.Tron.Nodes = []setting.TronNode{node}
+	p.reload(cfg)
+	if _, err := wallet.GetNowBlock2(ctx, new(api.EmptyMessage)); status.Code(err) != codes.Unavailable {
+		t.Fatalf("wrong server name: %v", err)
+	}
+	if keys := n.calls(); len(keys) != 0 {
+		t.Fatalf("keys %v sent to an untrusted server", keys)
+	}
+}
+
+func TestNodePoolBreakerCallerDeadline(t *testing.T) {
+	a := startHeadNode(t, 100)
+	cfg := &setting.Config{App: setting.App{Node_Addr: []string{a.addr}},
+		Tron: setting.Tron{Retries: -1, BreakerFailures: 2, BreakerCooldown: 60}}
+	p := NewNodePool(cfg, zap.NewNop())
+	defer p.Close()
+	wallet := api.NewWalletClient(p)
+	n := p.nodes[0]
+	a.slow(200 * time.Millisecond)
+
+	// the deadline or cancellation of the caller does not count against the node
+	for i := 0; i < 3; i++ {
+		c, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		_, err := wallet.GetNowBlock2(c, new(api.EmptyMessage))
+		cancel()
+		if status.Code(err) != codes.DeadlineExceeded {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+	c, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	if _, err := wallet.GetNowBlock2(c, new(api.EmptyMessage)); status.Code(err) != codes.Canceled {
+		t.Fatalf("cancelled call: %v", err)
+	}
+	if !n.breaker.allow(time.Now()) || n.breaker.failures != 0 {
+		t.Fatalf("breaker counted %d failures of the caller", n.breaker.failures)
+	}
+
+	// nor does it decide a trial call after the cool-down
+	n.breaker.failures, n.breaker.openUntil = 2, time.Now()
+	c, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := wallet.GetNowBlock2(c, new(api.EmptyMessage)); status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("trial call: %v", err)
+	}
+	if n.breaker.openUntil.IsZero() || n.breaker.trial {
+		t.Fatal("trial call cut short by the caller decided the breaker")
+	}
+	n.breaker.failures, n.breaker.openUntil = 0, time.Time{}
+
+	// the call timeout running out does
+	cfg.Tron.CallTimeout = 20
+	p.reload(cfg)
+	for i := 0; i < 2; i++ {
+		if _, err := wallet.GetNowBlock2(context.Background(), new(api.EmptyMessage)); status.Code(err) != codes.DeadlineExceeded {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+	if n.breaker.allow(time.Now()) {
+		t.Fatal("breaker not open after the node ran out of the call timeout")
+	}
+}
+
+func TestNodePoolResilience(t *testing.T) {
+	a := startHeadNode(t, 100)
+	cfg := &setting.Config{App: setting.App{Node_Addr: []string{a.addr}},
+		Tron: setting.Tron{RetryBackoff: 1, BreakerFailures: 3, BreakerCooldown: 60}}
+	p := NewNodePool(cfg, zap.NewNop())
+	defer p.Close()
+	ctx := context.Background()
+	wallet := api.NewWalletClient(p)
+	unavailable, throttled := status.Error(codes.Unavailable, "down"), status.Error(codes.ResourceExhausted, "quota")
+
+	// retried on unavailable and throttled calls, not on other errors
+	a.fail(unavailable, throttled)
+	if _, err := wallet.GetNowBlock2(ctx, new(api.EmptyMessage)); err != nil || a.count() != 3 {
+		t.Fatalf("%d calls: %v", a.count(), err)
+	}
+	a.fail(status.Error(codes.InvalidArgument, "bad"))
+	if _, err := wallet.GetNowBlock2(ctx, new(api.EmptyMessage)); status.Code(err) != codes.InvalidArgument || a.count() != 1 {
+		t.Fatalf("%d calls: %v", a.count(), err)
+	}
+	// no retry the deadline has no room for
+	cfg.Tron.RetryBackoff = 1000
+	p.reload(cfg)
+	a.fail(unavailable, unavailable)
+	c, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if _, err := wallet.GetNowBlock2(c, new(api.EmptyMessage)); status.Code(err) != codes.Unavailable || a.count() != 1 {
+		t.Fatalf("%d calls: %v", a.count(), err)
+	}
+
+	// three failures in a row open the breaker, calls fail fast until the cool-down ends
+	cfg.Tron.Retries, cfg.Tron.RetryBackoff = -1, 1
+	p.reload(cfg)
+	trips := testutil.ToFloat64(nodeBreakerTrips.WithLabelValues("full", a.addr))
+	a.fail()
+	if _, err := wallet.GetNowBlock2(ctx, new(api.EmptyMessage)); err != nil {
+		t.Fatal(err)
+	}
+	a.fail(unavailable, unavailable, unavailable)
+	for i := 0; i < 3; i++ {
+		wallet.GetNowBlock2(ctx, new(api.EmptyMessage))
+	}
+	if _, err := wallet.GetNowBlock2(ctx, new(api.EmptyMessage)); status.Code(err) != codes.Unavailable || a.count() != 3 {
+		t.Fatalf("%d calls with the breaker open: %v", a.count(), err)
+	}
+	if testutil.ToFloat64(nodeBreakerOpen.WithLabelValues("full", a.addr)) != 1 ||
+		testutil.ToFloat64(nodeBreakerTrips.WithLabelValues("full", a.addr)) != trips+1 {
+		t.Fatal("breaker not reported open")
+	}
+	// a failed trial call after the cool-down opens it again, a good one closes it
+	n := p.nodes[0]
+	n.breaker.openUntil = time.Now()
+	a.fail(unavailable)
+	if _, err := wallet.GetNowBlock2(ctx, new(api.EmptyMessage)); status.Code(err) != codes.Unavailable || a.count() != 1 {
+		t.Fatalf("trial call: %v", err)
+	}
+	if n.breaker.allow(time.Now()) {
+		t.Fatal("breaker closed by a failed trial")
+	}
+	n.breaker.openUntil = time.Now()
+	if _, err := wallet.GetNowBlock2(ctx, new(api.EmptyMessage)); err != nil {
+		t.Fatalf("trial call: %v", err)
+	}
+	if testutil.ToFloat64(nodeBreakerOpen.WithLabelValues("full", a.addr)) != 0 {
+		t.Fatal("breaker not reported closed")
+	}
+
+	// rate limited to 20 calls a second: the burst goes at once, the next call waits its
+	// turn unless the deadline comes first
+	cfg.Tron.RateLimit, cfg.Tron.RateBurst = 20, 2
+	p.reload(cfg)
+	a.set(100)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := wallet.GetNowBlock2(ctx, new(api.EmptyMessage)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Fatalf("3 calls in %s", elapsed)
+	}
+	c, cancel = context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := wallet.GetNowBlock2(c, new(api.EmptyMessage)); status.Code(err) != codes.DeadlineExceeded || a.count() != 3 {
+		t.Fatalf("%d calls: %v", a.count(), err)
+	}
+}
@@ -0,0 +1,143 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrOfflineMismatch is returned when an imported transaction is not one we exported
+	// or is not signed by its owner
+	ErrOfflineMismatch = errors.New("transaction does not match the exported one")
+	// ErrOfflineExpired is returned when an imported transaction expired
+	ErrOfflineExpired = errors.New("transaction expired")
+)
+
+type OfflineStatus string
+
+const (
+	OfflineStatusExported OfflineStatus = "exported" // waiting for the offline signature
+	OfflineStatusSent     OfflineStatus = "sent"
+)
+
+// OfflineTx is a transfer exported unsigned for an offline signer. Only exported
+// transactions are accepted back.
+type OfflineTx struct {
+	ID        int64           `gorm:"primaryKey" json:"id"`
+	Txid      string          `gorm:"size:64;not null;uniqueIndex" json:"txid"`
+	From      string          `gorm:"column:from_address;size:34;not null" json:"from"`
+	To        string          `gorm:"column:to_address;size:34;not null" json:"to"`
+	Token     string          `gorm:"size:32;not null" json:"token"`
+	Contract  string          `gorm:"size:34" json:"contract"`                   // empty for TRX
+	Amount    decimal.Decimal `gorm:"type:decimal(65,0);not null" json:"amount"` // in the token's smallest unit
+	FeeLimit  int64           `gorm:"not null" json:"fee_limit"`                 // SUN, of the transaction once exported
+	Status    OfflineStatus   `gorm:"size:16;not null" json:"status"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// OfflineRepo persists exported transactions
+type OfflineRepo interface {
+	CreateOfflineTx(ctx context.Context, o *OfflineTx) error
+	// GetOfflineTx return the transaction exported with txid, ErrNotFound if there is none
+	GetOfflineTx(ctx context.Context, txid string) (*OfflineTx, error)
+	UpdateOfflineTx(ctx context.Context, o *OfflineTx) error
+}
+
+// OfflineUsecase builds transfers for air-gapped signers and broadcasts them once signed
+type OfflineUsecase struct {
+	uc   *TrxUsecase
+	repo OfflineRepo
+	log  *zap.Logger
+	now  func() time.Time
+}
+
+// NewOfflineUsecase new an offline signing usecase.
+func NewOfflineUsecase(uc *TrxUsecase, repo OfflineRepo, logger *zap.Logger) *OfflineUsecase {
+	return &OfflineUsecase{uc: uc, repo: repo, log: logger, now: time.Now}
+}
+
+// Export build the unsigned transfer of o, valid for expiration so the offline signer has
+// time to sign it, and store it to accept it back. A TRC20 fee limit of 0 is estimated and
+// capped by o.FeeLimit.
+func (u *OfflineUsecase) Export(ctx context.Context, o *OfflineTx, feeLimit int64, expiration time.Duration) (*OfflineTx, *api.TransactionExtention, error) {
+	tx, err := u.uc.buildTransfer(ctx, o.From, o.To, o.Contract, o.Amount.BigInt(), feeLimit, o.FeeLimit)
+	if err != nil {
+		return nil, nil, err
+	}
+	o.ExpiresAt = u.now().Add(expiration).Truncate(time.Millisecond)
+	if err := extendExpiration(tx, o.ExpiresAt); err != nil {
+		return nil, nil, err
+	}
+	o.Txid = hex.EncodeToString(tx.Txid)
+	o.FeeLimit = tx.Transaction.GetRawData().GetFeeLimit()
+	o.Status = OfflineStatusExported
+	if err := u.repo.CreateOfflineTx(ctx, o); err != nil {
+		return nil, nil, err
+	}
+	u.log.Sugar().Infow("offline tx exported", "txid", o.Txid, "from", o.From, "to", o.To, "token", o.Token, "amount", o.Amount,
+		"expires_at", o.ExpiresAt)
+	return o, tx, nil
+}
+
+// Import check that signed is an exported transaction signed by its owner and broadcast it.
+// A transaction imported again is broadcast again, the node drops duplicates.
+func (u *OfflineUsecase) Import(ctx context.Context, signed *core.Transaction) (*OfflineTx, error) {
+	txid, err := txHash(signed)
+	if err != nil {
+		return nil, err
+	}
+	o, err := u.repo.GetOfflineTx(ctx, hex.EncodeToString(txid))
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("%w: txid %x was not exported", ErrOfflineMismatch, txid)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !u.now().Before(o.ExpiresAt) {
+		return nil, ErrOfflineExpired
+	}
+	owner, err := ownerAddress(signed)
+	if err != nil {
+		return nil, err
+	}
+	if len(signed.Signature) != 1 {
+		return nil, fmt.Errorf("%w: %d signatures", ErrOfflineMismatch, len(signed.Signature))
+	}
+	signer, err := signerAddress(signed, signed.Signature[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOfflineMismatch, err)
+	}
+	if !bytes.Equal(signer.Bytes(), owner) {
+		return nil, fmt.Errorf("%w: signed by %s instead of %s", ErrOfflineMismatch, signer, address.Address(owner))
+	}
+
+	ret, err := u.uc.cli.Broadcast(ctx, signed)
+	if err != nil && ret.GetCode() != api.Return_DUP_TRANSACTION_ERROR {
+		return nil, err
+	}
+	if o.Status == OfflineStatusSent {
+		return o, nil
+	}
+	o.Status, o.UpdatedAt = OfflineStatusSent, u.now()
+	if err := u.repo.UpdateOfflineTx(ctx, o); err != nil {
+		return nil, err
+	}
+	u.log.Sugar().Infow("offline tx sent", "txid", o.Txid, "from", o.From, "to", o.To, "token", o.Token, "amount", o.Amount)
+	expiresAt := o.ExpiresAt
+	u.uc.recordWithdrawal(ctx, &api.TransactionExtention{Txid: txid}, &Tx{
+		Token: o.Token, Contract: o.Contract, From: o.From, To: o.To, Amount: o.Amount, ExpiresAt: &expiresAt,
+	})
+	return o, nil
+}
@@ -0,0 +1,132 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/leondevpt/wallet/trxservice/pkg/bundle"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+type memOfflineRepo struct {
+	mu  sync.Mutex
+	txs map[string]*OfflineTx
+}
+
+func (r *memOfflineRepo) CreateOfflineTx(ctx context.Context, o *OfflineTx) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	o.ID = int64(len(r.txs) + 1)
+	c := *o
+	r.txs[o.Txid] = &c
+	return nil
+}
+
+func (r *memOfflineRepo) GetOfflineTx(ctx context.Context, txid string) (*OfflineTx, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	o, ok := r.txs[txid]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	c := *o
+	return &c, nil
+}
+
+func (r *memOfflineRepo) UpdateOfflineTx(ctx context.Context, o *OfflineTx) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := *o
+	r.txs[o.Txid] = &c
+	return nil
+}
+
+func TestOfflineSigning(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cold, to := address.PubkeyToAddress(key.PublicKey), newTestAddress(t)
+	node := &fakeSweepNode{balances: map[string]int64{string(cold.Bytes()): 100 * sunPerTRX}}
+	cli := &TronCli{TronWalletCli: node, GrpcTimeout: time.Second}
+	trxRepo := newMemTrxRepo()
+	uc := NewTrxUsecase(trxRepo, nil, zap.NewNop(), cli, nopSigner{}, NewEventBus(), nil)
+	u := NewOfflineUsecase(uc, &memOfflineRepo{txs: make(map[string]*OfflineTx)}, zap.NewNop())
+	now := time.UnixMilli(1666000000000)
+	u.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	o, tx, err := u.Export(ctx, &OfflineTx{From: cold.String(), To: to.String(), Token: TokenTRX, Amount: decimal.NewFromInt(sunPerTRX)},
+		0, 2*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Transaction.RawData.Expiration != now.Add(2*time.Hour).UnixMilli() || o.Status != OfflineStatusExported {
+		t.Fatalf("exported %+v expiring at %d", o, tx.Transaction.RawData.Expiration)
+	}
+
+	// carried to the offline signer and back in either format
+	for _, format := range []string{bundle.FormatJSON, bundle.FormatHex} {
+		b, err := bundle.New(tx.Transaction, tx.Txid, o.From, "send 1 TRX")
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := bundle.Encode(b, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, gotFormat, err := bundle.Decode(s)
+		if err != nil || gotFormat != format || got.Txid != o.Txid || got.Summary != "send 1 TRX" {
+			t.Fatalf("%s bundle %v: %v", format, got, err)
+		}
+	}
+	if s, err := bundle.Describe(tx.Transaction); err != nil || s != "send 1000000 SUN from "+cold.String()+" to "+to.String()+
+		", expires 2022-10-17T11:46:40Z" {
+		t.Fatalf("described as %q: %v", s, err)
+	}
+
+	// signed by another key, or with the raw data changed after signing
+	other, _ := crypto.GenerateKey()
+	wrong := proto.Clone(tx.Transaction).(*core.Transaction)
+	sig, err := crypto.Sign(tx.Txid, other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrong.Signature = [][]byte{sig}
+	if _, err := u.Import(ctx, wrong); !errors.Is(err, ErrOfflineMismatch) {
+		t.Fatalf("imported a tx signed by another key: %v", err)
+	}
+	signed, err := newKeySigner(key).Sign(ctx, proto.Clone(tx.Transaction).(*core.Transaction))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := proto.Clone(signed).(*core.Transaction)
+	tampered.RawData.FeeLimit = 1
+	if _, err := u.Import(ctx, tampered); !errors.Is(err, ErrOfflineMismatch) {
+		t.Fatalf("imported a tampered tx: %v", err)
+	}
+
+	if o, err = u.Import(ctx, signed); err != nil {
+		t.Fatal(err)
+	}
+	if o.Status != OfflineStatusSent || len(node.broadcast) != 1 || len(trxRepo.txs) != 1 {
+		t.Fatalf("imported %+v, broadcast %d, withdrawals %d", o, len(node.broadcast), len(trxRepo.txs))
+	}
+	// importing again broadcasts again and records nothing new
+	if _, err = u.Import(ctx, signed); err != nil || len(trxRepo.txs) != 1 {
+		t.Fatalf("imported twice: %v", err)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, err := u.Import(ctx, signed); !errors.Is(err, ErrOfflineExpired) {
+		t.Fatalf("imported an expired tx: %v", err)
+	}
+}
@@ -0,0 +1,383 @@
+package biz
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/leondevpt/wallet/trxservice/pkg/errcode"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	maxPayoutAttempts = 5
+	// payoutClaimTimeout is how long a claimed item may stay unsigned before it is
+	// handed out again, far longer than building and signing take
+	payoutClaimTimeout = 10 * time.Minute
+	// rebroadcastAfter is how long a stored signed tx waits before it is broadcast again
+	rebroadcastAfter = time.Minute
+	// txExpiryMargin covers the head block lagging behind the clock when telling whether
+	// a tx expired
+	txExpiryMargin = time.Minute
+)
+
+// ErrPayoutConflict is returned when a client reference is already used by a different payout
+var ErrPayoutConflict = errors.New("client reference used by another payout")
+
+type PayoutStatus string
+
+const (
+	PayoutStatusPending PayoutStatus = "pending" // waits for a worker
+	PayoutStatusSending PayoutStatus = "sending" // claimed by a worker, nothing signed yet
+	PayoutStatusSigned  PayoutStatus = "signed"  // signed tx stored, it may have been broadcast
+	PayoutStatusSent    PayoutStatus = "sent"    // accepted by the node, tracked as a withdrawal
+	PayoutStatusFailed  PayoutStatus = "failed"  // given up, nothing was paid
+)
+
+// PayoutBatch groups the items submitted together
+type PayoutBatch struct {
+	ID        int64     `gorm:"primaryKey" json:"id"`
+	From      string    `gorm:"column:from_address;size:34;not null" json:"from"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PayoutItem is a single transfer of a batch. Its ClientReference is the idempotency key,
+// an item is paid at most once however often it is submitted.
+type PayoutItem struct {
+	ID              int64           `gorm:"primaryKey" json:"id"`
+	BatchID         int64           `gorm:"not null;index" json:"batch_id"`
+	ClientReference string          `gorm:"size:64;not null;uniqueIndex" json:"client_reference"`
+	From            string          `gorm:"column:from_address;size:34;not null" json:"from"`
+	To              string          `gorm:"column:to_address;size:34;not null" json:"to"`
+	Token           string          `gorm:"size:32;not null" json:"token"`
+	Contract        string          `gorm:"size:34" json:"contract"`                   // empty for TRX
+	Amount          decimal.Decimal `gorm:"type:decimal(65,0);not null" json:"amount"` // in the token's smallest unit
+	FeeLimit        int64           `gorm:"not null;default:0" json:"fee_limit"`       // SUN, ceiling of the estimated fee limit
+	Status          PayoutStatus    `gorm:"size:16;not null;index:idx_status_updated" json:"status"`
+	Txid            string          `gorm:"size:64" json:"txid"`
+	RawTx           string          `gorm:"type:text" json:"-"` // hex of the signed tx
+	ExpiresAt       *time.Time      `json:"expires_at"`         // of the signed tx
+	Attempts        int             `gorm:"not null" json:"attempts"`
+	LastError       string          `gorm:"size:255" json:"last_error"`
+	Version         int64           `gorm:"not null" json:"-"` // bumped by every update
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `gorm:"index:idx_status_updated" json:"updated_at"`
+}
+
+// Matches report whether o pays the same as p, a resubmission must
+func (p *PayoutItem) Matches(o *PayoutItem) bool {
+	return p.From == o.From && p.To == o.To && p.Token == o.Token && p.Contract == o.Contract && p.Amount.Equal(o.Amount)
+}
+
+// PayoutRepo persists payout batches
+type PayoutRepo interface {
+	// CreatePayoutBatch store items in a new batch of from. Items whose client reference is
+	// stored already are kept, ErrPayoutConflict is returned if one of them does not match.
+	// It returns the batch and the stored items in the order given. When every item was
+	// stored already no batch is created and the batch of the first one is returned.
+	CreatePayoutBatch(ctx context.Context, from string, items []*PayoutItem) (*PayoutBatch, []*PayoutItem, error)
+	// GetPayoutBatch return a batch and its items in ID order, ErrNotFound if there is none
+	GetPayoutBatch(ctx context.Context, id int64) (*PayoutBatch, []*PayoutItem, error)
+	// ListPayouts return items in status last updated before before, in ID order
+	ListPayouts(ctx context.Context, status PayoutStatus, before time.Time, limit int) ([]*PayoutItem, error)
+	// UpdatePayout persist p if nobody updated it since it was read and bump its Version.
+	// It returns ErrNotFound otherwise.
+	UpdatePayout(ctx context.Context, p *PayoutItem) error
+}
+
+// PayoutUsecase pays out batches, one item after the other per hot wallet
+type PayoutUsecase struct {
+	uc   *TrxUsecase
+	repo PayoutRepo
+	cfg  *setting.Config
+	log  *zap.Logger
+	now  func() time.Time
+	wake chan struct{}
+}
+
+// NewPayoutUsecase new a payout usecase.
+func NewPayoutUsecase(uc *TrxUsecase, repo PayoutRepo, cfg *setting.Config, logger *zap.Logger) *PayoutUsecase {
+	return &PayoutUsecase{uc: uc, repo: repo, cfg: cfg, log: logger, now: time.Now, wake: make(chan struct{}, 1)}
+}
+
+// Enabled report whether the payout worker is switched on in config
+func (p *PayoutUsecase) Enabled() bool {
+	return p.cfg.Payout.Enable
+}
+
+// Submit store a batch of payouts from a hot wallet, they are paid in the background.
+// Resubmitted items are returned as they are.
+func (p *PayoutUsecase) Submit(ctx context.Context, from string, items []*PayoutItem) (*PayoutBatch, []*PayoutItem, error) {
+	for _, item := range items {
+		item.From = from
+		item.Status = PayoutStatusPending
+	}
+	batch, stored, err := p.repo.CreatePayoutBatch(ctx, from, items)
+	if err != nil {
+		return nil, nil, err
+	}
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+	return batch, stored, nil
+}
+
+// GetBatch return a batch and the status of its items
+func (p *PayoutUsecase) GetBatch(ctx context.Context, id int64) (*PayoutBatch, []*PayoutItem, error) {
+	return p.repo.GetPayoutBatch(ctx, id)
+}
+
+// Run pay pending items until ctx is done, submissions wake it up early
+func (p *PayoutUsecase) Run(ctx context.Context) error {
+	interval := time.Duration(p.cfg.Payout.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	for {
+		if err := p.recover(ctx); err != nil {
+			p.log.Sugar().Errorw("PayoutUsecase recover", "err", err)
+		}
+		if err := p.dispatch(ctx); err != nil {
+			p.log.Sugar().Errorw("PayoutUsecase dispatch", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-p.wake:
+		case <-time.After(interval):
+		}
+	}
+}
+
+// recover pick up items a worker left behind: claims that were never signed go back to
+// pending, signed txs are broadcast again until the node accepts them or they expire
+func (p *PayoutUsecase) recover(ctx context.Context) error {
+	now := p.now()
+	claimed, err := p.repo.ListPayouts(ctx, PayoutStatusSending, now.Add(-payoutClaimTimeout), p.batchSize())
+	if err != nil {
+		return err
+	}
+	for _, item := range claimed {
+		p.log.Sugar().Warnw("payout claim timed out", "id", item.ID, "reference", item.ClientReference)
+		item.Status = PayoutStatusPending
+		if err := p.update(ctx, item); err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+	}
+
+	signed, err := p.repo.ListPayouts(ctx, PayoutStatusSigned, now.Add(-rebroadcastAfter), p.batchSize())
+	if err != nil {
+		return err
+	}
+	for _, item := range signed {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if item.ExpiresAt != nil && now.After(item.ExpiresAt.Add(txExpiryMargin)) {
+			if err := p.settleExpired(ctx, item); err != nil {
+				return err
+			}
+			continue
+		}
+		raw, err := hex.DecodeString(item.RawTx)
+		if err != nil {
+			return err
+		}
+		tx := new(core.Transaction)
+		if err := proto.Unmarshal(raw, tx); err != nil {
+			return fmt.Errorf("payout %d: %w", item.ID, err)
+		}
+		if err := p.broadcast(ctx, item, tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dispatch pay the pending items, the wallets in parallel up to the configured concurrency
+// and the items of a wallet one after the other in ID order
+func (p *PayoutUsecase) dispatch(ctx context.Context) error {
+	items, err := p.repo.ListPayouts(ctx, PayoutStatusPending, p.now(), p.batchSize())
+	if err != nil {
+		return err
+	}
+	var wallets []string
+	byWallet := make(map[string][]*PayoutItem)
+	for _, item := range items {
+		if _, ok := byWallet[item.From]; !ok {
+			wallets = append(wallets, item.From)
+		}
+		byWallet[item.From] = append(byWallet[item.From], item)
+	}
+
+	concurrency := p.cfg.Payout.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, from := range wallets {
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+			wg.Add(1)
+			go func(from string, items []*PayoutItem) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				for _, item := range items {
+					if ctx.Err() != nil {
+						return
+					}
+					// later items of the wallet wait for the next round
+					if err := p.pay(ctx, item); err != nil {
+						p.log.Sugar().Errorw("PayoutUsecase pay", "from", from, "id", item.ID, "err", err)
+						return
+					}
+				}
+			}(from, byWallet[from])
+		}
+	}
+	wg.Wait()
+	return nil
+}
+
+// pay claim, build, sign and broadcast a pending item. The signed tx is stored before it
+// is broadcast, so a crash can not lead to paying the item twice.
+func (p *PayoutUsecase) pay(ctx context.Context, item *PayoutItem) error {
+	item.Status = PayoutStatusSending
+	if err := p.update(ctx, item); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil // claimed by another worker
+		}
+		return err
+	}
+
+	tx, err := p.uc.buildTransfer(ctx, item.From, item.To, item.Contract, item.Amount.BigInt(), 0, item.FeeLimit)
+	if err == nil {
+		tx.Transaction, err = p.uc.signer.Sign(ctx, tx.Transaction)
+	}
+	if err != nil {
+		return p.retry(ctx, item, err)
+	}
+	raw, err := proto.Marshal(tx.Transaction)
+	if err != nil {
+		return p.retry(ctx, item, err)
+	}
+	expiresAt := time.UnixMilli(tx.Transaction.GetRawData().GetExpiration())
+	item.Status = PayoutStatusSigned
+	item.Txid = hex.EncodeToString(tx.Txid)
+	item.RawTx = hex.EncodeToString(raw)
+	item.ExpiresAt = &expiresAt
+	if err := p.update(ctx, item); err != nil {
+		return err
+	}
+	return p.broadcast(ctx, item, tx.Transaction)
+}
+
+// retry put an item whose tx could not be built back to pending, or fail it when retrying
+// can not help
+func (p *PayoutUsecase) retry(ctx context.Context, item *PayoutItem, cause error) error {
+	item.Attempts++
+	item.LastError = truncate(cause.Error(), 255)
+	terminal := errors.Is(cause, ErrInsufficientBalance) || errors.Is(cause, ErrFeeLimitExceeded)
+	if terminal || item.Attempts >= maxPayoutAttempts {
+		item.Status = PayoutStatusFailed
+		p.log.Sugar().Errorw("payout failed", "id", item.ID, "reference", item.ClientReference, "attempts", item.Attempts, "err", cause)
+	} else {
+		item.Status = PayoutStatusPending
+	}
+	if err := p.update(ctx, item); err != nil {
+		return err
+	}
+	if terminal {
+		return nil
+	}
+	return cause
+}
+
+// broadcast send the signed tx of item and record the outcome. When the node can not be
+// reached the item stays signed and is broadcast again later.
+func (p *PayoutUsecase) broadcast(ctx context.Context, item *PayoutItem, tx *core.Transaction) error {
+	ret, err := p.uc.cli.Broadcast(ctx, tx)
+	switch {
+	case err == nil || ret.GetCode() == api.Return_DUP_TRANSACTION_ERROR:
+		return p.sent(ctx, item)
+	case ret == nil:
+		return err
+	}
+	switch errcode.FromBroadcastCode(ret.GetCode()) {
+	case errcode.BroadcastUnavailable:
+		return err
+	case errcode.BroadcastExpired:
+		// an earlier broadcast of the tx may have been included before it expired
+		return p.settleExpired(ctx, item)
+	}
+	item.Status = PayoutStatusFailed
+	item.LastError = truncate(err.Error(), 255)
+	p.log.Sugar().Errorw("payout rejected", "id", item.ID, "reference", item.ClientReference, "txid", item.Txid, "err", err)
+	return p.update(ctx, item)
+}
+
+// settleExpired mark an item whose tx expired as sent when it is on chain. It goes back to
+// pending to be paid with a new tx only once a solidified block is past the expiration,
+// until then the tx may still be included and the item stays signed.
+func (p *PayoutUsecase) settleExpired(ctx context.Context, item *PayoutItem) error {
+	solid, err := p.uc.cli.SolidBlockTime(ctx)
+	if err != nil {
+		return err
+	}
+	info, err := p.uc.cli.GetTransactionInfoById(ctx, item.Txid)
+	if err != nil {
+		return err
+	}
+	if info.GetBlockNumber() > 0 {
+		return p.sent(ctx, item)
+	}
+	if item.ExpiresAt == nil || !solid.After(*item.ExpiresAt) {
+		return nil
+	}
+	p.log.Sugar().Warnw("payout tx expired, paying again", "id", item.ID, "reference", item.ClientReference, "txid", item.Txid)
+	item.Status = PayoutStatusPending
+	item.Txid, item.RawTx, item.ExpiresAt = "", "", nil
+	return p.update(ctx, item)
+}
+
+// sent mark item as sent and track its tx like any other withdrawal
+func (p *PayoutUsecase) sent(ctx context.Context, item *PayoutItem) error {
+	item.Status = PayoutStatusSent
+	item.LastError = ""
+	if err := p.update(ctx, item); err != nil {
+		return err
+	}
+	txid, err := hex.DecodeString(item.Txid)
+	if err != nil {
+		return err
+	}
+	p.log.Sugar().Infow("payout sent", "id", item.ID, "reference", item.ClientReference, "to", item.To, "token", item.Token,
+		"amount", item.Amount, "txid", item.Txid)
+	p.uc.recordWithdrawal(ctx, &api.TransactionExtention{Txid: txid}, &Tx{
+		Token: item.Token, Contract: item.Contract, From: item.From, To: item.To, Amount: item.Amount, ExpiresAt: item.ExpiresAt,
+	})
+	return nil
+}
+
+func (p *PayoutUsecase) update(ctx context.Context, item *PayoutItem) error {
+	item.UpdatedAt = p.now()
+	return p.repo.UpdatePayout(ctx, item)
+}
+
+func (p *PayoutUsecase) batchSize() int {
+	if p.cfg.Payout.Batch <= 0 {
+		return 100
+	}
+	return p.cfg.Payout.Batch
+}
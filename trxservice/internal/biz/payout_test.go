@@ -0,0 +1,384 @@
+package biz
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	protov1 "github.com/golang/protobuf/proto"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+type memPayoutRepo struct {
+	mu      sync.Mutex
+	batches []*PayoutBatch
+	items   []*PayoutItem
+}
+
+func (r *memPayoutRepo) CreatePayoutBatch(ctx context.Context, from string, items []*PayoutItem) (*PayoutBatch, []*PayoutItem, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var fresh, stored []*PayoutItem
+	for _, item := range items {
+		s := r.byReference(item.ClientReference)
+		if s == nil {
+			fresh = append(fresh, item)
+		} else if !s.Matches(item) {
+			return nil, nil, ErrPayoutConflict
+		}
+	}
+	if len(fresh) == 0 {
+		s := r.byReference(items[0].ClientReference)
+		batch := *r.batches[s.BatchID-1]
+		for _, item := range items {
+			c := *r.byReference(item.ClientReference)
+			stored = append(stored, &c)
+		}
+		return &batch, stored, nil
+	}
+	batch := &PayoutBatch{ID: int64(len(r.batches) + 1), From: from}
+	r.batches = append(r.batches, batch)
+	for _, item := range fresh {
+		item.ID, item.BatchID = int64(len(r.items)+1), batch.ID
+		c := *item
+		r.items = append(r.items, &c)
+	}
+	for _, item := range items {
+		c := *r.byReference(item.ClientReference)
+		stored = append(stored, &c)
+	}
+	b := *batch
+	return &b, stored, nil
+}
+
+func (r *memPayoutRepo) byReference(ref string) *PayoutItem {
+	for _, item := range r.items {
+		if item.ClientReference == ref {
+			return item
+		}
+	}
+	return nil
+}
+
+func (r *memPayoutRepo) GetPayoutBatch(ctx context.Context, id int64) (*PayoutBatch, []*PayoutItem, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id <= 0 || id > int64(len(r.batches)) {
+		return nil, nil, ErrNotFound
+	}
+	var list []*PayoutItem
+	for _, item := range r.items {
+		if item.BatchID == id {
+			c := *item
+			list = append(list, &c)
+		}
+	}
+	batch := *r.batches[id-1]
+	return &batch, list, nil
+}
+
+func (r *memPayoutRepo) ListPayouts(ctx context.Context, status PayoutStatus, before time.Time, limit int) ([]*PayoutItem, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var list []*PayoutItem
+	for _, item := range r.items {
+		if item.Status == status && !item.UpdatedAt.After(before) && len(list) < limit {
+			c := *item
+			list = append(list, &c)
+		}
+	}
+	return list, nil
+}
+
+func (r *memPayoutRepo) UpdatePayout(ctx context.Context, p *PayoutItem) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := r.items[p.ID-1]
+	if stored.Version != p.Version {
+		return ErrNotFound
+	}
+	p.Version++
+	*stored = *p
+	return nil
+}
+
+// fakePayoutNode is a WalletClient building and accepting TRX transfers
+type fakePayoutNode struct {
+	api.WalletClient
+	mu        sync.Mutex
+	balance   int64
+	reject    api.ReturnResponseCode // answer broadcasts with it once when set
+	down      bool                   // fail broadcasts as if the node could not be reached
+	broadcast []*core.Transaction
+	onChain   bool  // every tx asked for is included
+	solidTime int64 // timestamp in ms of the latest solidified block
+}
+
+func (n *fakePayoutNode) GetNodeInfo(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*core.NodeInfo, error) {
+	return &core.NodeInfo{SolidityBlock: "Num:90,ID:00"}, nil
+}
+
+func (n *fakePayoutNode) GetBlockByNum2(ctx context.Context, in *api.NumberMessage, opts ...grpc.CallOption) (*api.BlockExtention, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return &api.BlockExtention{BlockHeader: &core.BlockHeader{RawData: &core.BlockHeaderRaw{Number: in.Num, Timestamp: n.solidTime}}}, nil
+}
+
+func (n *fakePayoutNode) GetAccount(ctx context.Context, in *core.Account, opts ...grpc.CallOption) (*core.Account, error) {
+	return &core.Account{Address: in.Address, Balance: n.balance}, nil
+}
+
+func (n *fakePayoutNode) CreateTransaction2(ctx context.Context, in *core.TransferContract, opts ...grpc.CallOption) (*api.TransactionExtention, error) {
+	tx := newUnsignedTx(core.Transaction_Contract_TransferContract, in)
+	raw, _ := proto.Marshal(tx.RawData)
+	id := sha256.Sum256(raw)
+	return &api.TransactionExtention{Transaction: tx, Txid: id[:], Result: &api.Return{Result: true}}, nil
+}
+
+func (n *fakePayoutNode) BroadcastTransaction(ctx context.Context, in *core.Transaction, opts ...grpc.CallOption) (*api.Return, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.down {
+		return nil, errors.New("connection refused")
+	}
+	if n.reject != api.Return_SUCCESS {
+		code := n.reject
+		n.reject = api.Return_SUCCESS
+		return &api.Return{Code: code, Message: []byte(code.String())}, nil
+	}
+	n.broadcast = append(n.broadcast, in)
+	return &api.Return{Result: true}, nil
+}
+
+func (n *fakePayoutNode) GetTransactionInfoById(ctx context.Context, in *api.BytesMessage, opts ...grpc.CallOption) (*core.TransactionInfo, error) {
+	if n.onChain {
+		return &core.TransactionInfo{Id: in.Value, BlockNumber: 100}, nil
+	}
+	return &core.TransactionInfo{}, nil
+}
+
+func (n *fakePayoutNode) paid(t *testing.T) map[string][]int64 {
+	t.Helper()
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	paid := make(map[string][]int64)
+	for _, tx := range n.broadcast {
+		c := new(core.TransferContract)
+		if err := tx.RawData.Contract[0].Parameter.UnmarshalTo(protov1.MessageV2(c)); err != nil {
+			t.Fatal(err)
+		}
+		paid[string(c.OwnerAddress)] = append(paid[string(c.OwnerAddress)], c.Amount)
+	}
+	return paid
+}
+
+func newTestPayoutUsecase(node *fakePayoutNode) (*PayoutUsecase, *memPayoutRepo) {
+	cli := &TronCli{TronWalletCli: node, GrpcTimeout: time.Second}
+	uc := NewTrxUsecase(newMemTrxRepo(), nil, zap.NewNop(), cli, nopSigner{}, NewEventBus(), nil)
+	repo := &memPayoutRepo{}
+	cfg := &setting.Config{}
+	cfg.Payout.Concurrency = 2
+	return NewPayoutUsecase(uc, repo, cfg, zap.NewNop()), repo
+}
+
+func newTestPayout(ref, to string, sun int64) *PayoutItem {
+	return &PayoutItem{ClientReference: ref, To: to, Token: TokenTRX, Amount: decimal.NewFromInt(sun)}
+}
+
+func TestPayoutIdempotent(t *testing.T) {
+	hotA, hotB, to := newTestAddress(t), newTestAddress(t), newTestAddress(t)
+	node := &fakePayoutNode{balance: 1000000}
+	p, _ := newTestPayoutUsecase(node)
+	ctx := context.Background()
+
+	batch, items, err := p.Submit(ctx, hotA.String(), []*PayoutItem{
+		newTestPayout("a1", to.String(), 1), newTestPayout("a2", to.String(), 2), newTestPayout("a3", to.String(), 3),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 3 || items[2].BatchID != batch.ID {
+		t.Fatalf("items %+v", items)
+	}
+	if _, _, err := p.Submit(ctx, hotB.String(), []*PayoutItem{newTestPayout("b1", to.String(), 4), newTestPayout("b2", to.String(), 5)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.dispatch(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// each wallet pays its items in the order submitted
+	paid := node.paid(t)
+	if got := paid[string(hotA.Bytes())]; len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Fatalf("paid from A %v", got)
+	}
+	if got := paid[string(hotB.Bytes())]; len(got) != 2 || got[0] != 4 || got[1] != 5 {
+		t.Fatalf("paid from B %v", got)
+	}
+	_, items, err = p.GetBatch(ctx, batch.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, item := range items {
+		if item.Status != PayoutStatusSent || item.Txid == "" {
+			t.Fatalf("item %+v", item)
+		}
+	}
+
+	// a resubmitted reference is returned as stored, nothing is paid again
+	again, items, err := p.Submit(ctx, hotA.String(), []*PayoutItem{newTestPayout("a2", to.String(), 2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.ID != batch.ID || items[0].Status != PayoutStatusSent {
+		t.Fatalf("resubmitted batch %d, item %+v", again.ID, items[0])
+	}
+	if err := p.dispatch(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(node.paid(t)[string(hotA.Bytes())]) != 3 {
+		t.Fatalf("paid twice")
+	}
+
+	_, _, err = p.Submit(ctx, hotA.String(), []*PayoutItem{newTestPayout("a2", to.String(), 20)})
+	if !errors.Is(err, ErrPayoutConflict) {
+		t.Fatalf("err %v, want ErrPayoutConflict", err)
+	}
+}
+
+func TestPayoutFailures(t *testing.T) {
+	hot, to := newTestAddress(t), newTestAddress(t)
+	node := &fakePayoutNode{balance: 100, reject: api.Return_CONTRACT_VALIDATE_ERROR}
+	p, repo := newTestPayoutUsecase(node)
+	ctx := context.Background()
+
+	batch, _, err := p.Submit(ctx, hot.String(), []*PayoutItem{
+		newTestPayout("rejected", to.String(), 10), newTestPayout("too-much", to.String(), 1000), newTestPayout("ok", to.String(), 20),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.dispatch(ctx); err != nil {
+		t.Fatal(err)
+	}
+	_, items, err := p.GetBatch(ctx, batch.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	if items[0].Status != PayoutStatusFailed || items[1].Status != PayoutStatusFailed || items[2].Status != PayoutStatusSent {
+		t.Fatalf("statuses %s %s %s", items[0].Status, items[1].Status, items[2].Status)
+	}
+	if items[1].LastError != ErrInsufficientBalance.Error() {
+		t.Fatalf("last error %q", items[1].LastError)
+	}
+	if repo.items[0].Txid == "" || repo.items[1].Txid != "" {
+		t.Fatalf("txids %q %q", repo.items[0].Txid, repo.items[1].Txid)
+	}
+}
+
+func TestPayoutRecover(t *testing.T) {
+	hot, to := newTestAddress(t), newTestAddress(t)
+	node := &fakePayoutNode{balance: 1000000, down: true}
+	p, repo := newTestPayoutUsecase(node)
+	now := time.UnixMilli(1666000000000)
+	p.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if _, _, err := p.Submit(ctx, hot.String(), []*PayoutItem{newTestPayout("p1", to.String(), 1), newTestPayout("p2", to.String(), 2)}); err != nil {
+		t.Fatal(err)
+	}
+	// the node can not be reached, the first tx stays signed and the wallet waits
+	if err := p.dispatch(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if repo.items[0].Status != PayoutStatusSigned || repo.items[1].Status != PayoutStatusPending {
+		t.Fatalf("statuses %s %s", repo.items[0].Status, repo.items[1].Status)
+	}
+	signed := *repo.items[0]
+
+	// the same signed tx is broadcast again, not a new one
+	node.down = false
+	now = now.Add(2 * rebroadcastAfter)
+	if err := p.recover(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if repo.items[0].Status != PayoutStatusSent || len(node.broadcast) != 1 {
+		t.Fatalf("status %s, broadcast %d", repo.items[0].Status, len(node.broadcast))
+	}
+	raw, _ := proto.Marshal(node.broadcast[0])
+	if signed.RawTx == "" || hex.EncodeToString(raw) != signed.RawTx {
+		t.Fatalf("broadcast a different tx")
+	}
+
+	// a worker died after claiming p2, it is paid once the claim timed out
+	repo.items[1].Status = PayoutStatusSending
+	repo.items[1].UpdatedAt = now
+	now = now.Add(payoutClaimTimeout + time.Second)
+	if err := p.recover(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.dispatch(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if repo.items[1].Status != PayoutStatusSent || len(node.broadcast) != 2 {
+		t.Fatalf("status %s, broadcast %d", repo.items[1].Status, len(node.broadcast))
+	}
+
+	// an expired tx that is not on chain is paid with a new one, once no block can include it
+	if _, _, err := p.Submit(ctx, hot.String(), []*PayoutItem{newTestPayout("p3", to.String(), 3)}); err != nil {
+		t.Fatal(err)
+	}
+	node.down = true
+	if err := p.dispatch(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !now.After(repo.items[2].ExpiresAt.Add(txExpiryMargin)) {
+		t.Fatalf("p3 not expired yet")
+	}
+	now = now.Add(2 * rebroadcastAfter)
+	node.solidTime = repo.items[2].ExpiresAt.UnixMilli()
+	if err := p.recover(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if repo.items[2].Status != PayoutStatusSigned {
+		t.Fatalf("p3 %s before a solidified block passed its expiration", repo.items[2].Status)
+	}
+	node.solidTime++
+	if err := p.recover(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if repo.items[2].Status != PayoutStatusPending || repo.items[2].Txid != "" {
+		t.Fatalf("expired p3 %+v", repo.items[2])
+	}
+	node.down = false
+	if err := p.dispatch(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if repo.items[2].Status != PayoutStatusSent || len(node.broadcast) != 3 {
+		t.Fatalf("status %s, broadcast %d", repo.items[2].Status, len(node.broadcast))
+	}
+
+	// the node calls the tx expired but an earlier broadcast was included
+	if _, _, err := p.Submit(ctx, hot.String(), []*PayoutItem{newTestPayout("p4", to.String(), 4)}); err != nil {
+		t.Fatal(err)
+	}
+	node.reject, node.onChain = api.Return_TRANSACTION_EXPIRATION_ERROR, true
+	if err := p.dispatch(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if repo.items[3].Status != PayoutStatusSent || len(node.broadcast) != 3 {
+		t.Fatalf("status %s, broadcast %d", repo.items[3].Status, len(node.broadcast))
+	}
+}
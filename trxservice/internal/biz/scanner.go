@@ -0,0 +1,362 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	protov1 "github.com/golang/protobuf/proto"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const depositScanner = "deposit"
+
+// BlockScanner follows the chain and records deposits to managed addresses
+type BlockScanner struct {
+	cli      *TronCli
+	repo     TrxRepo
+	addrRepo AddressRepo
+	cache    BalanceCache // nil without one
+	bus      *EventBus
+	cfg      *setting.Config
+	log      *zap.Logger
+}
+
+// NewBlockScanner new a deposit scanner.
+func NewBlockScanner(cli *TronCli, repo TrxRepo, addrRepo AddressRepo, cache BalanceCache, bus *EventBus, cfg *setting.Config,
+	logger *zap.Logger) *BlockScanner {
+	return &BlockScanner{cli: cli, repo: repo, addrRepo: addrRepo, cache: cache, bus: bus, cfg: cfg, log: logger}
+}
+
+// Enabled report whether the scanner is switched on in config
+func (s *BlockScanner) Enabled() bool {
+	return s.cfg.Scanner.Enable
+}
+
+// Run scan blocks until ctx is done
+func (s *BlockScanner) Run(ctx context.Context) error {
+	interval := time.Duration(s.cfg.Scanner.Interval) * time.Second
+	if interval <= 0 {
+		interval = 3 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.scan(ctx); err != nil {
+			s.log.Sugar().Errorw("BlockScanner", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// scan process blocks after the checkpoint, up to Batch blocks per round
+func (s *BlockScanner) scan(ctx context.Context) error {
+	head, err := s.cli.GetNowBlock(ctx)
+	if err != nil {
+		return err
+	}
+	headNum := head.GetBlockHeader().GetRawData().GetNumber()
+
+	next, prevHash := s.cfg.Scanner.StartBlock, ""
+	cp, err := s.repo.GetCheckpoint(ctx, depositScanner)
+	switch {
+	case err == nil:
+		next, prevHash = cp.BlockNum+1, cp.BlockHash
+	case !errors.Is(err, ErrNotFound):
+		return err
+	case next <= 0:
+		next = headNum
+	}
+
+	batch := int64(s.cfg.Scanner.Batch)
+	if batch <= 0 {
+		batch = 20
+	}
+	for n := next; n <= headNum && n < next+batch; n++ {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if prevHash, err = s.processBlock(ctx, n, prevHash); err != nil {
+			return err
+		}
+		if prevHash == "" {
+			// rolled back, the next round resumes from the fork point
+			return nil
+		}
+	}
+	return nil
+}
+
+// processBlock record deposits of block num and move the checkpoint in one db transaction,
+// so a restart resumes at the next block without gaps or duplicates. If the block does not
+// build on prevHash the chain reorganized, the scanner rolls back and returns an empty hash.
+func (s *BlockScanner) processBlock(ctx context.Context, num int64, prevHash string) (string, error) {
+	block, err := s.cli.GetBlockByNum(ctx, num)
+	if err != nil {
+		return "", err
+	}
+	hash := hex.EncodeToString(block.Blockid)
+	if parent := hex.EncodeToString(block.GetBlockHeader().GetRawData().GetParentHash()); prevHash != "" && parent != prevHash {
+		s.log.Sugar().Warnw("reorg detected", "block", num, "parent", parent, "expected", prevHash)
+		return "", s.rollback(ctx, num-1)
+	}
+
+	txs, err := s.extractTransfers(ctx, block)
+	if err != nil {
+		return "", err
+	}
+	deposits, err := s.filterDeposits(ctx, txs)
+	if err != nil {
+		return "", err
+	}
+
+	cp := &ScanCheckpoint{Name: depositScanner, BlockNum: num, BlockHash: hash}
+	events, err := s.repo.SaveBlock(ctx, cp, deposits)
+	if err != nil {
+		return "", err
+	}
+	for _, e := range events {
+		s.log.Sugar().Infow("deposit", "block", num, "txid", e.Tx.Txid, "token", e.Tx.Token, "to", e.Tx.To, "amount", e.Tx.Amount.String())
+	}
+	s.bus.Publish(events...)
+	s.invalidateBalances(ctx, txs)
+
+	if err := s.repo.PruneScanBlocks(ctx, depositScanner, num-s.reorgWindow()); err != nil {
+		s.log.Sugar().Warnw("PruneScanBlocks", "err", err)
+	}
+	return hash, nil
+}
+
+// rollback find the newest remembered block at or below tip that is still on the chain
+// and roll the scanner back to it
+func (s *BlockScanner) rollback(ctx context.Context, tip int64) error {
+	blocks, err := s.repo.ListScanBlocks(ctx, depositScanner, tip-s.reorgWindow()+1)
+	if err != nil {
+		return err
+	}
+	for _, b := range blocks {
+		if b.Num > tip {
+			continue
+		}
+		block, err := s.cli.GetBlockByNum(ctx, b.Num)
+		if err != nil {
+			return err
+		}
+		if hex.EncodeToString(block.Blockid) != b.Hash {
+			continue
+		}
+		events, err := s.repo.Rollback(ctx, &ScanCheckpoint{Name: depositScanner, BlockNum: b.Num, BlockHash: b.Hash})
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			s.log.Sugar().Warnw("rollback", "fork", b.Num, "txid", e.Tx.Txid, "token", e.Tx.Token, "to", e.Tx.To, "status", e.Status)
+		}
+		s.bus.Publish(events...)
+		txs := make([]*Tx, 0, len(events))
+		for _, e := range events {
+			txs = append(txs, e.Tx)
+		}
+		s.invalidateBalances(ctx, txs)
+		return nil
+	}
+	return fmt.Errorf("no common ancestor within %d blocks below %d, the reorg window is too small", s.reorgWindow(), tip)
+}
+
+// invalidateBalances drop the cached balances of the senders and receivers of txs. They may
+// be keyed by a head the nodes were probed at before the txs, so would outlive them.
+func (s *BlockScanner) invalidateBalances(ctx context.Context, txs []*Tx) {
+	if s.cache == nil || len(txs) == 0 {
+		return
+	}
+	seen := make(map[string]bool, 2*len(txs))
+	addrs := make([]string, 0, 2*len(txs))
+	for _, tx := range txs {
+		for _, addr := range []string{tx.From, tx.To} {
+			if addr != "" && !seen[addr] {
+				seen[addr] = true
+				addrs = append(addrs, addr)
+			}
+		}
+	}
+	if err := s.cache.InvalidateBalances(ctx, addrs...); err != nil {
+		s.log.Sugar().Warnw("InvalidateBalances", "addresses", len(addrs), "err", err)
+	}
+}
+
+func (s *BlockScanner) reorgWindow() int64 {
+	if s.cfg.Scanner.ReorgWindow > 0 {
+		return s.cfg.Scanner.ReorgWindow
+	}
+	return 100
+}
+
+// extractTransfers decode TRX, TRC10 and TRC20 transfers in block
+func (s *BlockScanner) extractTransfers(ctx context.Context, block *api.BlockExtention) ([]*Tx, error) {
+	raw := block.GetBlockHeader().GetRawData()
+	blockHash := hex.EncodeToString(block.Blockid)
+	blockTime := time.UnixMilli(raw.GetTimestamp())
+	tokens := s.tokensByContract()
+
+	var (
+		txs           []*Tx
+		needEventLogs bool
+	)
+	for _, te := range block.Transactions {
+		tx := te.GetTransaction()
+		if !contractSucceeded(tx) || len(tx.GetRawData().GetContract()) == 0 {
+			continue
+		}
+		contract := tx.GetRawData().GetContract()[0]
+		base := Tx{
+			Txid:      hex.EncodeToString(te.Txid),
+			BlockNum:  raw.GetNumber(),
+			BlockHash: blockHash,
+			BlockTime: &blockTime,
+			Status:    TxStatusPending,
+		}
+
+		switch contract.GetType() {
+		case core.Transaction_Contract_TransferContract:
+			c := new(core.TransferContract)
+			if err := unmarshalContract(contract, c); err != nil {
+				return nil, err
+			}
+			t := base
+			t.Token = TokenTRX
+			t.From = address.Address(c.OwnerAddress).String()
+			t.To = address.Address(c.ToAddress).String()
+			t.Amount = decimal.NewFromInt(c.Amount)
+			txs = append(txs, &t)
+		case core.Transaction_Contract_TransferAssetContract:
+			c := new(core.TransferAssetContract)
+			if err := unmarshalContract(contract, c); err != nil {
+				return nil, err
+			}
+			t := base
+			t.Token = string(c.AssetName)
+			t.From = address.Address(c.OwnerAddress).String()
+			t.To = address.Address(c.ToAddress).String()
+			t.Amount = decimal.NewFromInt(c.Amount)
+			txs = append(txs, &t)
+		case core.Transaction_Contract_TriggerSmartContract:
+			// transfer events are the source of truth, they also cover transferFrom and
+			// transfers made by routers, multisig or exchange contracts calling the token,
+			// so any contract call may have moved a token and logs are needed
+			needEventLogs = len(tokens) > 0
+		}
+	}
+
+	if !needEventLogs {
+		return txs, nil
+	}
+	infos, err := s.cli.GetTransactionInfoByBlockNum(ctx, raw.GetNumber())
+	if err != nil {
+		return nil, err
+	}
+	for _, info := range infos {
+		if info.GetResult() != core.TransactionInfo_SUCESS || info.GetReceipt().GetResult() != core.Transaction_Result_SUCCESS {
+			continue
+		}
+		for i, l := range info.GetLog() {
+			contractAddr := address.Address(append([]byte{address.TronBytePrefix}, l.Address...)).String()
+			symbol, ok := tokens[contractAddr]
+			if !ok {
+				continue
+			}
+			from, to, amount, ok := parseTransferEvent(l)
+			if !ok {
+				continue
+			}
+			txs = append(txs, &Tx{
+				Txid:      hex.EncodeToString(info.Id),
+				LogIndex:  i,
+				BlockNum:  raw.GetNumber(),
+				BlockHash: blockHash,
+				BlockTime: &blockTime,
+				Token:     symbol,
+				Contract:  contractAddr,
+				From:      from,
+				To:        to,
+				Amount:    decimal.NewFromBigInt(amount, 0),
+				Status:    TxStatusPending,
+			})
+		}
+	}
+	return txs, nil
+}
+
+// filterDeposits keep transfers whose recipient is a managed address
+func (s *BlockScanner) filterDeposits(ctx context.Context, txs []*Tx) ([]*Tx, error) {
+	if len(txs) == 0 {
+		return nil, nil
+	}
+	addrs := make([]string, 0, len(txs))
+	for _, t := range txs {
+		addrs = append(addrs, t.To)
+	}
+	managed, err := s.addrRepo.FilterManagedAddresses(ctx, addrs)
+	if err != nil {
+		return nil, err
+	}
+	var deposits []*Tx
+	for _, t := range txs {
+		if managed[t.To] {
+			t.Direction = TxDirectionIn
+			deposits = append(deposits, t)
+		}
+	}
+	return deposits, nil
+}
+
+// tokensByContract map configured TRC20 contract address to token symbol
+func (s *BlockScanner) tokensByContract() map[string]string {
+	tokens := make(map[string]string, len(s.cfg.TokenList))
+	for symbol, t := range s.cfg.TokenList {
+		tokens[t.ContractAddr] = strings.ToUpper(symbol)
+	}
+	return tokens
+}
+
+// parseTransferEvent decode a TRC20 Transfer(address,address,uint256) log
+func parseTransferEvent(l *core.TransactionInfo_Log) (from, to string, amount *big.Int, ok bool) {
+	if len(l.Topics) != 3 || len(l.Topics[1]) != 32 || len(l.Topics[2]) != 32 || len(l.Data) != 32 {
+		return "", "", nil, false
+	}
+	sig, _ := common.FromHex(trc20TransferEventSignature)
+	if !bytes.Equal(l.Topics[0], sig) {
+		return "", "", nil, false
+	}
+	from = address.Address(append([]byte{address.TronBytePrefix}, l.Topics[1][12:]...)).String()
+	to = address.Address(append([]byte{address.TronBytePrefix}, l.Topics[2][12:]...)).String()
+	return from, to, new(big.Int).SetBytes(l.Data), true
+}
+
+func contractSucceeded(tx *core.Transaction) bool {
+	for _, ret := range tx.GetRet() {
+		if ret.ContractRet != core.Transaction_Result_DEFAULT && ret.ContractRet != core.Transaction_Result_SUCCESS {
+			return false
+		}
+	}
+	return true
+}
+
+// unmarshalContract decode contract parameter into one of gotron-sdk's legacy contract messages
+func unmarshalContract(contract *core.Transaction_Contract, m protov1.Message) error {
+	return contract.GetParameter().UnmarshalTo(protov1.MessageV2(m))
+}
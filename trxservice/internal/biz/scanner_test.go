@@ -0,0 +1,631 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	protov1 "github.com/golang/protobuf/proto"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// fakeChain is a WalletClient serving blocks kept in memory
+type fakeChain struct {
+	api.WalletClient
+	mu     sync.Mutex
+	blocks []*api.BlockExtention // blocks[i] has number i
+	infos  map[int64][]*core.TransactionInfo
+	solid  int64
+	salt   byte // changes block hashes after a fork
+}
+
+func newFakeChain() *fakeChain {
+	c := &fakeChain{infos: make(map[int64][]*core.TransactionInfo)}
+	c.addBlock(nil, nil)
+	return c
+}
+
+// addBlock append a block on top of the current head and return its number
+func (c *fakeChain) addBlock(txs []*core.Transaction, infos []*core.TransactionInfo) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	num := int64(len(c.blocks))
+	var parent []byte
+	if num > 0 {
+		parent = c.blocks[num-1].Blockid
+	}
+	block := &api.BlockExtention{BlockHeader: &core.BlockHeader{RawData: &core.BlockHeaderRaw{
+		Number:     num,
+		ParentHash: parent,
+		Timestamp:  1666000000000 + num*3000,
+	}}}
+	seed := sha256.Sum256(append(parent, c.salt, byte(len(txs))))
+	block.Blockid = append(make([]byte, 8), seed[8:]...)
+	binary.BigEndian.PutUint64(block.Blockid, uint64(num))
+	for _, tx := range txs {
+		hash, _ := txHash(tx)
+		block.Transactions = append(block.Transactions, &api.TransactionExtention{Transaction: tx, Txid: hash})
+	}
+	for _, info := range infos {
+		info.BlockNumber = num
+		info.BlockTimeStamp = block.BlockHeader.RawData.Timestamp
+	}
+	c.blocks = append(c.blocks, block)
+	c.infos[num] = infos
+	return num
+}
+
+func (c *fakeChain) GetNowBlock2(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*api.BlockExtention, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.blocks[len(c.blocks)-1], nil
+}
+
+func (c *fakeChain) GetBlockByNum2(ctx context.Context, in *api.NumberMessage, opts ...grpc.CallOption) (*api.BlockExtention, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if in.Num >= int64(len(c.blocks)) {
+		return &api.BlockExtention{}, nil
+	}
+	return c.blocks[in.Num], nil
+}
+
+// fork drop blocks above num, blocks added later form a new branch
+func (c *fakeChain) fork(num int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for n := num + 1; n < int64(len(c.blocks)); n++ {
+		delete(c.infos, n)
+	}
+	c.blocks = c.blocks[:num+1]
+	c.salt++
+}
+
+// setSolid mark blocks up to num as solidified
+func (c *fakeChain) setSolid(num int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.solid = num
+}
+
+func (c *fakeChain) GetNodeInfo(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*core.NodeInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &core.NodeInfo{SolidityBlock: fmt.Sprintf("Num:%d,ID:%x", c.solid, c.blocks[c.solid].Blockid)}, nil
+}
+
+func (c *fakeChain) GetTransactionInfoById(ctx context.Context, in *api.BytesMessage, opts ...grpc.CallOption) (*core.TransactionInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, block := range c.blocks {
+		for _, te := range block.Transactions {
+			if bytes.Equal(te.Txid, in.Value) {
+				num := block.BlockHeader.RawData.Number
+				for _, info := range c.infos[num] {
+					if bytes.Equal(info.Id, in.Value) {
+						return info, nil
+					}
+				}
+				info := &core.TransactionInfo{Id: te.Txid, BlockNumber: num, BlockTimeStamp: block.BlockHeader.RawData.Timestamp}
+				if !contractSucceeded(te.Transaction) {
+					info.Result = core.TransactionInfo_FAILED
+				}
+				return info, nil
+			}
+		}
+	}
+	return &core.TransactionInfo{}, nil
+}
+
+func (c *fakeChain) GetTransactionInfoByBlockNum(ctx context.Context, in *api.NumberMessage, opts ...grpc.CallOption) (*api.TransactionInfoList, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &api.TransactionInfoList{TransactionInfo: c.infos[in.Num]}, nil
+}
+
+// memTrxRepo keeps scanned transactions in memory
+type memTrxRepo struct {
+	TrxRepo
+	mu     sync.Mutex
+	cps    map[string]ScanCheckpoint
+	blocks map[int64]string
+	txs    map[string]*Tx
+	events []*TxEvent
+	seq    int64
+	hold   bool // leave new events uncommitted until commitEvents
+}
+
+func newMemTrxRepo() *memTrxRepo {
+	return &memTrxRepo{cps: make(map[string]ScanCheckpoint), blocks: make(map[int64]string), txs: make(map[string]*Tx)}
+}
+
+func (r *memTrxRepo) GetCheckpoint(ctx context.Context, name string) (*ScanCheckpoint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cp, ok := r.cps[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &cp, nil
+}
+
+func (r *memTrxRepo) SaveBlock(ctx context.Context, cp *ScanCheckpoint, txs []*Tx) ([]*TxEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var events []*TxEvent
+	for _, t := range txs {
+		if e := r.createTx(t); e != nil {
+			events = append(events, e)
+		}
+	}
+	r.blocks[cp.BlockNum] = cp.BlockHash
+	r.cps[cp.Name] = *cp
+	return events, nil
+}
+
+func (r *memTrxRepo) ListScanBlocks(ctx context.Context, scanner string, from int64) ([]*ScanBlock, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var blocks []*ScanBlock
+	for num, hash := range r.blocks {
+		if num >= from {
+			blocks = append(blocks, &ScanBlock{Scanner: scanner, Num: num, Hash: hash})
+		}
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].Num > blocks[j].Num })
+	return blocks, nil
+}
+
+func (r *memTrxRepo) PruneScanBlocks(ctx context.Context, scanner string, below int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for num := range r.blocks {
+		if num < below {
+			delete(r.blocks, num)
+		}
+	}
+	return nil
+}
+
+func (r *memTrxRepo) Rollback(ctx context.Context, cp *ScanCheckpoint) ([]*TxEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var events []*TxEvent
+	for _, t := range r.txs {
+		if t.BlockNum <= cp.BlockNum || (t.Status != TxStatusPending && t.Status != TxStatusConfirmed) {
+			continue
+		}
+		from := t.Status
+		if t.Direction == TxDirectionIn {
+			t.Status = TxStatusReverted
+		} else {
+			t.Status, t.BlockNum, t.BlockHash, t.BlockTime = TxStatusPending, 0, "", nil
+		}
+		t.Confirmations = 0
+		if t.Status != from {
+			events = append(events, r.addEvent(t))
+		}
+	}
+	for num := range r.blocks {
+		if num > cp.BlockNum {
+			delete(r.blocks, num)
+		}
+	}
+	r.cps[cp.Name] = *cp
+	return events, nil
+}
+
+func (r *memTrxRepo) CreateTx(ctx context.Context, tx *Tx) (*TxEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e := r.createTx(tx)
+	if e == nil {
+		return nil, fmt.Errorf("duplicate tx %s", tx.Txid)
+	}
+	return e, nil
+}
+
+func (r *memTrxRepo) createTx(tx *Tx) *TxEvent {
+	key := memTxKey(tx)
+	if stored, ok := r.txs[key]; ok {
+		if stored.Status != TxStatusReverted {
+			return nil
+		}
+		tx.ID = stored.ID
+		*stored = *tx
+		return r.addEvent(stored)
+	}
+	tx.ID = int64(len(r.txs) + 1)
+	r.txs[key] = tx
+	return r.addEvent(tx)
+}
+
+// memTxKey mirrors uk_txid_log_direction
+func memTxKey(tx *Tx) string {
+	return fmt.Sprintf("%s/%d/%d", tx.Txid, tx.LogIndex, tx.Direction)
+}
+
+func (r *memTrxRepo) addEvent(tx *Tx) *TxEvent {
+	copied := *tx
+	e := &TxEvent{ID: int64(len(r.events) + 1), TxID: tx.ID, Tx: &copied, Status: tx.Status, Confirmations: tx.Confirmations}
+	if !r.hold {
+		r.seq++
+		e.Seq = r.seq
+	}
+	r.events = append(r.events, e)
+	return e
+}
+
+// commitEvents commit events written while held, they are sequenced after those already read
+func (r *memTrxRepo) commitEvents(ids ...int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, id := range ids {
+		r.seq++
+		r.events[id-1].Seq = r.seq
+	}
+}
+
+func (r *memTrxRepo) ListUnfinalizedTxs(ctx context.Context, afterID int64, limit int) ([]*Tx, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var txs []*Tx
+	for id := afterID + 1; id <= int64(len(r.txs)) && len(txs) < limit; id++ {
+		for _, t := range r.txs {
+			if t.ID == id && (t.Status == TxStatusPending || t.Status == TxStatusConfirmed) {
+				copied := *t
+				txs = append(txs, &copied)
+			}
+		}
+	}
+	return txs, nil
+}
+
+func (r *memTrxRepo) ListEvents(ctx context.Context, afterSeq int64, limit int) ([]*TxEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var events []*TxEvent
+	for _, e := range r.events {
+		if e.Seq > afterSeq {
+			events = append(events, e)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Seq < events[j].Seq })
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func (r *memTrxRepo) LastEventSeq(ctx context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.seq, nil
+}
+
+func (r *memTrxRepo) UpdateTxStatus(ctx context.Context, tx *Tx, from TxStatus) (*TxEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.txs[memTxKey(tx)]
+	if !ok || stored.Status != from {
+		return nil, ErrNotFound
+	}
+	*stored = *tx
+	return r.addEvent(stored), nil
+}
+
+type memAddressRepo struct {
+	AddressRepo
+	managed map[string]bool
+	account uint32
+}
+
+func (r *memAddressRepo) ListDepositAddresses(ctx context.Context, addrs []string) ([]*DepositAddress, error) {
+	var list []*DepositAddress
+	for _, a := range addrs {
+		if r.managed[a] {
+			list = append(list, &DepositAddress{Address: a, Account: r.account})
+		}
+	}
+	return list, nil
+}
+
+func (r *memAddressRepo) FilterManagedAddresses(ctx context.Context, addrs []string) (map[string]bool, error) {
+	found := make(map[string]bool)
+	for _, a := range addrs {
+		if r.managed[a] {
+			found[a] = true
+		}
+	}
+	return found, nil
+}
+
+func newTestAddress(t *testing.T) address.Address {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return address.PubkeyToAddress(key.PublicKey)
+}
+
+func newTestContractTx(t *testing.T, typ core.Transaction_Contract_ContractType, c protov1.Message) *core.Transaction {
+	t.Helper()
+	param, err := anypb.New(protov1.MessageV2(c))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &core.Transaction{
+		RawData: &core.TransactionRaw{
+			Contract:  []*core.Transaction_Contract{{Type: typ, Parameter: param}},
+			Timestamp: 1666000000000,
+		},
+		Ret: []*core.Transaction_Result{{ContractRet: core.Transaction_Result_SUCCESS}},
+	}
+}
+
+// newTestTRC20Transfer return a TriggerSmartContract tx and the receipt with its Transfer event
+func newTestTRC20Transfer(t *testing.T, contract, from, to address.Address, amount int64) (*core.Transaction, *core.TransactionInfo) {
+	t.Helper()
+	tx := newTestContractTx(t, core.Transaction_Contract_TriggerSmartContract, &core.TriggerSmartContract{
+		OwnerAddress:    from.Bytes(),
+		ContractAddress: contract.Bytes(),
+	})
+	id, _ := txHash(tx)
+	sig, _ := common.FromHex(trc20TransferEventSignature)
+	return tx, &core.TransactionInfo{
+		Id:      id,
+		Result:  core.TransactionInfo_SUCESS,
+		Receipt: &core.ResourceReceipt{Result: core.Transaction_Result_SUCCESS},
+		Log: []*core.TransactionInfo_Log{{
+			Address: contract.Bytes()[1:],
+			Topics:  [][]byte{sig, common.LeftPadBytes(from.Bytes()[1:], 32), common.LeftPadBytes(to.Bytes()[1:], 32)},
+			Data:    common.LeftPadBytes(big.NewInt(amount).Bytes(), 32),
+		}},
+	}
+}
+
+func newTestScanner(chain *fakeChain, repo TrxRepo, managed ...address.Address) (*BlockScanner, *setting.Config) {
+	cfg := &setting.Config{TokenList: map[string]setting.Token{}}
+	cfg.Scanner.StartBlock = 1
+	addrRepo := &memAddressRepo{managed: make(map[string]bool)}
+	for _, a := range managed {
+		addrRepo.managed[a.String()] = true
+	}
+	cli := &TronCli{TronWalletCli: chain, GrpcTimeout: time.Second}
+	return NewBlockScanner(cli, repo, addrRepo, nil, NewEventBus(), cfg, zap.NewNop()), cfg
+}
+
+func TestBlockScannerRecordsDeposits(t *testing.T) {
+	deposit, other := newTestAddress(t), newTestAddress(t)
+	usdt := newTestAddress(t)
+
+	chain := newFakeChain()
+	trxIn := newTestContractTx(t, core.Transaction_Contract_TransferContract, &core.TransferContract{
+		OwnerAddress: other.Bytes(), ToAddress: deposit.Bytes(), Amount: 1500000,
+	})
+	trxOut := newTestContractTx(t, core.Transaction_Contract_TransferContract, &core.TransferContract{
+		OwnerAddress: deposit.Bytes(), ToAddress: other.Bytes(), Amount: 7,
+	})
+	trc10In := newTestContractTx(t, core.Transaction_Contract_TransferAssetContract, &core.TransferAssetContract{
+		AssetName: []byte("1002000"), OwnerAddress: other.Bytes(), ToAddress: deposit.Bytes(), Amount: 9,
+	})
+	failed := newTestContractTx(t, core.Transaction_Contract_TransferContract, &core.TransferContract{
+		OwnerAddress: other.Bytes(), ToAddress: deposit.Bytes(), Amount: 1,
+	})
+	failed.Ret[0].ContractRet = core.Transaction_Result_REVERT
+	chain.addBlock([]*core.Transaction{trxIn, trxOut, trc10In, failed}, nil)
+	trc20Tx, trc20Info := newTestTRC20Transfer(t, usdt, other, deposit, 42)
+	chain.addBlock([]*core.Transaction{trc20Tx}, []*core.TransactionInfo{trc20Info})
+
+	repo := newMemTrxRepo()
+	s, cfg := newTestScanner(chain, repo, deposit)
+	cfg.TokenList["usdt"] = setting.Token{ContractAddr: usdt.String(), Decimal: 6}
+
+	if err := s.scan(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.txs) != 3 {
+		t.Fatalf("recorded %d deposits, want 3", len(repo.txs))
+	}
+	want := map[string]string{TokenTRX: "1500000", "1002000": "9", "USDT": "42"}
+	for _, tx := range repo.txs {
+		if tx.To != deposit.String() || tx.Direction != TxDirectionIn || tx.Status != TxStatusPending {
+			t.Errorf("unexpected deposit %+v", tx)
+		}
+		if amount, ok := want[tx.Token]; !ok || tx.Amount.String() != amount {
+			t.Errorf("deposit of %s amount %s", tx.Token, tx.Amount)
+		}
+	}
+	if cp := repo.cps[depositScanner]; cp.BlockNum != 2 {
+		t.Fatalf("checkpoint at %d, want 2", cp.BlockNum)
+	}
+}
+
+func TestBlockScannerRecordsTransfersByOtherContracts(t *testing.T) {
+	deposit, other, router, usdt := newTestAddress(t), newTestAddress(t), newTestAddress(t), newTestAddress(t)
+
+	// a router calls transferFrom on the token, only its Transfer event names the deposit
+	chain := newFakeChain()
+	tx := newTestContractTx(t, core.Transaction_Contract_TriggerSmartContract, &core.TriggerSmartContract{
+		OwnerAddress:    other.Bytes(),
+		ContractAddress: router.Bytes(),
+	})
+	_, info := newTestTRC20Transfer(t, usdt, router, deposit, 42)
+	info.Id, _ = txHash(tx)
+	_, foreign := newTestTRC20Transfer(t, newTestAddress(t), router, deposit, 5)
+	info.Log = append(info.Log, foreign.Log...)
+	chain.addBlock([]*core.Transaction{tx}, []*core.TransactionInfo{info})
+
+	repo := newMemTrxRepo()
+	s, cfg := newTestScanner(chain, repo, deposit)
+	cfg.TokenList["usdt"] = setting.Token{ContractAddr: usdt.String(), Decimal: 6}
+	if err := s.scan(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	// the event of a token not configured is ignored
+	if len(repo.txs) != 1 {
+		t.Fatalf("recorded %d deposits, want 1", len(repo.txs))
+	}
+	for _, d := range repo.txs {
+		if d.Token != "USDT" || d.Contract != usdt.String() || d.From != router.String() || d.To != deposit.String() || d.Amount.String() != "42" {
+			t.Fatalf("unexpected deposit %+v", d)
+		}
+	}
+}
+
+func TestBlockScannerRecordsWithdrawalToManagedAddress(t *testing.T) {
+	hot, deposit := newTestAddress(t), newTestAddress(t)
+	chain := newFakeChain()
+	tx := newTestContractTx(t, core.Transaction_Contract_TransferContract, &core.TransferContract{
+		OwnerAddress: hot.Bytes(), ToAddress: deposit.Bytes(), Amount: 7,
+	})
+	chain.addBlock([]*core.Transaction{tx}, nil)
+
+	// the hot wallet withdrew to one of our deposit addresses, both sides are recorded
+	repo := newMemTrxRepo()
+	txid, _ := txHash(tx)
+	if _, err := repo.CreateTx(context.Background(), &Tx{Txid: hex.EncodeToString(txid), Direction: TxDirectionOut,
+		From: hot.String(), To: deposit.String(), Amount: decimal.NewFromInt(7), Status: TxStatusPending}); err != nil {
+		t.Fatal(err)
+	}
+	s, _ := newTestScanner(chain, repo, deposit)
+	if err := s.scan(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	in, ok := repo.txs[memTxKey(&Tx{Txid: hex.EncodeToString(txid), Direction: TxDirectionIn})]
+	if len(repo.txs) != 2 || !ok || in.BlockNum != 1 {
+		t.Fatalf("recorded %v", repo.txs)
+	}
+}
+
+func TestBlockScannerResumesFromCheckpoint(t *testing.T) {
+	deposit, other := newTestAddress(t), newTestAddress(t)
+	chain := newFakeChain()
+	repo := newMemTrxRepo()
+	s, cfg := newTestScanner(chain, repo, deposit)
+	cfg.Scanner.Batch = 1
+
+	for i := int64(1); i <= 3; i++ {
+		chain.addBlock([]*core.Transaction{newTestContractTx(t, core.Transaction_Contract_TransferContract, &core.TransferContract{
+			OwnerAddress: other.Bytes(), ToAddress: deposit.Bytes(), Amount: i,
+		})}, nil)
+	}
+	for i := 0; i < 5; i++ {
+		if err := s.scan(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(repo.txs) != 3 {
+		t.Fatalf("recorded %d deposits, want 3", len(repo.txs))
+	}
+	if cp := repo.cps[depositScanner]; cp.BlockNum != 3 {
+		t.Fatalf("checkpoint at %d, want 3", cp.BlockNum)
+	}
+}
+
+func TestBlockScannerRollsBackOrphanedDeposits(t *testing.T) {
+	deposit, other := newTestAddress(t), newTestAddress(t)
+	newDeposit := func(amount int64) *core.Transaction {
+		return newTestContractTx(t, core.Transaction_Contract_TransferContract, &core.TransferContract{
+			OwnerAddress: other.Bytes(), ToAddress: deposit.Bytes(), Amount: amount,
+		})
+	}
+	orphaned, moved, kept := newDeposit(1), newDeposit(2), newDeposit(3)
+
+	chain := newFakeChain()
+	repo := newMemTrxRepo()
+	s, _ := newTestScanner(chain, repo, deposit)
+	var reverted []string
+	s.bus.Subscribe(func(e *TxEvent) {
+		if e.Status == TxStatusReverted {
+			reverted = append(reverted, e.Tx.Amount.String())
+		}
+	})
+	scan := func() {
+		t.Helper()
+		if err := s.scan(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	chain.addBlock([]*core.Transaction{kept}, nil)            // 1
+	chain.addBlock([]*core.Transaction{orphaned, moved}, nil) // 2
+	chain.addBlock(nil, nil)                                  // 3
+	scan()
+	if len(repo.txs) != 3 {
+		t.Fatalf("recorded %d deposits, want 3", len(repo.txs))
+	}
+
+	// blocks 2 and 3 are replaced by a longer branch that carries only one of the deposits
+	chain.fork(1)
+	chain.addBlock(nil, nil)                        // 2'
+	chain.addBlock([]*core.Transaction{moved}, nil) // 3'
+	chain.addBlock(nil, nil)                        // 4'
+	scan()                                          // detects the fork at 4' and rolls back to 1
+	if cp := repo.cps[depositScanner]; cp.BlockNum != 1 {
+		t.Fatalf("checkpoint at %d after rollback, want 1", cp.BlockNum)
+	}
+	scan() // rescans the new branch
+
+	if len(reverted) != 2 {
+		t.Fatalf("reverted %v, want both deposits of block 2", reverted)
+	}
+	want := map[string]struct {
+		status TxStatus
+		block  int64
+	}{
+		"1": {TxStatusReverted, 2},
+		"2": {TxStatusPending, 3},
+		"3": {TxStatusPending, 1},
+	}
+	for _, tx := range repo.txs {
+		w := want[tx.Amount.String()]
+		if tx.Status != w.status || tx.BlockNum != w.block {
+			t.Errorf("deposit of %s is %s at block %d, want %s at %d", tx.Amount, tx.Status, tx.BlockNum, w.status, w.block)
+		}
+	}
+	if cp := repo.cps[depositScanner]; cp.BlockNum != 4 || cp.BlockHash != hex.EncodeToString(chain.blocks[4].Blockid) {
+		t.Fatalf("checkpoint %+v, want the tip of the new branch", cp)
+	}
+}
+
+func TestBlockScannerReorgBeyondWindow(t *testing.T) {
+	chain := newFakeChain()
+	repo := newMemTrxRepo()
+	s, cfg := newTestScanner(chain, repo)
+	cfg.Scanner.ReorgWindow = 2
+	for i := 0; i < 5; i++ {
+		chain.addBlock(nil, nil)
+	}
+	if err := s.scan(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	chain.fork(1)
+	for i := 0; i < 5; i++ {
+		chain.addBlock(nil, nil)
+	}
+	if err := s.scan(context.Background()); err == nil {
+		t.Fatal("a fork deeper than the window must stop the scanner")
+	}
+	if cp := repo.cps[depositScanner]; cp.BlockNum != 5 {
+		t.Fatalf("checkpoint moved to %d", cp.BlockNum)
+	}
+}
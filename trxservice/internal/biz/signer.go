@@ -0,0 +1,139 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	protov1 "github.com/golang/protobuf/proto"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	SignerTypeLocal    = "local"
+	SignerTypeKeystore = "keystore"
+	SignerTypeRemote   = "remote"
+)
+
+// ErrSignerNotConfigured is returned when signing without a hot wallet key
+var ErrSignerNotConfigured = errors.New("signer not configured")
+
+// Signer signs a transaction built by TronCli
+type Signer interface {
+	Sign(ctx context.Context, tx *core.Transaction) (*core.Transaction, error)
+}
+
+// noSigner is the signer of deployments without a hot wallet key, e.g. read-only or
+// deposit-only ones. It fails only when used.
+type noSigner struct{}
+
+func (noSigner) Sign(ctx context.Context, tx *core.Transaction) (*core.Transaction, error) {
+	return nil, ErrSignerNotConfigured
+}
+
+// NewSigner create the signer backend selected by config, one refusing to sign when
+// signer.type is not set
+func NewSigner(cfg *setting.Config) (Signer, error) {
+	switch cfg.Signer.Type {
+	case "":
+		return noSigner{}, nil
+	case SignerTypeLocal:
+		if cfg.Signer.PrivateKey == "" {
+			return nil, fmt.Errorf("signer.private_key is required by a local signer")
+		}
+		return NewLocalSigner(cfg.Signer.PrivateKey)
+	case SignerTypeKeystore:
+		return NewKeystoreSigner(cfg.Signer.KeystoreFile, cfg.Signer.KeystorePassword)
+	case SignerTypeRemote:
+		return NewRemoteSigner(cfg.Signer.RemoteAddr, cfg.Signer.RemoteCAFile)
+	}
+	return nil, fmt.Errorf("unknown signer type %s", cfg.Signer.Type)
+}
+
+// keySigner signs with a private key held in memory
+type keySigner struct {
+	key  *ecdsa.PrivateKey
+	addr address.Address
+}
+
+// NewLocalSigner create signer with a hex encoded private key
+func NewLocalSigner(privateKey string) (Signer, error) {
+	key, err := crypto.HexToECDSA(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %v", err)
+	}
+	return newKeySigner(key), nil
+}
+
+func newKeySigner(key *ecdsa.PrivateKey) *keySigner {
+	return &keySigner{key: key, addr: address.PubkeyToAddress(key.PublicKey)}
+}
+
+func (s *keySigner) Sign(ctx context.Context, tx *core.Transaction) (*core.Transaction, error) {
+	owner, err := ownerAddress(tx)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(owner, s.addr.Bytes()) {
+		return nil, fmt.Errorf("signer %s can not sign for %s", s.addr.String(), address.Address(owner).String())
+	}
+	hash, err := txHash(tx)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := crypto.Sign(hash, s.key)
+	if err != nil {
+		return nil, err
+	}
+	tx.Signature = append(tx.Signature, signature)
+	return tx, nil
+}
+
+// txHash return sha256 of transaction raw data, which is also the txid
+func txHash(tx *core.Transaction) ([]byte, error) {
+	rawData, err := proto.Marshal(tx.GetRawData())
+	if err != nil {
+		return nil, err
+	}
+	h256h := sha256.New()
+	h256h.Write(rawData)
+	return h256h.Sum(nil), nil
+}
+
+// ownerAddress get owner address of the first contract in transaction
+func ownerAddress(tx *core.Transaction) ([]byte, error) {
+	contracts := tx.GetRawData().GetContract()
+	if len(contracts) == 0 {
+		return nil, fmt.Errorf("transaction has no contract")
+	}
+	msg, err := unmarshalContractParameter(contracts[0].GetParameter())
+	if err != nil {
+		return nil, err
+	}
+	// contract messages of gotron-sdk are generated with the legacy API
+	owner, ok := protov1.MessageV1(msg).(interface{ GetOwnerAddress() []byte })
+	if !ok {
+		return nil, fmt.Errorf("unsupported contract type %s", contracts[0].GetType())
+	}
+	return owner.GetOwnerAddress(), nil
+}
+
+// signerAddress recover the address that produced signature over the transaction
+func signerAddress(tx *core.Transaction, signature []byte) (address.Address, error) {
+	hash, err := txHash(tx)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := crypto.SigToPub(hash, signature)
+	if err != nil {
+		return nil, err
+	}
+	return address.PubkeyToAddress(*pub), nil
+}
@@ -0,0 +1,21 @@
+package biz
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
+)
+
+// NewKeystoreSigner create signer from a password encrypted keystore file
+func NewKeystoreSigner(file, password string) (Signer, error) {
+	keyJSON, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read keystore %s: %v", file, err)
+	}
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt keystore %s: %v", file, err)
+	}
+	return newKeySigner(key.PrivateKey), nil
+}
@@ -0,0 +1,77 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	pb "github.com/leondevpt/wallet/trxservice/api/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+)
+
+// remoteSigner delegates signing to a SignerService on a separate host
+type remoteSigner struct {
+	conn *grpc.ClientConn
+	cli  pb.SignerServiceClient
+}
+
+// NewRemoteSigner dial the remote signer, caFile enables TLS when set
+func NewRemoteSigner(addr, caFile string) (Signer, error) {
+	creds := insecure.NewCredentials()
+	if caFile != "" {
+		tlsCreds, err := credentials.NewClientTLSFromFile(caFile, "")
+		if err != nil {
+			return nil, err
+		}
+		creds = tlsCreds
+	}
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	return newRemoteSigner(conn), nil
+}
+
+func newRemoteSigner(conn *grpc.ClientConn) *remoteSigner {
+	return &remoteSigner{conn: conn, cli: pb.NewSignerServiceClient(conn)}
+}
+
+func (s *remoteSigner) Sign(ctx context.Context, tx *core.Transaction) (*core.Transaction, error) {
+	owner, err := ownerAddress(tx)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := proto.Marshal(tx)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := s.cli.SignTransaction(ctx, &pb.SignTransactionRequest{Transaction: raw})
+	if err != nil {
+		return nil, err
+	}
+	signed := new(core.Transaction)
+	if err := proto.Unmarshal(reply.Transaction, signed); err != nil {
+		return nil, err
+	}
+
+	// never trust the remote side to leave the transaction untouched
+	if !proto.Equal(signed.GetRawData(), tx.GetRawData()) {
+		return nil, fmt.Errorf("remote signer modified transaction raw data")
+	}
+	if len(signed.Signature) != len(tx.Signature)+1 {
+		return nil, fmt.Errorf("remote signer returned %d signatures, expect %d", len(signed.Signature), len(tx.Signature)+1)
+	}
+	addr, err := signerAddress(signed, signed.Signature[len(signed.Signature)-1])
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(addr.Bytes(), owner) {
+		return nil, fmt.Errorf("remote signature from %s, expect %s", addr.String(), address.Address(owner).String())
+	}
+	return signed, nil
+}
@@ -0,0 +1,198 @@
+package biz
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	protov1 "github.com/golang/protobuf/proto"
+	pb "github.com/leondevpt/wallet/trxservice/api/v1"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func newTestTransferTx(t *testing.T, owner address.Address) *core.Transaction {
+	t.Helper()
+	param, err := anypb.New(protov1.MessageV2(&core.TransferContract{OwnerAddress: owner.Bytes(), ToAddress: owner.Bytes(), Amount: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &core.Transaction{RawData: &core.TransactionRaw{
+		Contract: []*core.Transaction_Contract{{
+			Type:      core.Transaction_Contract_TransferContract,
+			Parameter: param,
+		}},
+		Timestamp:  1666000000000,
+		Expiration: 1666000060000,
+	}}
+}
+
+func checkSignedBy(t *testing.T, tx *core.Transaction, want address.Address) {
+	t.Helper()
+	if len(tx.Signature) != 1 {
+		t.Fatalf("got %d signatures, want 1", len(tx.Signature))
+	}
+	got, err := signerAddress(tx, tx.Signature[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != want.String() {
+		t.Fatalf("signed by %s, want %s", got, want)
+	}
+}
+
+func TestLocalSigner(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	owner := address.PubkeyToAddress(key.PublicKey)
+	signer, err := NewLocalSigner(hex.EncodeToString(crypto.FromECDSA(key)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := signer.Sign(context.Background(), newTestTransferTx(t, owner))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSignedBy(t, tx, owner)
+
+	other, _ := crypto.GenerateKey()
+	if _, err := signer.Sign(context.Background(), newTestTransferTx(t, address.PubkeyToAddress(other.PublicKey))); err == nil {
+		t.Fatal("expect error when signing for another owner")
+	}
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	owner := address.PubkeyToAddress(key.PublicKey)
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{Address: owner, PrivateKey: key}, "secret", keystore.LightScryptN, keystore.LightScryptP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "key.json")
+	if err := os.WriteFile(file, keyJSON, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewKeystoreSigner(file, "wrong"); err == nil {
+		t.Fatal("expect error with wrong password")
+	}
+	signer, err := NewKeystoreSigner(file, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := signer.Sign(context.Background(), newTestTransferTx(t, owner))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSignedBy(t, tx, owner)
+}
+
+// standInSigner is an in-process SignerService backed by a local key
+type standInSigner struct {
+	pb.UnimplementedSignerServiceServer
+	signer Signer
+	tamper bool
+}
+
+func (s *standInSigner) SignTransaction(ctx context.Context, req *pb.SignTransactionRequest) (*pb.SignTransactionReply, error) {
+	tx := new(core.Transaction)
+	if err := proto.Unmarshal(req.Transaction, tx); err != nil {
+		return nil, err
+	}
+	if s.tamper {
+		tx.RawData.Expiration += 1000
+	}
+	tx, err := s.signer.Sign(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := proto.Marshal(tx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SignTransactionReply{Transaction: raw}, nil
+}
+
+func newTestRemoteSigner(t *testing.T, srv pb.SignerServiceServer) Signer {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	pb.RegisterSignerServiceServer(s, srv)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return newRemoteSigner(conn)
+}
+
+func TestRemoteSigner(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	owner := address.PubkeyToAddress(key.PublicKey)
+
+	signer := newTestRemoteSigner(t, &standInSigner{signer: newKeySigner(key)})
+	tx, err := signer.Sign(context.Background(), newTestTransferTx(t, owner))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSignedBy(t, tx, owner)
+
+	// the stand-in refuses to sign for an owner it has no key for
+	other, _ := crypto.GenerateKey()
+	if _, err := signer.Sign(context.Background(), newTestTransferTx(t, address.PubkeyToAddress(other.PublicKey))); err == nil {
+		t.Fatal("expect error when remote has no key for owner")
+	}
+}
+
+func TestRemoteSignerRejectsTamperedTransaction(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	owner := address.PubkeyToAddress(key.PublicKey)
+
+	signer := newTestRemoteSigner(t, &standInSigner{signer: newKeySigner(key), tamper: true})
+	if _, err := signer.Sign(context.Background(), newTestTransferTx(t, owner)); err == nil {
+		t.Fatal("expect error when remote modifies raw data")
+	}
+}
+
+func TestSignerNotConfigured(t *testing.T) {
+	// a local signer needs its key, no type means no signer
+	if _, err := NewSigner(&setting.Config{Signer: setting.Signer{Type: SignerTypeLocal}}); err == nil {
+		t.Fatal("local signer without a private key")
+	}
+	signer, err := NewSigner(&setting.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := signer.Sign(context.Background(), newTestTransferTx(t, newTestAddress(t))); !errors.Is(err, ErrSignerNotConfigured) {
+		t.Fatalf("sign: %v", err)
+	}
+
+	// transfers fail before calling a node
+	uc := NewTrxUsecase(newMemTrxRepo(), nil, zap.NewNop(), &TronCli{}, signer, NewEventBus(), nil)
+	from, to := newTestAddress(t).String(), newTestAddress(t).String()
+	if _, err := uc.TransferTrx(context.Background(), from, to, sunPerTRX); !errors.Is(err, ErrSignerNotConfigured) {
+		t.Fatalf("transfer TRX: %v", err)
+	}
+	if _, err := uc.TransferTRC20(context.Background(), "USDT", from, to, newTestAddress(t).String(), big.NewInt(1), 0, 0); !errors.Is(err, ErrSignerNotConfigured) {
+		t.Fatalf("transfer TRC20: %v", err)
+	}
+}
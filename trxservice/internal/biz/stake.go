@@ -0,0 +1,164 @@
+package biz
+
+import (
+	"context"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// Unfreeze is staked TRX on its way back to the balance
+type Unfreeze struct {
+	Resource   core.ResourceCode
+	Amount     int64 // SUN
+	ExpireTime time.Time
+}
+
+// Delegation is the resource of staked TRX one account lends another, amounts in SUN
+type Delegation struct {
+	From                   string
+	To                     string
+	Bandwidth              int64
+	Energy                 int64
+	ExpireTimeForBandwidth time.Time // locked until, zero when not locked
+	ExpireTimeForEnergy    time.Time
+}
+
+// AccountResources is the resource state of an address under Stake 2.0
+type AccountResources struct {
+	Address            string
+	FreeBandwidthLimit int64
+	FreeBandwidthUsed  int64
+	BandwidthLimit     int64 // from staked and delegated TRX
+	BandwidthUsed      int64
+	EnergyLimit        int64
+	EnergyUsed         int64
+	FrozenBandwidth    int64 // SUN staked by the account for bandwidth
+	FrozenEnergy       int64
+	Unfreezing         []*Unfreeze
+	Withdrawable       int64 // SUN of expired unfreezes
+	DelegatedOut       []*Delegation
+	DelegatedIn        []*Delegation
+}
+
+// FreezeBalanceV2 stake amount SUN of owner for resource
+func (t *TrxUsecase) FreezeBalanceV2(ctx context.Context, owner string, amount int64, resource core.ResourceCode) (*api.TransactionExtention, error) {
+	return t.signAndBroadcast(ctx, func() (*api.TransactionExtention, error) {
+		return t.withExpiration(t.cli.FreezeBalanceV2(ctx, owner, amount, resource))
+	})
+}
+
+// UnfreezeBalanceV2 start unstaking amount SUN of owner staked for resource
+func (t *TrxUsecase) UnfreezeBalanceV2(ctx context.Context, owner string, amount int64, resource core.ResourceCode) (*api.TransactionExtention, error) {
+	return t.signAndBroadcast(ctx, func() (*api.TransactionExtention, error) {
+		return t.withExpiration(t.cli.UnfreezeBalanceV2(ctx, owner, amount, resource))
+	})
+}
+
+// WithdrawExpireUnfreeze move expired unfreezes of owner back to its balance
+func (t *TrxUsecase) WithdrawExpireUnfreeze(ctx context.Context, owner string) (*api.TransactionExtention, error) {
+	return t.signAndBroadcast(ctx, func() (*api.TransactionExtention, error) {
+		return t.withExpiration(t.cli.WithdrawExpireUnfreeze(ctx, owner))
+	})
+}
+
+// DelegateResource lend receiver the resource of amount SUN staked by owner
+func (t *TrxUsecase) DelegateResource(ctx context.Context, owner, receiver string, amount int64, resource core.ResourceCode, lock bool) (*api.TransactionExtention, error) {
+	return t.signAndBroadcast(ctx, func() (*api.TransactionExtention, error) {
+		return t.withExpiration(t.cli.DelegateResource(ctx, owner, receiver, amount, resource, lock))
+	})
+}
+
+// UnDelegateResource reclaim the resource of amount SUN owner delegated to receiver
+func (t *TrxUsecase) UnDelegateResource(ctx context.Context, owner, receiver string, amount int64, resource core.ResourceCode) (*api.TransactionExtention, error) {
+	return t.signAndBroadcast(ctx, func() (*api.TransactionExtention, error) {
+		return t.withExpiration(t.cli.UnDelegateResource(ctx, owner, receiver, amount, resource))
+	})
+}
+
+// GetAccountResources return limits, usage, stakes and delegations of addr
+func (t *TrxUsecase) GetAccountResources(ctx context.Context, addr string) (*AccountResources, error) {
+	res, err := t.cli.GetAccountResource(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	r := &AccountResources{
+		Address:            addr,
+		FreeBandwidthLimit: res.GetFreeNetLimit(),
+		FreeBandwidthUsed:  res.GetFreeNetUsed(),
+		BandwidthLimit:     res.GetNetLimit(),
+		BandwidthUsed:      res.GetNetUsed(),
+		EnergyLimit:        res.GetEnergyLimit(),
+		EnergyUsed:         res.GetEnergyUsed(),
+	}
+
+	_, stake, err := t.cli.GetAccount(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range stake.GetFrozenV2() {
+		switch core.ResourceCode(f.GetType()) {
+		case core.ResourceCode_BANDWIDTH:
+			r.FrozenBandwidth += f.GetAmount()
+		case core.ResourceCode_ENERGY:
+			r.FrozenEnergy += f.GetAmount()
+		}
+	}
+	now := time.Now()
+	for _, u := range stake.GetUnfrozenV2() {
+		expire := time.UnixMilli(u.GetUnfreezeExpireTime())
+		r.Unfreezing = append(r.Unfreezing, &Unfreeze{
+			Resource:   core.ResourceCode(u.GetType()),
+			Amount:     u.GetUnfreezeAmount(),
+			ExpireTime: expire,
+		})
+		if !expire.After(now) {
+			r.Withdrawable += u.GetUnfreezeAmount()
+		}
+	}
+
+	index, err := t.cli.GetDelegatedResourceAccountIndexV2(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	owner := index.GetAccount()
+	for _, to := range index.GetToAccounts() {
+		list, err := t.cli.GetDelegatedResourceV2(ctx, owner, to)
+		if err != nil {
+			return nil, err
+		}
+		r.DelegatedOut = append(r.DelegatedOut, toDelegations(list)...)
+	}
+	for _, from := range index.GetFromAccounts() {
+		list, err := t.cli.GetDelegatedResourceV2(ctx, from, owner)
+		if err != nil {
+			return nil, err
+		}
+		r.DelegatedIn = append(r.DelegatedIn, toDelegations(list)...)
+	}
+	return r, nil
+}
+
+func toDelegations(list []*core.DelegatedResource) []*Delegation {
+	ds := make([]*Delegation, 0, len(list))
+	for _, d := range list {
+		ds = append(ds, &Delegation{
+			From:                   address.Address(d.GetFrom()).String(),
+			To:                     address.Address(d.GetTo()).String(),
+			Bandwidth:              d.GetFrozenBalanceForBandwidth(),
+			Energy:                 d.GetFrozenBalanceForEnergy(),
+			ExpireTimeForBandwidth: unixMilliOrZero(d.GetExpireTimeForBandwidth()),
+			ExpireTimeForEnergy:    unixMilliOrZero(d.GetExpireTimeForEnergy()),
+		})
+	}
+	return ds
+}
+
+func unixMilliOrZero(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
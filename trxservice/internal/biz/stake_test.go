@@ -0,0 +1,192 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	pb "github.com/leondevpt/wallet/trxservice/api/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// standInWallet is an in-process node serving the calls staking makes. Stake 2.0 queries
+// gotron-sdk has no stubs for are answered by unknownMethod.
+type standInWallet struct {
+	api.UnimplementedWalletServer
+	created     []*core.Transaction_Contract
+	broadcast   []*core.Transaction
+	stake       *pb.AccountStakeV2
+	delegations []*core.DelegatedResource
+	resources   map[string]*api.AccountResourceMessage // by address bytes, a default otherwise
+}
+
+func (w *standInWallet) CreateCommonTransaction(ctx context.Context, in *core.Transaction) (*api.TransactionExtention, error) {
+	w.created = append(w.created, in.RawData.Contract[0])
+	in.RawData.Expiration = 1666000060000
+	raw, _ := proto.Marshal(in.RawData)
+	id := sha256.Sum256(raw)
+	return &api.TransactionExtention{Transaction: in, Txid: id[:], Result: &api.Return{Result: true}}, nil
+}
+
+func (w *standInWallet) BroadcastTransaction(ctx context.Context, in *core.Transaction) (*api.Return, error) {
+	w.broadcast = append(w.broadcast, in)
+	return &api.Return{Result: true}, nil
+}
+
+func (w *standInWallet) GetAccount(ctx context.Context, in *core.Account) (*core.Account, error) {
+	acc := &core.Account{Address: in.Address, Balance: 1}
+	unknown, err := proto.Marshal(w.stake)
+	if err != nil {
+		return nil, err
+	}
+	acc.ProtoReflect().SetUnknown(unknown)
+	return acc, nil
+}
+
+func (w *standInWallet) GetAccountResource(ctx context.Context, in *core.Account) (*api.AccountResourceMessage, error) {
+	if res, ok := w.resources[string(in.Address)]; ok {
+		return res, nil
+	}
+	return &api.AccountResourceMessage{FreeNetLimit: 600, NetLimit: 100, EnergyLimit: 65000, EnergyUsed: 32000}, nil
+}
+
+func (w *standInWallet) unknownMethod(srv interface{}, stream grpc.ServerStream) error {
+	method, _ := grpc.MethodFromServerStream(stream)
+	switch method {
+	case walletMethodPrefix + "GetDelegatedResourceAccountIndexV2":
+		in := new(api.BytesMessage)
+		if err := stream.RecvMsg(in); err != nil {
+			return err
+		}
+		index := &core.DelegatedResourceAccountIndex{Account: in.Value}
+		for _, d := range w.delegations {
+			index.ToAccounts = append(index.ToAccounts, d.To)
+		}
+		return stream.SendMsg(index)
+	case walletMethodPrefix + "GetDelegatedResourceV2":
+		in := new(api.DelegatedResourceMessage)
+		if err := stream.RecvMsg(in); err != nil {
+			return err
+		}
+		list := new(api.DelegatedResourceList)
+		for _, d := range w.delegations {
+			if bytes.Equal(d.From, in.FromAddress) && bytes.Equal(d.To, in.ToAddress) {
+				list.DelegatedResource = append(list.DelegatedResource, d)
+			}
+		}
+		return stream.SendMsg(list)
+	}
+	return fmt.Errorf("unexpected method %s", method)
+}
+
+func newTestStakeUsecase(t *testing.T, w *standInWallet) *TrxUsecase {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer(grpc.UnknownServiceHandler(w.unknownMethod))
+	api.RegisterWalletServer(s, w)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	cli := &TronCli{Conn: conn, TronWalletCli: api.NewWalletClient(conn), GrpcTimeout: time.Second}
+	return NewTrxUsecase(newMemTrxRepo(), nil, zap.NewNop(), cli, nopSigner{}, NewEventBus(), nil)
+}
+
+func TestStakeTransactions(t *testing.T) {
+	owner, hot := newTestAddress(t), newTestAddress(t)
+	w := &standInWallet{}
+	uc := newTestStakeUsecase(t, w)
+
+	if _, err := uc.FreezeBalanceV2(context.Background(), owner.String(), 1000000000, core.ResourceCode_ENERGY); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := uc.DelegateResource(context.Background(), owner.String(), hot.String(), 500000000, core.ResourceCode_ENERGY, true); err != nil {
+		t.Fatal(err)
+	}
+	if len(w.created) != 2 || len(w.broadcast) != 2 || len(w.broadcast[1].Signature) != 1 {
+		t.Fatalf("created %d, broadcast %d", len(w.created), len(w.broadcast))
+	}
+
+	if w.created[0].Type != FreezeBalanceV2ContractType {
+		t.Fatalf("contract type %d", w.created[0].Type)
+	}
+	// java-tron only knows the contract by its protocol name
+	if url := w.created[0].Parameter.TypeUrl; url != "type.googleapis.com/protocol.FreezeBalanceV2Contract" {
+		t.Fatalf("type url %s", url)
+	}
+	param, err := unmarshalContractParameter(w.created[0].Parameter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	freeze := param.(*pb.FreezeBalanceV2Contract)
+	if !bytes.Equal(freeze.OwnerAddress, owner.Bytes()) || freeze.FrozenBalance != 1000000000 || freeze.Resource != int32(core.ResourceCode_ENERGY) {
+		t.Fatalf("freeze %v", freeze)
+	}
+
+	if w.created[1].Type != DelegateResourceContractType {
+		t.Fatalf("contract type %d", w.created[1].Type)
+	}
+	if param, err = unmarshalContractParameter(w.created[1].Parameter); err != nil {
+		t.Fatal(err)
+	}
+	delegate := param.(*pb.DelegateResourceContract)
+	if !bytes.Equal(delegate.ReceiverAddress, hot.Bytes()) || delegate.Balance != 500000000 || !delegate.Lock {
+		t.Fatalf("delegate %v", delegate)
+	}
+}
+
+func TestGetAccountResources(t *testing.T) {
+	owner, hot := newTestAddress(t), newTestAddress(t)
+	expired := time.Now().Add(-time.Hour).UnixMilli()
+	pending := time.Now().Add(14 * 24 * time.Hour).UnixMilli()
+	w := &standInWallet{
+		stake: &pb.AccountStakeV2{
+			FrozenV2: []*pb.AccountStakeV2_FreezeV2{
+				{Type: int32(core.ResourceCode_BANDWIDTH), Amount: 10000000},
+				{Type: int32(core.ResourceCode_ENERGY), Amount: 700000000},
+			},
+			UnfrozenV2: []*pb.AccountStakeV2_UnFreezeV2{
+				{Type: int32(core.ResourceCode_ENERGY), UnfreezeAmount: 3000000, UnfreezeExpireTime: expired},
+				{Type: int32(core.ResourceCode_ENERGY), UnfreezeAmount: 5000000, UnfreezeExpireTime: pending},
+			},
+		},
+		delegations: []*core.DelegatedResource{{From: owner.Bytes(), To: hot.Bytes(), FrozenBalanceForEnergy: 500000000}},
+	}
+	uc := newTestStakeUsecase(t, w)
+
+	r, err := uc.GetAccountResources(context.Background(), owner.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.EnergyLimit != 65000 || r.EnergyUsed != 32000 || r.FreeBandwidthLimit != 600 {
+		t.Fatalf("resources %+v", r)
+	}
+	if r.FrozenBandwidth != 10000000 || r.FrozenEnergy != 700000000 {
+		t.Fatalf("frozen %d/%d", r.FrozenBandwidth, r.FrozenEnergy)
+	}
+	if len(r.Unfreezing) != 2 || r.Withdrawable != 3000000 {
+		t.Fatalf("unfreezing %d, withdrawable %d", len(r.Unfreezing), r.Withdrawable)
+	}
+	if len(r.DelegatedOut) != 1 || len(r.DelegatedIn) != 0 {
+		t.Fatalf("delegated out %d, in %d", len(r.DelegatedOut), len(r.DelegatedIn))
+	}
+	if d := r.DelegatedOut[0]; d.To != hot.String() || d.Energy != 500000000 || !d.ExpireTimeForEnergy.IsZero() {
+		t.Fatalf("delegation %+v", d)
+	}
+}
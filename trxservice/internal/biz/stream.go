@@ -0,0 +1,75 @@
+package biz
+
+import (
+	"context"
+	"time"
+)
+
+// streamSafetyPoll catches events written by other instances, which do not wake local subscribers
+const streamSafetyPoll = 10 * time.Second
+
+// TransferFilter selects the events a subscriber receives
+type TransferFilter struct {
+	Addresses map[string]bool // From or To must be one of them
+	Tokens    map[string]bool // empty for all tokens
+}
+
+func (f *TransferFilter) match(tx *Tx) bool {
+	if tx == nil || !(f.Addresses[tx.From] || f.Addresses[tx.To]) {
+		return false
+	}
+	return len(f.Tokens) == 0 || f.Tokens[tx.Token]
+}
+
+// SubscribeTransfers call send for every event matching f after cursor, in order, until ctx
+// is done or send fails. A zero cursor starts at the newest event. Each event's Seq is the
+// cursor to resume from after a reconnect.
+func (t *TrxUsecase) SubscribeTransfers(ctx context.Context, f *TransferFilter, cursor int64, send func(e *TxEvent) error) error {
+	wake := make(chan struct{}, 1)
+	unsubscribe := t.bus.Subscribe(func(e *TxEvent) {
+		if f.match(e.Tx) {
+			select {
+			case wake <- struct{}{}:
+			default:
+			}
+		}
+	})
+	defer unsubscribe()
+
+	if cursor <= 0 {
+		var err error
+		if cursor, err = t.repo.LastEventSeq(ctx); err != nil {
+			return err
+		}
+	}
+	safety := time.NewTicker(streamSafetyPoll)
+	defer safety.Stop()
+	for {
+		const batch = 100
+		for {
+			events, err := t.repo.ListEvents(ctx, cursor, batch)
+			if err != nil {
+				return err
+			}
+			for _, e := range events {
+				cursor = e.Seq
+				if !f.match(e.Tx) {
+					continue
+				}
+				if err := send(e); err != nil {
+					return err
+				}
+			}
+			if len(events) < batch {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-wake:
+		case <-safety.C:
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package biz
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestSubscribeTransfers(t *testing.T) {
+	watched, other := newTestAddress(t).String(), newTestAddress(t).String()
+	repo := newMemTrxRepo()
+	bus := NewEventBus()
+	uc := NewTrxUsecase(repo, nil, zap.NewNop(), nil, nil, bus, nil)
+
+	newTestDepositEvent(t, repo, watched) // event 1
+	newTestDepositEvent(t, repo, other)   // event 2
+
+	subscribe := func(cursor int64) (<-chan *TxEvent, context.CancelFunc) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := make(chan *TxEvent, 10)
+		f := &TransferFilter{Addresses: map[string]bool{watched: true}}
+		go func() {
+			_ = uc.SubscribeTransfers(ctx, f, cursor, func(e *TxEvent) error {
+				ch <- e
+				return nil
+			})
+		}()
+		return ch, cancel
+	}
+	next := func(ch <-chan *TxEvent) *TxEvent {
+		t.Helper()
+		select {
+		case e := <-ch:
+			return e
+		case <-time.After(5 * time.Second):
+			t.Fatal("no event")
+			return nil
+		}
+	}
+
+	live, cancelLive := subscribe(0)
+	defer cancelLive()
+	time.Sleep(50 * time.Millisecond) // let the subscriber register before publishing
+
+	// a state change of the watched deposit is pushed to the live subscriber
+	event, err := repo.UpdateTxStatus(context.Background(), &Tx{
+		ID: 1, Txid: repo.events[0].Tx.Txid, Direction: TxDirectionIn, To: watched, Token: TokenTRX, Status: TxStatusConfirmed,
+	}, TxStatusPending)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bus.Publish(event)
+	if e := next(live); e.ID != 3 || e.Status != TxStatusConfirmed {
+		t.Fatalf("got event %d %s, want 3 confirmed", e.ID, e.Status)
+	}
+
+	// a client reconnecting with cursor 1 gets event 3 but not event 2 of the other address
+	resumed, cancelResumed := subscribe(1)
+	defer cancelResumed()
+	if e := next(resumed); e.ID != 3 {
+		t.Fatalf("resumed at event %d, want 3", e.ID)
+	}
+}
+
+func TestSubscribeTransfersLateCommittedEvent(t *testing.T) {
+	first, second := newTestAddress(t).String(), newTestAddress(t).String()
+	repo := newMemTrxRepo()
+	bus := NewEventBus()
+	uc := NewTrxUsecase(repo, nil, zap.NewNop(), nil, nil, bus, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan *TxEvent, 10)
+	go func() {
+		_ = uc.SubscribeTransfers(ctx, &TransferFilter{Addresses: map[string]bool{first: true, second: true}}, 0, func(e *TxEvent) error {
+			ch <- e
+			return nil
+		})
+	}()
+	time.Sleep(50 * time.Millisecond) // let the subscriber register before publishing
+
+	// event 1 commits after event 2 was sent, it is sent next rather than skipped
+	repo.hold = true
+	newTestDepositEvent(t, repo, first)
+	repo.hold = false
+	newTestDepositEvent(t, repo, second)
+	bus.Publish(repo.events[1])
+	for i, want := range []int64{2, 1} {
+		if i > 0 {
+			repo.commitEvents(1)
+			bus.Publish(repo.events[0])
+		}
+		select {
+		case e := <-ch:
+			if e.ID != want {
+				t.Fatalf("got event %d, want %d", e.ID, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no event %d", want)
+		}
+	}
+}
@@ -0,0 +1,741 @@
+package biz
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	sweepActor = "sweep"
+
+	AuditActionSweepFund     = "sweep_fund"
+	AuditActionSweepDelegate = "sweep_delegate"
+	AuditActionSweep         = "sweep"
+	AuditActionSweepReclaim  = "sweep_reclaim"
+	AuditActionSweepFailed   = "sweep_failed"
+
+	// sweepTick is how often an open run moves on, its steps wait for blocks
+	sweepTick = 15 * time.Second
+	// sweepCatchUp is how late a scheduled run may still start, e.g. after a restart
+	sweepCatchUp     = time.Hour
+	maxSweepAttempts = 5
+	// sweepNothingLeft is the LastError of a task skipped for an empty balance
+	sweepNothingLeft = "nothing left to sweep"
+)
+
+var (
+	// ErrSweepDisabled is returned when a run is started while sweeping is switched off
+	ErrSweepDisabled = errors.New("sweep is not enabled")
+	// ErrSweepRunning is returned when a run is started before the last one is done
+	ErrSweepRunning = errors.New("a sweep run is in progress")
+)
+
+type SweepRunStatus string
+
+const (
+	SweepRunPlanning SweepRunStatus = "planning" // finding deposit addresses above the thresholds
+	SweepRunSweeping SweepRunStatus = "sweeping"
+	SweepRunDone     SweepRunStatus = "done"
+)
+
+type SweepStatus string
+
+const (
+	SweepStatusPlanned    SweepStatus = "planned"
+	SweepStatusFunding    SweepStatus = "funding"    // the gas wallet sends TRX for the fees
+	SweepStatusDelegating SweepStatus = "delegating" // the staking account delegates energy
+	SweepStatusSweeping   SweepStatus = "sweeping"   // the balance goes to the treasury
+	SweepStatusReclaiming SweepStatus = "reclaiming" // the delegated energy is taken back
+	SweepStatusSwept      SweepStatus = "swept"
+	SweepStatusSkipped    SweepStatus = "skipped"   // the balance no longer covers the fee
+	SweepStatusEstimated  SweepStatus = "estimated" // planned by a dry run, nothing was sent
+	SweepStatusFailed     SweepStatus = "failed"
+)
+
+// sweepSteps is the order of the steps of a task, steps it does not need are skipped
+var sweepSteps = []SweepStatus{SweepStatusPlanned, SweepStatusFunding, SweepStatusDelegating, SweepStatusSweeping,
+	SweepStatusReclaiming, SweepStatusSwept}
+
+// SweepRun is one pass collecting deposit addresses into the treasury
+type SweepRun struct {
+	ID         int64          `gorm:"primaryKey" json:"id"`
+	DryRun     bool           `gorm:"not null" json:"dry_run"`
+	Status     SweepRunStatus `gorm:"size:16;not null;index" json:"status"`
+	Cursor     int64          `gorm:"not null" json:"cursor"` // ID of the last deposit address planned
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	FinishedAt *time.Time     `json:"finished_at"`
+}
+
+// SweepTask sweeps one token of a deposit address. Each step stores its signed tx before
+// broadcasting it, a run resumed after a crash never sends a step twice.
+type SweepTask struct {
+	ID             int64           `gorm:"primaryKey" json:"id"`
+	RunID          int64           `gorm:"not null;uniqueIndex:uk_run_address_token" json:"run_id"`
+	Address        string          `gorm:"size:34;not null;uniqueIndex:uk_run_address_token" json:"address"`
+	Account        uint32          `gorm:"not null" json:"account"`
+	Index          uint32          `gorm:"column:address_index;not null" json:"index"`
+	Token          string          `gorm:"size:32;not null;uniqueIndex:uk_run_address_token" json:"token"`
+	Contract       string          `gorm:"size:34" json:"contract"`                   // empty for TRX
+	Amount         decimal.Decimal `gorm:"type:decimal(65,0);not null" json:"amount"` // in the token's smallest unit, swept or to sweep
+	Status         SweepStatus     `gorm:"size:16;not null" json:"status"`
+	FundAmount     int64           `gorm:"not null" json:"fund_amount"`     // SUN sent by the gas wallet
+	DelegateAmount int64           `gorm:"not null" json:"delegate_amount"` // SUN of stake delegated for energy
+	FundTxid       string          `gorm:"size:64" json:"fund_txid"`
+	DelegateTxid   string          `gorm:"size:64" json:"delegate_txid"`
+	SweepTxid      string          `gorm:"size:64" json:"sweep_txid"`
+	ReclaimTxid    string          `gorm:"size:64" json:"reclaim_txid"`
+	RawTx          string          `gorm:"type:text" json:"-"` // hex of the signed tx of the current step until it is included
+	ExpiresAt      *time.Time      `json:"expires_at"`
+	Attempts       int             `gorm:"not null" json:"attempts"`
+	LastError      string          `gorm:"size:255" json:"last_error"`
+	Failure        string          `gorm:"size:255" json:"failure"` // why the sweep failed, set while delegated energy is reclaimed
+	Skip           bool            `gorm:"not null" json:"skip"`    // nothing was left to sweep, set while delegated energy is reclaimed
+	Version        int64           `gorm:"not null" json:"-"`       // bumped by every update
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// Finished report whether nothing is left to do for t
+func (t *SweepTask) Finished() bool {
+	switch t.Status {
+	case SweepStatusSwept, SweepStatusSkipped, SweepStatusEstimated, SweepStatusFailed:
+		return true
+	}
+	return false
+}
+
+// stepTxid return the txid field of the current step
+func (t *SweepTask) stepTxid() *string {
+	switch t.Status {
+	case SweepStatusFunding:
+		return &t.FundTxid
+	case SweepStatusDelegating:
+		return &t.DelegateTxid
+	case SweepStatusSweeping:
+		return &t.SweepTxid
+	case SweepStatusReclaiming:
+		return &t.ReclaimTxid
+	}
+	return new(string)
+}
+
+// nextStep return the step after the current one that t needs
+func (t *SweepTask) nextStep() SweepStatus {
+	next := false
+	for _, step := range sweepSteps {
+		switch {
+		case step == t.Status:
+			next = true
+		case !next:
+		case step == SweepStatusFunding && t.FundAmount == 0:
+		case (step == SweepStatusDelegating || step == SweepStatusReclaiming) && t.DelegateAmount == 0:
+		case step == SweepStatusSwept && t.Failure != "":
+			return SweepStatusFailed
+		case step == SweepStatusSwept && t.Skip:
+			return SweepStatusSkipped
+		default:
+			return step
+		}
+	}
+	return SweepStatusSwept
+}
+
+// SweepRepo persists sweep runs
+type SweepRepo interface {
+	// LatestSweepRun return the newest run, ErrNotFound if there is none
+	LatestSweepRun(ctx context.Context) (*SweepRun, error)
+	// GetSweepRun return a run by ID, ErrNotFound if there is none
+	GetSweepRun(ctx context.Context, id int64) (*SweepRun, error)
+	// CreateSweepRun store a new run, ErrSweepRunning if another one is not done
+	CreateSweepRun(ctx context.Context, run *SweepRun) error
+	UpdateSweepRun(ctx context.Context, run *SweepRun) error
+	// SavePlan store tasks and the cursor and status of run atomically, tasks stored already are kept
+	SavePlan(ctx context.Context, run *SweepRun, tasks []*SweepTask) error
+	// ListSweepTasks return the tasks of a run in ID order
+	ListSweepTasks(ctx context.Context, runID int64) ([]*SweepTask, error)
+	// UpdateSweepTask persist t if nobody updated it since it was read and bump its Version.
+	// It returns ErrNotFound otherwise.
+	UpdateSweepTask(ctx context.Context, t *SweepTask) error
+}
+
+// SweepUsecase collects the balances of deposit addresses into the treasury. TRC20 sweeps
+// are funded with just the TRX or energy they need first.
+type SweepUsecase struct {
+	uc       *TrxUsecase
+	wallet   *HDWallet
+	repo     SweepRepo
+	addrRepo AddressRepo
+	audit    AuditRepo
+	cfg      *setting.Config
+	log      *zap.Logger
+	now      func() time.Time
+	wake     chan struct{}
+}
+
+// NewSweepUsecase new a sweep usecase.
+func NewSweepUsecase(uc *TrxUsecase, wallet *HDWallet, repo SweepRepo, addrRepo AddressRepo, audit AuditRepo, cfg *setting.Config,
+	logger *zap.Logger) *SweepUsecase {
+	return &SweepUsecase{
+		uc:       uc,
+		wallet:   wallet,
+		repo:     repo,
+		addrRepo: addrRepo,
+		audit:    audit,
+		cfg:      cfg,
+		log:      logger,
+		now:      time.Now,
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Enabled report whether sweeping is switched on in config
+func (s *SweepUsecase) Enabled() bool {
+	return s.cfg.Sweep.Enable
+}
+
+// Start open a run outside the schedule, the job picks it up at once
+func (s *SweepUsecase) Start(ctx context.Context, dryRun bool) (*SweepRun, error) {
+	if !s.Enabled() {
+		return nil, ErrSweepDisabled
+	}
+	run := &SweepRun{DryRun: dryRun, Status: SweepRunPlanning}
+	if err := s.repo.CreateSweepRun(ctx, run); err != nil {
+		return nil, err
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return run, nil
+}
+
+// GetRun return a run and its tasks
+func (s *SweepUsecase) GetRun(ctx context.Context, id int64) (*SweepRun, []*SweepTask, error) {
+	run, err := s.repo.GetSweepRun(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	tasks, err := s.repo.ListSweepTasks(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return run, tasks, nil
+}
+
+// Run start runs on schedule and move the open one on until ctx is done
+func (s *SweepUsecase) Run(ctx context.Context) error {
+	for {
+		if err := s.tick(ctx); err != nil {
+			s.log.Sugar().Errorw("SweepUsecase", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.wake:
+		case <-time.After(sweepTick):
+		}
+	}
+}
+
+// tick resume the open run, or open one when the schedule says so
+func (s *SweepUsecase) tick(ctx context.Context) error {
+	cfg := s.cfg.Sweep
+	run, err := s.repo.LatestSweepRun(ctx)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	if run == nil || run.Status == SweepRunDone {
+		var last time.Time
+		if run != nil {
+			last = run.CreatedAt
+		}
+		due, err := sweepDue(cfg, last, s.now())
+		if err != nil || !due {
+			return err
+		}
+		run = &SweepRun{DryRun: cfg.DryRun, Status: SweepRunPlanning}
+		if err := s.repo.CreateSweepRun(ctx, run); err != nil {
+			return err
+		}
+		s.log.Sugar().Infow("sweep run started", "run", run.ID, "dry_run", run.DryRun)
+	}
+	if cfg.Treasury == "" {
+		return fmt.Errorf("sweep.treasury is not configured")
+	}
+	if run.Status == SweepRunPlanning {
+		if err := s.plan(ctx, run, cfg); err != nil {
+			return err
+		}
+	}
+	if run.Status == SweepRunSweeping {
+		return s.sweep(ctx, run, cfg)
+	}
+	return nil
+}
+
+// sweepDue report whether a scheduled run starts at now, the last one started at last.
+// Daily times take precedence over the interval.
+func sweepDue(cfg setting.Sweep, last, now time.Time) (bool, error) {
+	if len(cfg.Times) == 0 {
+		return cfg.Interval > 0 && now.Sub(last) >= time.Duration(cfg.Interval)*time.Second, nil
+	}
+	var latest time.Time // the latest scheduled start not after now
+	for _, t := range cfg.Times {
+		hm, err := time.Parse("15:04", t)
+		if err != nil {
+			return false, fmt.Errorf("invalid sweep time %q", t)
+		}
+		at := time.Date(now.Year(), now.Month(), now.Day(), hm.Hour(), hm.Minute(), 0, 0, now.Location())
+		if at.After(now) {
+			at = at.AddDate(0, 0, -1)
+		}
+		if at.After(latest) {
+			latest = at
+		}
+	}
+	return last.Before(latest) && now.Sub(latest) < sweepCatchUp, nil
+}
+
+type sweepToken struct {
+	symbol    string
+	contract  string
+	threshold *big.Int // in the token's smallest unit
+}
+
+// tokens resolve the configured thresholds
+func (s *SweepUsecase) tokens(ctx context.Context, cfg setting.Sweep) ([]sweepToken, error) {
+	list := make([]sweepToken, 0, len(cfg.Tokens))
+	for _, t := range cfg.Tokens {
+		tk := sweepToken{symbol: strings.ToUpper(t.Token)}
+		decimals := int32(6)
+		if tk.symbol != TokenTRX {
+			info, ok := s.token(tk.symbol)
+			if !ok {
+				return nil, fmt.Errorf("sweep token %s is not in tokenList", t.Token)
+			}
+			d, err := s.uc.TokenDecimals(ctx, info)
+			if err != nil {
+				return nil, fmt.Errorf("decimals of sweep token %s: %v", t.Token, err)
+			}
+			tk.contract, decimals = info.ContractAddr, d
+		}
+		threshold, err := decimal.NewFromString(t.Threshold)
+		if err != nil || !threshold.IsPositive() {
+			return nil, fmt.Errorf("invalid sweep threshold %q of %s", t.Threshold, t.Token)
+		}
+		tk.threshold = threshold.Shift(decimals).Ceil().BigInt()
+		list = append(list, tk)
+	}
+	return list, nil
+}
+
+func (s *SweepUsecase) token(symbol string) (setting.Token, bool) {
+	for k, t := range s.cfg.TokenList {
+		if strings.ToUpper(k) == symbol {
+			return t, true
+		}
+	}
+	return setting.Token{}, false
+}
+
+// plan page through the deposit addresses from the cursor of run and store a task for each
+// balance above its threshold
+func (s *SweepUsecase) plan(ctx context.Context, run *SweepRun, cfg setting.Sweep) error {
+	tokens, err := s.tokens(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	batch := cfg.Batch
+	if batch <= 0 {
+		batch = 200
+	}
+	for run.Status == SweepRunPlanning {
+		if ctx.Err() != nil {
+			return nil
+		}
+		addrs, err := s.addrRepo.ListDepositAddressesAfter(ctx, run.Cursor, batch)
+		if err != nil {
+			return err
+		}
+		var tasks []*SweepTask
+		for _, a := range addrs {
+			for _, tk := range tokens {
+				balance, err := s.balance(ctx, a.Address, tk.contract)
+				if err != nil {
+					return err
+				}
+				if balance.Cmp(tk.threshold) < 0 {
+					continue
+				}
+				tasks = append(tasks, &SweepTask{
+					RunID: run.ID, Address: a.Address, Account: a.Account, Index: a.Index,
+					Token: tk.symbol, Contract: tk.contract, Amount: decimal.NewFromBigInt(balance, 0), Status: SweepStatusPlanned,
+				})
+			}
+			run.Cursor = a.ID
+		}
+		if len(addrs) < batch {
+			run.Status = SweepRunSweeping
+		}
+		if err := s.repo.SavePlan(ctx, run, tasks); err != nil {
+			return err
+		}
+	}
+	s.log.Sugar().Infow("sweep run planned", "run", run.ID, "dry_run", run.DryRun)
+	return nil
+}
+
+// balance return the solidified balance of addr to be swept, funds that may still be
+// reverted are left for the next run. Without solidity nodes it is the current balance.
+func (s *SweepUsecase) balance(ctx context.Context, addr, contract string) (*big.Int, error) {
+	confirmed := s.uc.cli.HasSolidityNodes()
+	if contract != "" {
+		return s.uc.GetTRC20TokenBalance(ctx, addr, contract, confirmed)
+	}
+	get := s.uc.cli.GetAccountBalance
+	if confirmed {
+		get = s.uc.cli.GetSolidAccountBalance
+	}
+	balance, _, err := get(ctx, addr)
+	return big.NewInt(balance), err
+}
+
+// sweep move every unfinished task of run one step on and close the run once all are
+// finished. TRX is swept last, the fees of token sweeps are paid from it.
+func (s *SweepUsecase) sweep(ctx context.Context, run *SweepRun, cfg setting.Sweep) error {
+	tasks, err := s.repo.ListSweepTasks(ctx, run.ID)
+	if err != nil {
+		return err
+	}
+	busy := make(map[string]bool)
+	for _, t := range tasks {
+		if t.Contract != "" && !t.Finished() {
+			busy[t.Address] = true
+		}
+	}
+	unfinished := 0
+	for _, t := range tasks {
+		if t.Finished() {
+			continue
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		if t.Contract != "" || !busy[t.Address] {
+			if run.DryRun {
+				err = s.estimate(ctx, t, cfg)
+			} else {
+				err = s.advance(ctx, t, cfg)
+			}
+			if err != nil {
+				s.retry(ctx, t, err)
+			}
+		}
+		if !t.Finished() {
+			unfinished++
+		}
+	}
+	if unfinished > 0 {
+		return nil
+	}
+	now := s.now()
+	run.Status, run.FinishedAt = SweepRunDone, &now
+	if err := s.repo.UpdateSweepRun(ctx, run); err != nil {
+		return err
+	}
+	s.log.Sugar().Infow("sweep run done", "run", run.ID, "dry_run", run.DryRun, "tasks", len(tasks))
+	return nil
+}
+
+// estimate work out the funding of t without sending anything
+func (s *SweepUsecase) estimate(ctx context.Context, t *SweepTask, cfg setting.Sweep) error {
+	if err := s.planFunding(ctx, t, cfg); err != nil {
+		return err
+	}
+	t.Status = SweepStatusEstimated
+	return s.update(ctx, t)
+}
+
+// advance do the next step of t. A step whose tx is out waits for it to be included.
+func (s *SweepUsecase) advance(ctx context.Context, t *SweepTask, cfg setting.Sweep) error {
+	if t.RawTx != "" {
+		return s.settle(ctx, t)
+	}
+	var (
+		tx  *api.TransactionExtention
+		err error
+	)
+	switch t.Status {
+	case SweepStatusPlanned:
+		if err := s.planFunding(ctx, t, cfg); err != nil {
+			return err
+		}
+		t.Status = t.nextStep()
+		return s.update(ctx, t)
+	case SweepStatusFunding:
+		if cfg.GasWallet == "" {
+			return fmt.Errorf("sweep.gas_wallet is not configured")
+		}
+		if tx, err = s.uc.cli.Transfer(ctx, cfg.GasWallet, t.Address, t.FundAmount); err != nil {
+			return err
+		}
+		return s.send(ctx, t, tx, s.uc.signer, AuditActionSweepFund)
+	case SweepStatusDelegating:
+		if tx, err = s.uc.cli.DelegateResource(ctx, cfg.StakingAccount, t.Address, t.DelegateAmount, core.ResourceCode_ENERGY, false); err != nil {
+			return err
+		}
+		return s.send(ctx, t, tx, s.uc.signer, AuditActionSweepDelegate)
+	case SweepStatusSweeping:
+		return s.sendSweep(ctx, t, cfg)
+	case SweepStatusReclaiming:
+		if tx, err = s.uc.cli.UnDelegateResource(ctx, cfg.StakingAccount, t.Address, t.DelegateAmount, core.ResourceCode_ENERGY); err != nil {
+			return err
+		}
+		return s.send(ctx, t, tx, s.uc.signer, AuditActionSweepReclaim)
+	}
+	return nil
+}
+
+// planFunding work out what a token sweep of t needs: energy delegated by the staking
+// account when there is one, and TRX from the gas wallet for the rest of the fees
+func (s *SweepUsecase) planFunding(ctx context.Context, t *SweepTask, cfg setting.Sweep) error {
+	t.FundAmount, t.DelegateAmount = 0, 0
+	if t.Contract == "" {
+		return nil
+	}
+	est, err := s.uc.EstimateFee(ctx, t.Address, cfg.Treasury, t.Contract, t.Amount.BigInt())
+	if err != nil {
+		return err
+	}
+	burn := est.Burn
+	if short := est.Energy - est.StakedEnergy; short > 0 && cfg.StakingAccount != "" {
+		res, err := s.uc.cli.GetAccountResource(ctx, t.Address)
+		if err != nil {
+			return err
+		}
+		energy := short * (100 + feeLimitMargin) / 100
+		if t.DelegateAmount, err = stakeForEnergy(energy, res.GetTotalEnergyLimit(), res.GetTotalEnergyWeight()); err != nil {
+			return err
+		}
+		burn -= short * est.EnergyFee
+	}
+	balance, activated, err := s.uc.cli.GetAccountBalance(ctx, t.Address)
+	if err != nil {
+		return err
+	}
+	if need := burn*(100+feeLimitMargin)/100 - balance; need > 0 {
+		t.FundAmount = need
+	}
+	// only an activated account can send, receiving TRX activates it
+	if !activated && t.FundAmount < sunPerTRX {
+		t.FundAmount = sunPerTRX
+	}
+	return nil
+}
+
+// sendSweep send the current balance of t to the treasury, signed with the key of the
+// deposit address
+func (s *SweepUsecase) sendSweep(ctx context.Context, t *SweepTask, cfg setting.Sweep) error {
+	signer, err := s.wallet.Signer(t.Account, t.Index)
+	if err != nil {
+		return err
+	}
+	var (
+		tx     *api.TransactionExtention
+		amount *big.Int
+	)
+	if t.Contract == "" {
+		balance, _, err := s.uc.cli.GetAccountBalance(ctx, t.Address)
+		if err != nil {
+			return err
+		}
+		est, err := s.uc.EstimateFee(ctx, t.Address, cfg.Treasury, "", big.NewInt(balance))
+		if err != nil {
+			return err
+		}
+		amount = big.NewInt(balance - est.Burn)
+	} else {
+		if amount, err = s.uc.cli.GetTRC20TokenBalance(ctx, t.Address, t.Contract); err != nil {
+			return err
+		}
+	}
+	if amount.Sign() <= 0 {
+		t.Status, t.Skip = SweepStatusSkipped, true
+		if t.DelegateAmount > 0 {
+			t.Status = SweepStatusReclaiming
+		}
+		t.LastError = sweepNothingLeft
+		return s.update(ctx, t)
+	}
+
+	if t.Contract == "" {
+		tx, err = s.uc.cli.Transfer(ctx, t.Address, cfg.Treasury, amount.Int64())
+	} else {
+		info, _ := s.token(t.Token)
+		tx, err = s.uc.buildTransfer(ctx, t.Address, cfg.Treasury, t.Contract, amount, 0, info.FeeLimit)
+	}
+	if err != nil {
+		return err
+	}
+	t.Amount = decimal.NewFromBigInt(amount, 0)
+	return s.send(ctx, t, tx, signer, AuditActionSweep)
+}
+
+// send sign the tx of the current step of t, store it and broadcast it
+func (s *SweepUsecase) send(ctx context.Context, t *SweepTask, tx *api.TransactionExtention, signer Signer, action string) error {
+	signed, err := signer.Sign(ctx, tx.Transaction)
+	if err != nil {
+		return err
+	}
+	raw, err := proto.Marshal(signed)
+	if err != nil {
+		return err
+	}
+	expiresAt := time.UnixMilli(signed.GetRawData().GetExpiration())
+	txid := hex.EncodeToString(tx.Txid)
+	*t.stepTxid() = txid
+	t.RawTx, t.ExpiresAt = hex.EncodeToString(raw), &expiresAt
+	if err := s.update(ctx, t); err != nil {
+		return err
+	}
+	s.record(ctx, action, t, txid)
+	return s.broadcast(ctx, t, signed)
+}
+
+// broadcast send the stored tx of t. When the node can not be reached it is broadcast
+// again later, when the node rejects it the step is done again.
+func (s *SweepUsecase) broadcast(ctx context.Context, t *SweepTask, tx *core.Transaction) error {
+	ret, err := s.uc.cli.Broadcast(ctx, tx)
+	if err == nil || ret.GetCode() == api.Return_DUP_TRANSACTION_ERROR {
+		return nil
+	}
+	if ret == nil {
+		return err
+	}
+	if class := broadcastClass(err); class == nil || class.Retryable() {
+		// settled once it is included or expired
+		return err
+	}
+	*t.stepTxid() = ""
+	t.RawTx, t.ExpiresAt = "", nil
+	if uerr := s.update(ctx, t); uerr != nil {
+		return uerr
+	}
+	return err
+}
+
+// settle move t on once the tx of its step is included. A tx that expired without being
+// included is dropped so the step is done again, one not seen for a while is broadcast again.
+func (s *SweepUsecase) settle(ctx context.Context, t *SweepTask) error {
+	txid := *t.stepTxid()
+	info, err := s.uc.cli.GetTransactionInfoById(ctx, txid)
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	switch {
+	case info.GetBlockNumber() > 0:
+		t.RawTx, t.ExpiresAt, t.Attempts, t.LastError = "", nil, 0, ""
+		if !receiptSucceeded(info) {
+			s.fail(ctx, t, fmt.Sprintf("%s tx %s failed: %s", t.Status, txid, info.GetResMessage()), txid)
+			return s.update(ctx, t)
+		}
+		if t.Status == SweepStatusSweeping {
+			s.log.Sugar().Infow("swept", "task", t.ID, "address", t.Address, "token", t.Token, "amount", t.Amount, "txid", txid)
+		}
+		switch t.Status = t.nextStep(); t.Status {
+		case SweepStatusFailed:
+			t.LastError = t.Failure
+			s.log.Sugar().Errorw("sweep failed, delegated energy reclaimed", "task", t.ID, "address", t.Address, "token", t.Token, "err", t.LastError)
+		case SweepStatusSkipped:
+			t.LastError = sweepNothingLeft
+		}
+		return s.update(ctx, t)
+	case t.ExpiresAt != nil && now.After(t.ExpiresAt.Add(txExpiryMargin)):
+		s.log.Sugar().Warnw("sweep tx expired", "task", t.ID, "status", t.Status, "txid", txid)
+		*t.stepTxid() = ""
+		t.RawTx, t.ExpiresAt = "", nil
+		return s.update(ctx, t)
+	case now.Sub(t.UpdatedAt) < rebroadcastAfter:
+		return nil
+	}
+	raw, err := hex.DecodeString(t.RawTx)
+	if err != nil {
+		return err
+	}
+	tx := new(core.Transaction)
+	if err := proto.Unmarshal(raw, tx); err != nil {
+		return err
+	}
+	// touch t so the next broadcast waits again
+	if err := s.update(ctx, t); err != nil {
+		return err
+	}
+	return s.broadcast(ctx, t, tx)
+}
+
+// retry count a failed step of t, the task fails after too many. A step whose tx is out
+// is left to settle.
+func (s *SweepUsecase) retry(ctx context.Context, t *SweepTask, cause error) {
+	s.log.Sugar().Errorw("sweep", "task", t.ID, "address", t.Address, "token", t.Token, "status", t.Status, "err", cause)
+	if t.RawTx != "" {
+		return
+	}
+	t.Attempts++
+	t.LastError = truncate(cause.Error(), 255)
+	if t.Attempts >= maxSweepAttempts {
+		s.fail(ctx, t, t.LastError, "")
+	}
+	if err := s.update(ctx, t); err != nil {
+		s.log.Sugar().Errorw("UpdateSweepTask", "task", t.ID, "err", err)
+	}
+}
+
+// fail end t for reason. Energy delegated to the address is reclaimed first, the task
+// fails once that is done.
+func (s *SweepUsecase) fail(ctx context.Context, t *SweepTask, reason, txid string) {
+	t.LastError = truncate(reason, 255)
+	s.record(ctx, AuditActionSweepFailed, t, txid)
+	s.log.Sugar().Errorw("sweep failed", "task", t.ID, "address", t.Address, "token", t.Token, "status", t.Status, "err", t.LastError)
+	if t.Status == SweepStatusSweeping && t.DelegateAmount > 0 && t.DelegateTxid != "" {
+		t.Status, t.Failure, t.Attempts = SweepStatusReclaiming, t.LastError, 0
+		return
+	}
+	t.Status = SweepStatusFailed
+}
+
+func (s *SweepUsecase) update(ctx context.Context, t *SweepTask) error {
+	t.UpdatedAt = s.now()
+	return s.repo.UpdateSweepTask(ctx, t)
+}
+
+// record write an audit log entry of a step, failing to do so does not undo the step
+func (s *SweepUsecase) record(ctx context.Context, action string, t *SweepTask, txid string) {
+	b, _ := json.Marshal(map[string]interface{}{
+		"run":             t.RunID,
+		"task":            t.ID,
+		"token":           t.Token,
+		"amount":          t.Amount,
+		"fund_amount":     t.FundAmount,
+		"delegate_amount": t.DelegateAmount,
+		"err":             t.LastError,
+	})
+	l := &AuditLog{Actor: sweepActor, Action: action, Address: t.Address, Txid: txid, Detail: string(b)}
+	if err := s.audit.CreateAuditLog(ctx, l); err != nil {
+		s.log.Sugar().Errorw("CreateAuditLog", "action", action, "address", t.Address, "txid", txid, "err", err)
+	}
+}
@@ -0,0 +1,503 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	protov1 "github.com/golang/protobuf/proto"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+type memSweepRepo struct {
+	mu    sync.Mutex
+	runs  []*SweepRun
+	tasks []*SweepTask
+}
+
+func (r *memSweepRepo) LatestSweepRun(ctx context.Context) (*SweepRun, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.runs) == 0 {
+		return nil, ErrNotFound
+	}
+	run := *r.runs[len(r.runs)-1]
+	return &run, nil
+}
+
+func (r *memSweepRepo) GetSweepRun(ctx context.Context, id int64) (*SweepRun, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id <= 0 || id > int64(len(r.runs)) {
+		return nil, ErrNotFound
+	}
+	run := *r.runs[id-1]
+	return &run, nil
+}
+
+func (r *memSweepRepo) CreateSweepRun(ctx context.Context, run *SweepRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if n := len(r.runs); n > 0 && r.runs[n-1].Status != SweepRunDone {
+		return ErrSweepRunning
+	}
+	run.ID = int64(len(r.runs) + 1)
+	c := *run
+	r.runs = append(r.runs, &c)
+	return nil
+}
+
+func (r *memSweepRepo) UpdateSweepRun(ctx context.Context, run *SweepRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	*r.runs[run.ID-1] = *run
+	return nil
+}
+
+func (r *memSweepRepo) SavePlan(ctx context.Context, run *SweepRun, tasks []*SweepTask) error {
+	r.mu.Lock()
+	for _, t := range tasks {
+		t.ID = int64(len(r.tasks) + 1)
+		c := *t
+		r.tasks = append(r.tasks, &c)
+	}
+	r.mu.Unlock()
+	return r.UpdateSweepRun(ctx, run)
+}
+
+func (r *memSweepRepo) ListSweepTasks(ctx context.Context, runID int64) ([]*SweepTask, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var list []*SweepTask
+	for _, t := range r.tasks {
+		if t.RunID == runID {
+			c := *t
+			list = append(list, &c)
+		}
+	}
+	return list, nil
+}
+
+func (r *memSweepRepo) UpdateSweepTask(ctx context.Context, t *SweepTask) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := r.tasks[t.ID-1]
+	if stored.Version != t.Version {
+		return ErrNotFound
+	}
+	t.Version++
+	*stored = *t
+	return nil
+}
+
+type listAddressRepo struct {
+	AddressRepo
+	list []*DepositAddress
+}
+
+func (r *listAddressRepo) ListDepositAddressesAfter(ctx context.Context, afterID int64, limit int) ([]*DepositAddress, error) {
+	var list []*DepositAddress
+	for _, a := range r.list {
+		if a.ID > afterID && len(list) < limit {
+			list = append(list, a)
+		}
+	}
+	return list, nil
+}
+
+// fakeSweepNode is a WalletClient holding TRX balances and building TRX transfers
+type fakeSweepNode struct {
+	api.WalletClient
+	mu        sync.Mutex
+	balances  map[string]int64
+	down      bool // fail broadcasts as if the node could not be reached
+	refused   bool // refuse broadcasts without a code
+	broadcast []*core.Transaction
+	onChain   bool // every tx asked for is included
+	reverted  bool // every tx included fails
+}
+
+func (n *fakeSweepNode) GetAccount(ctx context.Context, in *core.Account, opts ...grpc.CallOption) (*core.Account, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	balance, ok := n.balances[string(in.Address)]
+	if !ok {
+		return &core.Account{}, nil
+	}
+	return &core.Account{Address: in.Address, Balance: balance}, nil
+}
+
+func (n *fakeSweepNode) GetChainParameters(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*core.ChainParameters, error) {
+	return &core.ChainParameters{ChainParameter: []*core.ChainParameters_ChainParameter{
+		{Key: paramEnergyFee, Value: 420},
+		{Key: paramTransactionFee, Value: 1000},
+	}}, nil
+}
+
+func (n *fakeSweepNode) GetAccountResource(ctx context.Context, in *core.Account, opts ...grpc.CallOption) (*api.AccountResourceMessage, error) {
+	return &api.AccountResourceMessage{}, nil
+}
+
+func (n *fakeSweepNode) CreateTransaction2(ctx context.Context, in *core.TransferContract, opts ...grpc.CallOption) (*api.TransactionExtention, error) {
+	tx := newUnsignedTx(core.Transaction_Contract_TransferContract, in)
+	raw, _ := proto.Marshal(tx.RawData)
+	id := sha256.Sum256(raw)
+	return &api.TransactionExtention{Transaction: tx, Txid: id[:], Result: &api.Return{Result: true}}, nil
+}
+
+func (n *fakeSweepNode) BroadcastTransaction(ctx context.Context, in *core.Transaction, opts ...grpc.CallOption) (*api.Return, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.down {
+		return nil, errors.New("connection refused")
+	}
+	if n.refused {
+		return &api.Return{Message: []byte("refused")}, nil
+	}
+	n.broadcast = append(n.broadcast, in)
+	return &api.Return{Result: true}, nil
+}
+
+func (n *fakeSweepNode) GetTransactionInfoById(ctx context.Context, in *api.BytesMessage, opts ...grpc.CallOption) (*core.TransactionInfo, error) {
+	if n.onChain && n.reverted {
+		return &core.TransactionInfo{Id: in.Value, BlockNumber: 100, Result: core.TransactionInfo_FAILED,
+			Receipt: &core.ResourceReceipt{Result: core.Transaction_Result_REVERT}}, nil
+	}
+	if n.onChain {
+		return &core.TransactionInfo{Id: in.Value, BlockNumber: 100}, nil
+	}
+	return &core.TransactionInfo{}, nil
+}
+
+// swept return the SUN sent to the treasury per deposit address
+func (n *fakeSweepNode) swept(t *testing.T, treasury string) map[string]int64 {
+	t.Helper()
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	swept := make(map[string]int64)
+	for _, tx := range n.broadcast {
+		c := new(core.TransferContract)
+		if err := tx.RawData.Contract[0].Parameter.UnmarshalTo(protov1.MessageV2(c)); err != nil {
+			t.Fatal(err)
+		}
+		if string(c.ToAddress) == treasury {
+			swept[string(c.OwnerAddress)] += c.Amount
+		}
+	}
+	return swept
+}
+
+func newTestSweepUsecase(t *testing.T, node *fakeSweepNode, addrs *listAddressRepo) (*SweepUsecase, *memSweepRepo, *memAuditRepo) {
+	t.Helper()
+	master, err := hdkeychain.NewMaster(bytes.Repeat([]byte{0x5a}, 32), &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &setting.Config{HDWallet: setting.HDWallet{MasterKey: master.String()}}
+	wallet, err := NewHDWallet(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Sweep = setting.Sweep{
+		Enable:   true,
+		Treasury: newTestAddress(t).String(),
+		Batch:    2,
+		Tokens:   []setting.SweepToken{{Token: "trx", Threshold: "100"}},
+	}
+	cli := &TronCli{TronWalletCli: node, GrpcTimeout: time.Second}
+	uc := NewTrxUsecase(newMemTrxRepo(), nil, zap.NewNop(), cli, nopSigner{}, NewEventBus(), nil)
+	repo, audit := &memSweepRepo{}, &memAuditRepo{}
+	s := NewSweepUsecase(uc, wallet, repo, addrs, audit, cfg, zap.NewNop())
+	// the transfers the fake node builds expire at 1666000060000
+	now := time.UnixMilli(1666000000000)
+	s.now = func() time.Time { return now }
+
+	for i, balance := range []int64{200 * sunPerTRX, 50 * sunPerTRX, 150 * sunPerTRX} {
+		a, err := wallet.Derive(0, uint32(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		addrs.list = append(addrs.list, &DepositAddress{ID: int64(i + 1), Account: 0, Index: uint32(i), Address: a})
+		node.balances[string(mustDecode(t, a))] = balance
+	}
+	node.balances[string(mustDecode(t, cfg.Sweep.Treasury))] = 0
+	return s, repo, audit
+}
+
+func mustDecode(t *testing.T, addr string) []byte {
+	t.Helper()
+	b, err := common.DecodeCheck(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestSweep(t *testing.T) {
+	node := &fakeSweepNode{balances: make(map[string]int64)}
+	addrs := &listAddressRepo{}
+	s, repo, audit := newTestSweepUsecase(t, node, addrs)
+	ctx := context.Background()
+
+	// a dry run only plans, the address below the threshold is left alone
+	run, err := s.Start(ctx, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Start(ctx, false); !errors.Is(err, ErrSweepRunning) {
+		t.Fatalf("second run: %v", err)
+	}
+	if err := s.tick(ctx); err != nil {
+		t.Fatal(err)
+	}
+	run, tasks, err := s.GetRun(ctx, run.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Status != SweepRunDone || len(tasks) != 2 || tasks[0].Address != addrs.list[0].Address || tasks[1].Address != addrs.list[2].Address {
+		t.Fatalf("dry run %+v, tasks %+v", run, tasks)
+	}
+	for _, task := range tasks {
+		if task.Status != SweepStatusEstimated {
+			t.Fatalf("dry run task %+v", task)
+		}
+	}
+	if len(node.broadcast) != 0 {
+		t.Fatalf("dry run broadcast %d txs", len(node.broadcast))
+	}
+
+	// the first sweeps can not be broadcast, they are kept and broadcast again later
+	if run, err = s.Start(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	node.down = true
+	for i := 0; i < 2; i++ {
+		if err := s.tick(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, tasks, _ = s.GetRun(ctx, run.ID)
+	for _, task := range tasks {
+		if task.Status != SweepStatusSweeping || task.SweepTxid == "" || task.RawTx == "" || task.Attempts != 0 {
+			t.Fatalf("unbroadcast task %+v", task)
+		}
+	}
+
+	// expired without being included, the sweep is done again
+	node.down = false
+	now := time.UnixMilli(1666000060000).Add(txExpiryMargin + time.Second)
+	s.now = func() time.Time { return now }
+	if err := s.tick(ctx); err != nil {
+		t.Fatal(err)
+	}
+	_, tasks, _ = s.GetRun(ctx, run.ID)
+	if tasks[0].SweepTxid != "" || tasks[0].RawTx != "" {
+		t.Fatalf("expired task %+v", tasks[0])
+	}
+	if err := s.tick(ctx); err != nil {
+		t.Fatal(err)
+	}
+	node.onChain = true
+	if err := s.tick(ctx); err != nil {
+		t.Fatal(err)
+	}
+	run, tasks, _ = s.GetRun(ctx, run.ID)
+	if run.Status != SweepRunDone || run.FinishedAt == nil {
+		t.Fatalf("run %+v", run)
+	}
+	swept := node.swept(t, string(mustDecode(t, s.cfg.Sweep.Treasury)))
+	for _, task := range tasks {
+		if task.Status != SweepStatusSwept || task.SweepTxid == "" {
+			t.Fatalf("swept task %+v", task)
+		}
+		owner := string(mustDecode(t, task.Address))
+		// the balance less the bandwidth burned
+		if got := swept[owner]; got <= 0 || got >= node.balances[owner] || task.Amount.IntPart() != got {
+			t.Fatalf("swept %d of %d, task amount %s", got, node.balances[owner], task.Amount)
+		}
+	}
+	// one per signed sweep, the expired ones included
+	if len(audit.logs) != 4 {
+		t.Fatalf("audit logs %d", len(audit.logs))
+	}
+	for _, l := range audit.logs {
+		if l.Actor != sweepActor || l.Action != AuditActionSweep || l.Txid == "" {
+			t.Fatalf("audit log %+v", l)
+		}
+	}
+	if _, err := s.Start(ctx, false); err != nil {
+		t.Fatalf("run after a finished one: %v", err)
+	}
+	if len(repo.runs) != 3 {
+		t.Fatalf("runs %d", len(repo.runs))
+	}
+}
+
+func TestSweepFailureReclaimsEnergy(t *testing.T) {
+	node := &fakeSweepNode{balances: make(map[string]int64), onChain: true, reverted: true}
+	s, repo, audit := newTestSweepUsecase(t, node, &listAddressRepo{})
+	ctx := context.Background()
+	newTask := func(status SweepStatus, delegateTxid string) *SweepTask {
+		task := &SweepTask{ID: int64(len(repo.tasks) + 1), Token: "USDT", Contract: "contract", Status: status,
+			DelegateAmount: sunPerTRX, DelegateTxid: delegateTxid}
+		c := *task
+		repo.tasks = append(repo.tasks, &c)
+		return task
+	}
+
+	// the sweep tx fails on chain after the energy was delegated
+	reverted := newTask(SweepStatusSweeping, "01")
+	reverted.SweepTxid, reverted.RawTx = "02", "00"
+	if err := s.settle(ctx, reverted); err != nil {
+		t.Fatal(err)
+	}
+	if reverted.Status != SweepStatusReclaiming || reverted.Failure == "" || reverted.Finished() {
+		t.Fatalf("reverted task %+v", reverted)
+	}
+
+	// the sweep can not be sent
+	stuck := newTask(SweepStatusSweeping, "01")
+	for i := 0; i < maxSweepAttempts; i++ {
+		s.retry(ctx, stuck, errors.New("node down"))
+	}
+	if stuck.Status != SweepStatusReclaiming || stuck.Failure != "node down" || stuck.Attempts != 0 {
+		t.Fatalf("stuck task %+v", stuck)
+	}
+
+	// nothing was delegated yet, nothing to reclaim
+	undelegated := newTask(SweepStatusDelegating, "")
+	for i := 0; i < maxSweepAttempts; i++ {
+		s.retry(ctx, undelegated, errors.New("node down"))
+	}
+	if undelegated.Status != SweepStatusFailed || undelegated.Failure != "" {
+		t.Fatalf("undelegated task %+v", undelegated)
+	}
+
+	// once the energy is back the task ends as failed
+	node.reverted = false
+	reverted.ReclaimTxid, reverted.RawTx = "03", "00"
+	if err := s.settle(ctx, reverted); err != nil {
+		t.Fatal(err)
+	}
+	if reverted.Status != SweepStatusFailed || reverted.LastError != reverted.Failure || reverted.RawTx != "" {
+		t.Fatalf("reclaimed task %+v", reverted)
+	}
+	if len(audit.logs) != 3 {
+		t.Fatalf("audit logs %d", len(audit.logs))
+	}
+	for _, l := range audit.logs {
+		if l.Action != AuditActionSweepFailed {
+			t.Fatalf("audit log %+v", l)
+		}
+	}
+}
+
+func TestSweepNothingLeftReclaimsEnergy(t *testing.T) {
+	node := &fakeSweepNode{balances: make(map[string]int64), onChain: true}
+	s, repo, _ := newTestSweepUsecase(t, node, &listAddressRepo{})
+	ctx := context.Background()
+	task := &SweepTask{ID: 1, Address: newTestAddress(t).String(), Token: TokenTRX, Status: SweepStatusSweeping,
+		DelegateAmount: sunPerTRX, DelegateTxid: "01"}
+	c := *task
+	repo.tasks = append(repo.tasks, &c)
+
+	// the balance is gone by the time of the sweep, the delegated energy is reclaimed first
+	if err := s.sendSweep(ctx, task, s.cfg.Sweep); err != nil {
+		t.Fatal(err)
+	}
+	if task.Status != SweepStatusReclaiming || !task.Skip || len(node.broadcast) != 0 {
+		t.Fatalf("empty task %+v", task)
+	}
+	task.ReclaimTxid, task.RawTx = "03", "00"
+	if err := s.settle(ctx, task); err != nil {
+		t.Fatal(err)
+	}
+	if task.Status != SweepStatusSkipped || task.LastError != sweepNothingLeft {
+		t.Fatalf("reclaimed task %+v", task)
+	}
+}
+
+func TestSweepBroadcastRefusedWithoutCode(t *testing.T) {
+	node := &fakeSweepNode{balances: make(map[string]int64), refused: true}
+	s, repo, _ := newTestSweepUsecase(t, node, &listAddressRepo{})
+	task := &SweepTask{ID: 1, Token: TokenTRX, Status: SweepStatusSweeping, SweepTxid: "01", RawTx: "00"}
+	c := *task
+	repo.tasks = append(repo.tasks, &c)
+
+	// the node answered SUCCESS with result false, the step is done again
+	err := s.broadcast(context.Background(), task, newUnsignedTx(core.Transaction_Contract_TransferContract, &core.TransferContract{}))
+	var be *BroadcastError
+	if !errors.As(err, &be) || be.Code != api.Return_OTHER_ERROR {
+		t.Fatalf("err %v", err)
+	}
+	if task.SweepTxid != "" || task.RawTx != "" {
+		t.Fatalf("refused task %+v", task)
+	}
+}
+
+func TestSweepTaskSteps(t *testing.T) {
+	token := &SweepTask{Status: SweepStatusPlanned, Contract: "contract", FundAmount: 1, DelegateAmount: 1}
+	var steps []SweepStatus
+	for !token.Finished() {
+		token.Status = token.nextStep()
+		steps = append(steps, token.Status)
+	}
+	want := []SweepStatus{SweepStatusFunding, SweepStatusDelegating, SweepStatusSweeping, SweepStatusReclaiming, SweepStatusSwept}
+	if len(steps) != len(want) {
+		t.Fatalf("steps %v", steps)
+	}
+	for i := range want {
+		if steps[i] != want[i] {
+			t.Fatalf("steps %v", steps)
+		}
+	}
+	trx := &SweepTask{Status: SweepStatusPlanned}
+	if next := trx.nextStep(); next != SweepStatusSweeping {
+		t.Fatalf("TRX task goes to %s", next)
+	}
+}
+
+func TestSweepDue(t *testing.T) {
+	day := time.Date(2022, 10, 17, 0, 0, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+	daily := setting.Sweep{Times: []string{"03:00", "15:00"}, Interval: 60}
+	for _, c := range []struct {
+		name      string
+		cfg       setting.Sweep
+		last, now time.Time
+		want      bool
+	}{
+		{"before the first time", daily, at(-9, 0), at(2, 59), false},
+		{"at a time", daily, at(-9, 0), at(3, 0), true},
+		{"already ran", daily, at(3, 0), at(3, 30), false},
+		{"caught up within an hour", daily, at(3, 0), at(15, 59), true},
+		{"too late to catch up", daily, at(3, 0), at(16, 0), false},
+		{"after midnight", daily, at(-9, 0), at(24+3, 10), true},
+		{"interval", setting.Sweep{Interval: 60}, at(3, 0), at(3, 1), true},
+		{"interval not elapsed", setting.Sweep{Interval: 60}, at(3, 0), at(3, 0).Add(59 * time.Second), false},
+		{"manual only", setting.Sweep{}, time.Time{}, at(3, 0), false},
+	} {
+		got, err := sweepDue(c.cfg, c.last, c.now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.want {
+			t.Errorf("%s: got %v", c.name, got)
+		}
+	}
+	if _, err := sweepDue(setting.Sweep{Times: []string{"3pm"}}, time.Time{}, day); err == nil {
+		t.Error("invalid time accepted")
+	}
+}
@@ -0,0 +1,179 @@
+package biz
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"go.uber.org/zap"
+)
+
+// defaultConfirmations is used when neither the token nor the tracker configures a threshold
+const defaultConfirmations = 6
+
+var txStatusRank = map[TxStatus]int{
+	TxStatusPending:    0,
+	TxStatusConfirmed:  1,
+	TxStatusSolidified: 2,
+	TxStatusFailed:     2,
+	TxStatusReverted:   2,
+}
+
+// ConfirmationTracker moves recorded transactions through pending, confirmed and solidified
+type ConfirmationTracker struct {
+	cli  *TronCli
+	repo TrxRepo
+	bus  *EventBus
+	cfg  *setting.Config
+	log  *zap.Logger
+}
+
+// NewConfirmationTracker new a confirmation tracker.
+func NewConfirmationTracker(cli *TronCli, repo TrxRepo, bus *EventBus, cfg *setting.Config, logger *zap.Logger) *ConfirmationTracker {
+	return &ConfirmationTracker{cli: cli, repo: repo, bus: bus, cfg: cfg, log: logger}
+}
+
+// Enabled report whether the tracker is switched on in config
+func (k *ConfirmationTracker) Enabled() bool {
+	return k.cfg.Tracker.Enable
+}
+
+// Run track transactions until ctx is done
+func (k *ConfirmationTracker) Run(ctx context.Context) error {
+	interval := time.Duration(k.cfg.Tracker.Interval) * time.Second
+	if interval <= 0 {
+		interval = 3 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := k.track(ctx); err != nil {
+			k.log.Sugar().Errorw("ConfirmationTracker", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// track update every transaction that is not final yet
+func (k *ConfirmationTracker) track(ctx context.Context) error {
+	head, err := k.cli.GetNowBlock(ctx)
+	if err != nil {
+		return err
+	}
+	headNum := head.GetBlockHeader().GetRawData().GetNumber()
+	solidNum, err := k.cli.GetSolidBlockNum(ctx)
+	if err != nil {
+		return err
+	}
+
+	batch := k.cfg.Tracker.Batch
+	if batch <= 0 {
+		batch = 100
+	}
+	var afterID int64
+	for ctx.Err() == nil {
+		txs, err := k.repo.ListUnfinalizedTxs(ctx, afterID, batch)
+		if err != nil {
+			return err
+		}
+		for _, tx := range txs {
+			if err := k.update(ctx, tx, headNum, solidNum); err != nil {
+				return err
+			}
+			afterID = tx.ID
+		}
+		if len(txs) < batch {
+			break
+		}
+	}
+	return nil
+}
+
+// update work out the current status of tx and persist it when it moved forward
+func (k *ConfirmationTracker) update(ctx context.Context, tx *Tx, headNum, solidNum int64) error {
+	from := tx.Status
+	next := tx.Status
+	if tx.BlockNum == 0 {
+		// withdrawals are recorded when they are broadcast, before they are included
+		info, err := k.cli.GetTransactionInfoById(ctx, tx.Txid)
+		if err != nil {
+			return err
+		}
+		switch {
+		case info.GetBlockNumber() > 0:
+			tx.BlockNum = info.GetBlockNumber()
+			blockTime := time.UnixMilli(info.GetBlockTimeStamp())
+			tx.BlockTime = &blockTime
+			if !receiptSucceeded(info) {
+				next = TxStatusFailed
+			}
+		case tx.ExpiresAt != nil && time.Now().After(tx.ExpiresAt.Add(txExpiryMargin)):
+			// expired in the mempool, it can no longer be included
+			next = TxStatusFailed
+		default:
+			return nil
+		}
+	}
+
+	if tx.BlockNum > 0 {
+		tx.Confirmations = headNum - tx.BlockNum + 1
+	}
+	if tx.Confirmations < 0 {
+		tx.Confirmations = 0
+	}
+	switch {
+	case next == TxStatusFailed:
+	case tx.BlockNum <= solidNum:
+		// confirm on a solidity node that the tx really is in the solidified block we recorded
+		info, err := k.cli.GetSolidTransactionInfoById(ctx, tx.Txid)
+		if err != nil {
+			return err
+		}
+		if info.GetBlockNumber() != tx.BlockNum {
+			k.log.Sugar().Warnw("ConfirmationTracker", "txid", tx.Txid, "block", tx.BlockNum, "solidified_in", info.GetBlockNumber())
+			return nil
+		}
+		next = TxStatusSolidified
+	case tx.Confirmations >= k.threshold(tx.Token):
+		next = TxStatusConfirmed
+	}
+	if txStatusRank[next] <= txStatusRank[from] {
+		return nil
+	}
+
+	tx.Status = next
+	event, err := k.repo.UpdateTxStatus(ctx, tx, from)
+	if err != nil {
+		return err
+	}
+	k.log.Sugar().Infow("ConfirmationTracker", "txid", tx.Txid, "token", tx.Token, "from", from, "to", next, "confirmations", tx.Confirmations)
+	k.bus.Publish(event)
+	return nil
+}
+
+// threshold return the confirmations token needs before it is confirmed
+func (k *ConfirmationTracker) threshold(token string) int64 {
+	for symbol, t := range k.cfg.TokenList {
+		if strings.ToUpper(symbol) == token && t.Confirmations > 0 {
+			return t.Confirmations
+		}
+	}
+	if k.cfg.Tracker.Confirmations > 0 {
+		return k.cfg.Tracker.Confirmations
+	}
+	return defaultConfirmations
+}
+
+func receiptSucceeded(info *core.TransactionInfo) bool {
+	if info.GetResult() != core.TransactionInfo_SUCESS {
+		return false
+	}
+	ret := info.GetReceipt().GetResult()
+	return ret == core.Transaction_Result_DEFAULT || ret == core.Transaction_Result_SUCCESS
+}
@@ -0,0 +1,159 @@
+package biz
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+func TestConfirmationTracker(t *testing.T) {
+	deposit, hot := newTestAddress(t), newTestAddress(t)
+	usdt := newTestAddress(t)
+
+	chain := newFakeChain()
+	repo := newMemTrxRepo()
+	bus := NewEventBus()
+	var got []string
+	unsubscribe := bus.Subscribe(func(e *TxEvent) {
+		got = append(got, e.Tx.Token+":"+string(e.Status))
+	})
+	defer unsubscribe()
+
+	cfg := &setting.Config{TokenList: map[string]setting.Token{
+		"usdt": {ContractAddr: usdt.String(), Decimal: 6, Confirmations: 3},
+	}}
+	cfg.Tracker.Confirmations = 1
+	cli := &TronCli{TronWalletCli: chain, GrpcTimeout: time.Second}
+	tracker := NewConfirmationTracker(cli, repo, bus, cfg, zap.NewNop())
+	track := func() {
+		t.Helper()
+		if err := tracker.track(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// a USDT deposit recorded by the scanner at block 1
+	trc20Tx, trc20Info := newTestTRC20Transfer(t, usdt, hot, deposit, 5)
+	chain.addBlock([]*core.Transaction{trc20Tx}, []*core.TransactionInfo{trc20Info})
+	events, _ := repo.SaveBlock(context.Background(), &ScanCheckpoint{Name: depositScanner, BlockNum: 1}, []*Tx{{
+		Txid: hex.EncodeToString(trc20Info.Id), BlockNum: 1, Direction: TxDirectionIn, Token: "USDT",
+		From: hot.String(), To: deposit.String(), Amount: decimal.NewFromInt(5), Status: TxStatusPending,
+	}})
+	bus.Publish(events...)
+
+	// a TRX withdrawal recorded on broadcast, before it is included
+	withdrawal := newTestContractTx(t, core.Transaction_Contract_TransferContract, &core.TransferContract{
+		OwnerAddress: hot.Bytes(), ToAddress: deposit.Bytes(), Amount: 3,
+	})
+	id, _ := txHash(withdrawal)
+	event, err := repo.CreateTx(context.Background(), &Tx{
+		Txid: hex.EncodeToString(id), Direction: TxDirectionOut, Token: TokenTRX,
+		From: hot.String(), To: deposit.String(), Amount: decimal.NewFromInt(3), Status: TxStatusPending,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bus.Publish(event)
+
+	track()
+	chain.addBlock([]*core.Transaction{withdrawal}, nil) // block 2
+	track()
+	chain.addBlock(nil, nil) // block 3, USDT has 3 confirmations
+	track()
+	chain.setSolid(2)
+	track()
+	track()
+
+	want := []string{
+		"USDT:pending",
+		"TRX:pending",
+		"TRX:confirmed",
+		"USDT:confirmed",
+		"USDT:solidified",
+		"TRX:solidified",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("events %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("events %v, want %v", got, want)
+		}
+	}
+	for _, tx := range repo.txs {
+		if tx.Status != TxStatusSolidified || tx.BlockNum == 0 {
+			t.Errorf("tx %s status %s at block %d", tx.Token, tx.Status, tx.BlockNum)
+		}
+	}
+}
+
+func TestConfirmationTrackerFailedWithdrawal(t *testing.T) {
+	hot, to := newTestAddress(t), newTestAddress(t)
+	chain := newFakeChain()
+	repo := newMemTrxRepo()
+	cfg := &setting.Config{}
+	cli := &TronCli{TronWalletCli: chain, GrpcTimeout: time.Second}
+	tracker := NewConfirmationTracker(cli, repo, NewEventBus(), cfg, zap.NewNop())
+
+	tx := newTestContractTx(t, core.Transaction_Contract_TransferContract, &core.TransferContract{
+		OwnerAddress: hot.Bytes(), ToAddress: to.Bytes(), Amount: 3,
+	})
+	tx.Ret[0].ContractRet = core.Transaction_Result_REVERT
+	id, _ := txHash(tx)
+	if _, err := repo.CreateTx(context.Background(), &Tx{Txid: hex.EncodeToString(id), Token: TokenTRX, Status: TxStatusPending}); err != nil {
+		t.Fatal(err)
+	}
+	chain.addBlock([]*core.Transaction{tx}, nil)
+	if err := tracker.track(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	for _, tx := range repo.txs {
+		if tx.Status != TxStatusFailed {
+			t.Fatalf("status %s, want failed", tx.Status)
+		}
+	}
+}
+
+func TestConfirmationTrackerExpiredWithdrawal(t *testing.T) {
+	chain := newFakeChain()
+	repo := newMemTrxRepo()
+	bus := NewEventBus()
+	var got []TxStatus
+	unsubscribe := bus.Subscribe(func(e *TxEvent) { got = append(got, e.Status) })
+	defer unsubscribe()
+	cli := &TronCli{TronWalletCli: chain, GrpcTimeout: time.Second}
+	tracker := NewConfirmationTracker(cli, repo, bus, &setting.Config{}, zap.NewNop())
+
+	// never included: one expired past the margin, one that may still be
+	expired, live := time.Now().Add(-txExpiryMargin-time.Second), time.Now()
+	for i, expiresAt := range []*time.Time{&expired, &live} {
+		if _, err := repo.CreateTx(context.Background(), &Tx{
+			Txid: hex.EncodeToString([]byte{byte(i)}), Direction: TxDirectionOut, Token: TokenTRX, Status: TxStatusPending, ExpiresAt: expiresAt,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if err := tracker.track(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(got) != 1 || got[0] != TxStatusFailed {
+		t.Fatalf("events %v", got)
+	}
+	for _, tx := range repo.txs {
+		want := TxStatusPending
+		if tx.Txid == "00" {
+			want = TxStatusFailed
+		}
+		if tx.Status != want || tx.BlockNum != 0 || tx.Confirmations != 0 {
+			t.Errorf("tx %s status %s at block %d, %d confirmations", tx.Txid, tx.Status, tx.BlockNum, tx.Confirmations)
+		}
+	}
+}
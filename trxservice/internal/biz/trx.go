@@ -2,31 +2,426 @@ package biz
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
 	"math/big"
+	"sync"
+	"time"
 
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/leondevpt/wallet/trxservice/pkg/errcode"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+
+	defaultTxRebuilds     = 2
+	defaultTxRebroadcasts = 3
+)
+
+// ErrInsufficientBalance is returned when the sender can not cover the transfer amount
+var ErrInsufficientBalance = errors.New("insufficient balance")
+
+// BroadcastUnknownError is a signed tx whose broadcast got no answer, e.g. the deadline hit
+// after the node received it, or one rejected as expired before that could be proved. It may
+// still be included, so it is reconciled by Txid rather than sent again as a new tx.
+type BroadcastUnknownError struct {
+	Txid string
+	Err  error
+}
+
+func (e *BroadcastUnknownError) Error() string {
+	return fmt.Sprintf("broadcast of %s unknown: %v", e.Txid, e.Err)
+}
+
+func (e *BroadcastUnknownError) Unwrap() error {
+	return e.Err
+}
+
+// TxFilter selects recorded transactions, zero fields do not filter
+type TxFilter struct {
+	Address   string
+	Token     string
+	QueryType TxDirection // relative to Address, 0 for both
+	StartTime time.Time
+	EndTime   time.Time
+	PageNum   int
+	PageSize  int
+	// Cursor is the ID of the last tx of the previous page. When set PageNum is
+	// ignored and the total is not counted.
+	Cursor int64
+}
+
 // TrxRepo is a trx repo.
 type TrxRepo interface {
-	ListTransactions(ctx context.Context, addr, token string, pageNum, pageSize, queryType int) ([]interface{}, int, error)
+	// ListTransactions return txs matching f, newest first, and the total when paging by number
+	ListTransactions(ctx context.Context, f *TxFilter) ([]*Tx, int64, error)
+	GetCheckpoint(ctx context.Context, name string) (*ScanCheckpoint, error)
+	// SaveBlock store txs of a block, remember the block hash and move the checkpoint atomically.
+	// Txs already stored are skipped, reverted ones are revived. It returns the pending events.
+	SaveBlock(ctx context.Context, cp *ScanCheckpoint, txs []*Tx) ([]*TxEvent, error)
+	// ListScanBlocks return the remembered blocks of scanner from num on, newest first
+	ListScanBlocks(ctx context.Context, scanner string, from int64) ([]*ScanBlock, error)
+	// PruneScanBlocks forget blocks of scanner below num
+	PruneScanBlocks(ctx context.Context, scanner string, below int64) error
+	// Rollback move the checkpoint back to cp after a reorg. Unfinalized deposits above it are
+	// reverted and withdrawals are reset to be looked up again. It returns the events.
+	Rollback(ctx context.Context, cp *ScanCheckpoint) ([]*TxEvent, error)
+	// CreateTx store a broadcast withdrawal with its pending event
+	CreateTx(ctx context.Context, tx *Tx) (*TxEvent, error)
+	// ListUnfinalizedTxs return pending and confirmed txs with ID greater than afterID
+	ListUnfinalizedTxs(ctx context.Context, afterID int64, limit int) ([]*Tx, error)
+	// ListEvents sequence committed events and return those with Seq greater than afterSeq, in order and with their Tx
+	ListEvents(ctx context.Context, afterSeq int64, limit int) ([]*TxEvent, error)
+	// LastEventSeq return the Seq of the newest sequenced event, 0 if there is none
+	LastEventSeq(ctx context.Context) (int64, error)
+	// UpdateTxStatus persist tx if its status is still from and record the event.
+	// It returns ErrNotFound when the status was changed by someone else.
+	UpdateTxStatus(ctx context.Context, tx *Tx, from TxStatus) (*TxEvent, error)
 }
 
 type TrxUsecase struct {
-	repo TrxRepo
-	log  *zap.Logger
-	cli  *TronCli
+	repo   TrxRepo
+	cache  BalanceCache // nil without one
+	log    *zap.Logger
+	cli    *TronCli
+	signer Signer
+	bus    *EventBus
+	cfg    *setting.Config
+
+	decimals sync.Map // contract => int32 read by TokenDecimals, a contract never changes them
 }
 
 // NewTrxUsecase new a Trx usecase.
-func NewTrxUsecase(repo TrxRepo, logger *zap.Logger, cli *TronCli) *TrxUsecase {
-	return &TrxUsecase{repo: repo, log: logger, cli: cli}
+func NewTrxUsecase(repo TrxRepo, cache BalanceCache, logger *zap.Logger, cli *TronCli, signer Signer, bus *EventBus, cfg *setting.Config) *TrxUsecase {
+	return &TrxUsecase{repo: repo, cache: cache, log: logger, cli: cli, signer: signer, bus: bus, cfg: cfg}
+}
+
+// ListTransactions return recorded transfers matching f
+func (t *TrxUsecase) ListTransactions(ctx context.Context, f *TxFilter) ([]*Tx, int64, error) {
+	if f.PageSize <= 0 || f.PageSize > maxPageSize {
+		f.PageSize = defaultPageSize
+	}
+	if f.PageNum <= 0 {
+		f.PageNum = 1
+	}
+	return t.repo.ListTransactions(ctx, f)
+}
+
+// GetBalance return the TRX balance of addr in SUN, as of the latest solidified block when
+// confirmed
+func (t *TrxUsecase) GetBalance(ctx context.Context, addr string, confirmed bool) (*big.Int, error) {
+	return t.cachedBalance(ctx, addr, TokenTRX, confirmed, func(ctx context.Context) (*big.Int, error) {
+		if !confirmed {
+			return t.cli.GetBalance(ctx, addr)
+		}
+		balance, activated, err := t.cli.GetSolidAccountBalance(ctx, addr)
+		if err != nil {
+			return nil, err
+		}
+		if !activated {
+			return nil, fmt.Errorf("account not found")
+		}
+		return big.NewInt(balance), nil
+	})
+}
+
+// GetTRC20TokenBalance return the balance of addr in contractAddr, as of the latest
+// solidified block when confirmed
+func (t *TrxUsecase) GetTRC20TokenBalance(ctx context.Context, addr, contractAddr string, confirmed bool) (*big.Int, error) {
+	return t.cachedBalance(ctx, addr, contractAddr, confirmed, func(ctx context.Context) (*big.Int, error) {
+		if confirmed {
+			return t.cli.GetSolidTRC20Balance(ctx, addr, contractAddr)
+		}
+		return t.cli.GetTRC20TokenBalance(ctx, addr, contractAddr)
+	})
+}
+
+// cachedBalance read a balance through the cache, keyed by the head block of the pool it
+// is read from. Without a cache or a probed head it is read from the nodes.
+func (t *TrxUsecase) cachedBalance(ctx context.Context, addr, token string, confirmed bool,
+	load func(ctx context.Context) (*big.Int, error)) (*big.Int, error) {
+	height := t.cli.HeadHeight()
+	if confirmed {
+		height = t.cli.SolidHeight()
+	}
+	if t.cache == nil || height == 0 {
+		return load(ctx)
+	}
+	return t.cache.Balance(ctx, BalanceKey{Address: addr, Token: token, Height: height, Confirmed: confirmed}, load)
+}
+
+// GetTRC20Info return the name, symbol and decimals the TRC20 contract reports
+func (t *TrxUsecase) GetTRC20Info(ctx context.Context, contract string) (*TokenInfo, error) {
+	load := func(ctx context.Context) (*TokenInfo, error) {
+		name, err := t.cli.TRC20GetName(ctx, contract)
+		if err != nil {
+			return nil, err
+		}
+		symbol, err := t.cli.TRC20GetSymbol(ctx, contract)
+		if err != nil {
+			return nil, err
+		}
+		decimals, err := t.cli.TRC20GetDecimals(ctx, contract)
+		if err != nil {
+			return nil, err
+		}
+		// uint8 in the TRC20 standard
+		if !decimals.IsInt64() || decimals.Int64() < 0 || decimals.Int64() > math.MaxUint8 {
+			return nil, fmt.Errorf("contract %s reports %s decimals", contract, decimals)
+		}
+		return &TokenInfo{Name: name, Symbol: symbol, Decimals: int32(decimals.Int64())}, nil
+	}
+	if t.cache == nil {
+		return load(ctx)
+	}
+	return t.cache.TokenInfo(ctx, contract, load)
+}
+
+// TokenDecimals return the decimals of a tokenList entry, read from its contract once when
+// the entry leaves them 0
+func (t *TrxUsecase) TokenDecimals(ctx context.Context, token setting.Token) (int32, error) {
+	if token.Decimal > 0 {
+		return int32(token.Decimal), nil
+	}
+	if d, ok := t.decimals.Load(token.ContractAddr); ok {
+		return d.(int32), nil
+	}
+	info, err := t.GetTRC20Info(ctx, token.ContractAddr)
+	if err != nil {
+		return 0, err
+	}
+	t.decimals.Store(token.ContractAddr, info.Decimals)
+	return info.Decimals, nil
+}
+
+// TransferTrx build, sign and broadcast a TRX transfer, amount in SUN. When the outcome of
+// the broadcast is unknown the transfer is recorded and returned with a BroadcastUnknownError.
+func (t *TrxUsecase) TransferTrx(ctx context.Context, from, to string, amount int64) (*api.TransactionExtention, error) {
+	tx, err := t.signAndBroadcast(ctx, func() (*api.TransactionExtention, error) {
+		return t.buildTransfer(ctx, from, to, "", big.NewInt(amount), 0, 0)
+	})
+	var unknown *BroadcastUnknownError
+	if err != nil && !errors.As(err, &unknown) {
+		return nil, err
+	}
+	t.recordWithdrawal(ctx, tx, &Tx{Token: TokenTRX, From: from, To: to, Amount: decimal.NewFromInt(amount)})
+	return tx, err
+}
+
+// TransferTRC20 build, sign and broadcast a TRC20 transfer of token, amount in the token's smallest unit.
+// When feeLimit is 0 it is estimated, and the transfer is refused if that is above maxFeeLimit.
+func (t *TrxUsecase) TransferTRC20(ctx context.Context, token, from, to, contractAddr string, amount *big.Int, feeLimit, maxFeeLimit int64) (*api.TransactionExtention, error) {
+	tx, err := t.signAndBroadcast(ctx, func() (*api.TransactionExtention, error) {
+		return t.buildTransfer(ctx, from, to, contractAddr, amount, feeLimit, maxFeeLimit)
+	})
+	var unknown *BroadcastUnknownError
+	if err != nil && !errors.As(err, &unknown) {
+		return nil, err
+	}
+	t.recordWithdrawal(ctx, tx, &Tx{Token: token, Contract: contractAddr, From: from, To: to, Amount: decimal.NewFromBigInt(amount, 0)})
+	return tx, err
+}
+
+// buildTransfer check the balance of from and build an unsigned transfer valid for the
+// configured expiration, contractAddr is empty for TRX. Fee limits apply to TRC20 only,
+// see TransferTRC20.
+func (t *TrxUsecase) buildTransfer(ctx context.Context, from, to, contractAddr string, amount *big.Int, feeLimit, maxFeeLimit int64) (*api.TransactionExtention, error) {
+	if contractAddr == "" {
+		balance, err := t.cli.GetBalance(ctx, from)
+		if err != nil {
+			return nil, err
+		}
+		if balance.Cmp(amount) < 0 {
+			return nil, ErrInsufficientBalance
+		}
+		return t.withExpiration(t.cli.Transfer(ctx, from, to, amount.Int64()))
+	}
+
+	balance, err := t.cli.GetTRC20TokenBalance(ctx, from, contractAddr)
+	if err != nil {
+		return nil, err
+	}
+	if balance.Cmp(amount) < 0 {
+		return nil, ErrInsufficientBalance
+	}
+	if feeLimit <= 0 {
+		est, err := t.EstimateFee(ctx, from, to, contractAddr, amount)
+		if err != nil {
+			return nil, err
+		}
+		if maxFeeLimit > 0 && est.FeeLimit > maxFeeLimit {
+			return nil, fmt.Errorf("%w: %d > %d", ErrFeeLimitExceeded, est.FeeLimit, maxFeeLimit)
+		}
+		feeLimit = est.FeeLimit
+	}
+	return t.withExpiration(t.cli.TRC20Send(ctx, from, to, contractAddr, amount, feeLimit))
+}
+
+// withExpiration extend the expiration of a tx just built to the configured one. A
+// configured expiration shorter than the node's leaves the tx as it is.
+func (t *TrxUsecase) withExpiration(tx *api.TransactionExtention, err error) (*api.TransactionExtention, error) {
+	if err != nil || t.cfg == nil || t.cfg.Transaction.Expiration <= 0 {
+		return tx, err
+	}
+	expiration := time.Duration(t.cfg.Transaction.Expiration) * time.Second
+	if max := maxTxExpiration - txExpiryMargin; expiration > max {
+		expiration = max
+	}
+	at := time.Now().Add(expiration).Truncate(time.Millisecond)
+	if at.UnixMilli() <= tx.Transaction.GetRawData().GetExpiration() {
+		return tx, nil
+	}
+	if err := extendExpiration(tx, at); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// extendExpiration move the expiration of an unsigned tx to at and update its txid, e.g.
+// for signers that take longer than the minute a node allows
+func extendExpiration(tx *api.TransactionExtention, at time.Time) error {
+	if at.Sub(time.Now()) > maxTxExpiration {
+		return fmt.Errorf("expiration %s is more than %s away", at, maxTxExpiration)
+	}
+	tx.Transaction.GetRawData().Expiration = at.UnixMilli()
+	txid, err := txHash(tx.Transaction)
+	if err != nil {
+		return err
+	}
+	tx.Txid = txid
+	return nil
+}
+
+// signAndBroadcast sign and broadcast the tx returned by build. A tx a busy node does not
+// take is sent again as it is, see broadcast. A tx the node rejects as expired is built and
+// signed again only once a solidified block is past its expiration and it is not on chain:
+// the node may be lagging, or have accepted it before through another attempt. A broadcast
+// without an answer, or an expiry that can not be proved yet, returns the tx with a
+// BroadcastUnknownError. Without a signer nothing is built.
+func (t *TrxUsecase) signAndBroadcast(ctx context.Context, build func() (*api.TransactionExtention, error)) (*api.TransactionExtention, error) {
+	if _, ok := t.signer.(noSigner); ok {
+		return nil, ErrSignerNotConfigured
+	}
+	for rebuilds := 0; ; rebuilds++ {
+		tx, err := build()
+		if err != nil {
+			return nil, err
+		}
+		signed, err := t.signer.Sign(ctx, tx.Transaction)
+		if err != nil {
+			return nil, err
+		}
+		tx.Transaction = signed
+
+		ret, err := t.broadcast(ctx, tx.Transaction)
+		if err == nil || ret.GetCode() == api.Return_DUP_TRANSACTION_ERROR {
+			return tx, nil
+		}
+		if ret == nil {
+			// no answer, the node may have taken it
+			return tx, &BroadcastUnknownError{Txid: hex.EncodeToString(tx.Txid), Err: err}
+		}
+		var be *BroadcastError
+		if !errors.As(err, &be) || be.Code != api.Return_TRANSACTION_EXPIRATION_ERROR {
+			return nil, err
+		}
+		txid := hex.EncodeToString(tx.Txid)
+		solid, lerr := t.cli.SolidBlockTime(ctx)
+		var info *core.TransactionInfo
+		if lerr == nil {
+			info, lerr = t.cli.GetTransactionInfoById(ctx, txid)
+		}
+		switch {
+		case lerr == nil && info.GetBlockNumber() > 0:
+			return tx, nil
+		case lerr != nil || !solid.After(time.UnixMilli(tx.Transaction.GetRawData().GetExpiration())):
+			// a new tx could pay twice, this one is tracked until it is included or expires
+			return tx, &BroadcastUnknownError{Txid: txid, Err: err}
+		case rebuilds >= t.txRebuilds():
+			return nil, err
+		}
+		t.log.Sugar().Warnw("tx expired, building it again", "txid", txid, "code", ret.GetCode(), "rebuilds", rebuilds+1)
+	}
+}
+
+// broadcast send tx, again with backoff while the node answers it is busy, short of peers
+// or does not know the block tx references, the next call going to another node of the
+// pool. A signed tx is included at most once, so sending it again can not pay twice.
+func (t *TrxUsecase) broadcast(ctx context.Context, tx *core.Transaction) (*api.Return, error) {
+	var tron setting.Tron
+	if t.cfg != nil {
+		tron = t.cfg.Tron
+	}
+	for attempt := 0; ; attempt++ {
+		ret, err := t.cli.Broadcast(ctx, tx)
+		if err == nil || ret == nil || attempt >= t.txRebroadcasts() {
+			return ret, err
+		}
+		// an expired tx is built again by signAndBroadcast instead, while a node behind or on
+		// another fork may not know the referenced block that others do
+		var be *BroadcastError
+		if !errors.As(err, &be) {
+			return ret, err
+		}
+		class := errcode.FromBroadcastCode(be.Code)
+		if be.Code != api.Return_TAPOS_ERROR && (class == errcode.BroadcastExpired || !class.Retryable()) {
+			return ret, err
+		}
+		wait := retryBackoff(tron, attempt)
+		t.log.Sugar().Warnw("tx not accepted, broadcasting it again", "code", ret.GetCode(), "attempt", attempt+1, "wait", wait)
+		select {
+		case <-ctx.Done():
+			return ret, err
+		case <-time.After(wait):
+		}
+	}
+}
+
+// txRebroadcasts return how many times broadcast sends a tx a busy node did not take again
+func (t *TrxUsecase) txRebroadcasts() int {
+	if t.cfg == nil || t.cfg.Transaction.Rebroadcasts == 0 {
+		return defaultTxRebroadcasts
+	}
+	if t.cfg.Transaction.Rebroadcasts < 0 {
+		return 0
+	}
+	return t.cfg.Transaction.Rebroadcasts
 }
 
-func (t *TrxUsecase) GetBalance(ctx context.Context, addr string) (*big.Int, error) {
-	return t.cli.GetBalance(ctx, addr)
+// txRebuilds return how many times signAndBroadcast builds a stale tx again
+func (t *TrxUsecase) txRebuilds() int {
+	if t.cfg == nil || t.cfg.Transaction.Rebuilds == 0 {
+		return defaultTxRebuilds
+	}
+	if t.cfg.Transaction.Rebuilds < 0 {
+		return 0
+	}
+	return t.cfg.Transaction.Rebuilds
 }
 
-func (t *TrxUsecase) GetTRC20TokenBalance(ctx context.Context, addr, contractAddr string) (*big.Int, error) {
-	return t.cli.GetTRC20TokenBalance(ctx, addr, contractAddr)
+// recordWithdrawal store a broadcast transfer so its confirmations are tracked, or its
+// expiration when it never gets included. The
+// transfer is already on its way, so a failure here is only logged.
+func (t *TrxUsecase) recordWithdrawal(ctx context.Context, tx *api.TransactionExtention, w *Tx) {
+	w.Txid = hex.EncodeToString(tx.Txid)
+	w.Direction = TxDirectionOut
+	if w.ExpiresAt == nil && tx.GetTransaction() != nil {
+		expiresAt := time.UnixMilli(tx.Transaction.GetRawData().GetExpiration())
+		w.ExpiresAt = &expiresAt
+	}
+	w.Status = TxStatusPending
+	event, err := t.repo.CreateTx(ctx, w)
+	if err != nil {
+		t.log.Sugar().Errorw("recordWithdrawal", "txid", w.Txid, "err", err)
+		return
+	}
+	t.bus.Publish(event)
 }
@@ -0,0 +1,245 @@
+package biz
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// fakeStaleNode rejects the first stale broadcasts with code, as a node does with txs
+// that expired or reference a block it does not know
+type fakeStaleNode struct {
+	*fakeSweepNode
+	code      api.ReturnResponseCode
+	stale     int
+	rejected  int
+	txids     []string // of every broadcast
+	solidTime int64    // timestamp in ms of the latest solidified block
+}
+
+func (n *fakeStaleNode) GetNodeInfo(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*core.NodeInfo, error) {
+	return &core.NodeInfo{SolidityBlock: "Num:90,ID:00"}, nil
+}
+
+func (n *fakeStaleNode) GetBlockByNum2(ctx context.Context, in *api.NumberMessage, opts ...grpc.CallOption) (*api.BlockExtention, error) {
+	return &api.BlockExtention{BlockHeader: &core.BlockHeader{RawData: &core.BlockHeaderRaw{Number: in.Num, Timestamp: n.solidTime}}}, nil
+}
+
+func (n *fakeStaleNode) BroadcastTransaction(ctx context.Context, in *core.Transaction, opts ...grpc.CallOption) (*api.Return, error) {
+	n.mu.Lock()
+	txid, _ := txHash(in)
+	n.txids = append(n.txids, string(txid))
+	if n.rejected < n.stale {
+		n.rejected++
+		n.mu.Unlock()
+		return &api.Return{Code: n.code, Message: []byte("stale")}, nil
+	}
+	n.mu.Unlock()
+	return n.fakeSweepNode.BroadcastTransaction(ctx, in, opts...)
+}
+
+func TestTransferExpiration(t *testing.T) {
+	from, to := newTestAddress(t).String(), newTestAddress(t).String()
+	node := &fakeSweepNode{balances: map[string]int64{string(mustDecode(t, from)): 100 * sunPerTRX}}
+	cfg := &setting.Config{Transaction: setting.Transaction{Expiration: 3600}}
+	trxRepo := newMemTrxRepo()
+	uc := NewTrxUsecase(trxRepo, nil, zap.NewNop(), &TronCli{TronWalletCli: node, GrpcTimeout: time.Second}, nopSigner{}, NewEventBus(), cfg)
+
+	before := time.Now()
+	tx, err := uc.TransferTrx(context.Background(), from, to, sunPerTRX)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiration := time.UnixMilli(tx.Transaction.RawData.Expiration)
+	if expiration.Before(before.Add(time.Hour).Truncate(time.Millisecond)) || expiration.After(time.Now().Add(time.Hour)) {
+		t.Fatalf("expires at %s", expiration)
+	}
+	if id, _ := txHash(tx.Transaction); string(id) != string(tx.Txid) || len(trxRepo.txs) != 1 {
+		t.Fatalf("txid %x of a tx hashing to %x, withdrawals %d", tx.Txid, id, len(trxRepo.txs))
+	}
+
+	// capped below the protocol maximum
+	cfg.Transaction.Expiration = 2 * 86400
+	if tx, err = uc.TransferTrx(context.Background(), from, to, sunPerTRX); err != nil {
+		t.Fatal(err)
+	}
+	if expiration := time.UnixMilli(tx.Transaction.RawData.Expiration); expiration.After(time.Now().Add(maxTxExpiration - txExpiryMargin)) {
+		t.Fatalf("expires at %s", expiration)
+	}
+}
+
+func TestTransferRebuildsStaleTx(t *testing.T) {
+	from, to := newTestAddress(t).String(), newTestAddress(t).String()
+	const expired = 1666000060001 // a solidified block past the expiration of the txs built
+	for _, c := range []struct {
+		name       string
+		code       api.ReturnResponseCode
+		stale      int
+		rebuilds   int
+		onChain    bool
+		solidTime  int64
+		wantErr    bool
+		unknown    bool // the tx is kept and tracked though the transfer errs
+		broadcasts int  // attempts of the node, rejected ones included
+	}{
+		{name: "expired once", code: api.Return_TRANSACTION_EXPIRATION_ERROR, stale: 1, solidTime: expired, broadcasts: 2},
+		{name: "out of rebuilds", code: api.Return_TRANSACTION_EXPIRATION_ERROR, stale: 5, rebuilds: 1, solidTime: expired, wantErr: true, broadcasts: 2},
+		{name: "rebuilds disabled", code: api.Return_TRANSACTION_EXPIRATION_ERROR, stale: 1, rebuilds: -1, solidTime: expired, wantErr: true, broadcasts: 1},
+		// included by an earlier broadcast, a new tx would pay twice
+		{name: "on chain", code: api.Return_TRANSACTION_EXPIRATION_ERROR, stale: 1, onChain: true, solidTime: expired, broadcasts: 1},
+		// the node may be ahead of the chain, the tx may still be included
+		{name: "expiry not solidified", code: api.Return_TRANSACTION_EXPIRATION_ERROR, stale: 1, solidTime: expired - 1, wantErr: true, unknown: true, broadcasts: 1},
+		// a node behind does not know the referenced block, the same tx goes to another node
+		{name: "unknown ref block", code: api.Return_TAPOS_ERROR, stale: 2, broadcasts: 3},
+		{name: "unknown ref block on every node", code: api.Return_TAPOS_ERROR, stale: 10, wantErr: true, broadcasts: 4},
+		{name: "other rejection", code: api.Return_CONTRACT_VALIDATE_ERROR, stale: 1, wantErr: true, broadcasts: 1},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			node := &fakeStaleNode{
+				fakeSweepNode: &fakeSweepNode{balances: map[string]int64{string(mustDecode(t, from)): 100 * sunPerTRX}, onChain: c.onChain},
+				code:          c.code,
+				stale:         c.stale,
+				solidTime:     c.solidTime,
+			}
+			cfg := &setting.Config{Tron: setting.Tron{RetryBackoff: 1}, Transaction: setting.Transaction{Rebuilds: c.rebuilds}}
+			trxRepo := newMemTrxRepo()
+			uc := NewTrxUsecase(trxRepo, nil, zap.NewNop(), &TronCli{TronWalletCli: node, GrpcTimeout: time.Second}, nopSigner{}, NewEventBus(), cfg)
+
+			_, err := uc.TransferTrx(context.Background(), from, to, sunPerTRX)
+			var unknown *BroadcastUnknownError
+			if (err != nil) != c.wantErr || errors.As(err, &unknown) != c.unknown {
+				t.Fatalf("err %v", err)
+			}
+			if attempts := node.rejected + len(node.broadcast); attempts != c.broadcasts {
+				t.Fatalf("broadcast %d times, want %d", attempts, c.broadcasts)
+			}
+			if recorded := len(trxRepo.txs) == 1; recorded != (!c.wantErr || c.unknown) {
+				t.Fatalf("withdrawals %d", len(trxRepo.txs))
+			}
+		})
+	}
+}
+
+func TestTransferRebroadcastsToBusyNode(t *testing.T) {
+	from, to := newTestAddress(t).String(), newTestAddress(t).String()
+	busy := api.Return_SERVER_BUSY
+	for _, c := range []struct {
+		name         string
+		code         api.ReturnResponseCode
+		busy         int
+		rebroadcasts int
+		wantErr      api.ReturnResponseCode // code of the refusal returned, SUCCESS for none
+		broadcasts   int
+	}{
+		{name: "busy once", code: busy, busy: 1, broadcasts: 2},
+		{name: "busy until out of rebroadcasts", code: busy, busy: 5, rebroadcasts: 2, wantErr: busy, broadcasts: 3},
+		{name: "rebroadcasts disabled", code: busy, busy: 1, rebroadcasts: -1, wantErr: busy, broadcasts: 1},
+		// result false with code SUCCESS
+		{name: "refused without a code", code: api.Return_SUCCESS, busy: 1, wantErr: api.Return_OTHER_ERROR, broadcasts: 1},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			node := &fakeStaleNode{
+				fakeSweepNode: &fakeSweepNode{balances: map[string]int64{string(mustDecode(t, from)): 100 * sunPerTRX}},
+				code:          c.code,
+				stale:         c.busy,
+			}
+			cfg := &setting.Config{
+				Tron:        setting.Tron{RetryBackoff: 1},
+				Transaction: setting.Transaction{Rebroadcasts: c.rebroadcasts},
+			}
+			uc := NewTrxUsecase(newMemTrxRepo(), nil, zap.NewNop(), &TronCli{TronWalletCli: node, GrpcTimeout: time.Second}, nopSigner{}, NewEventBus(), cfg)
+
+			tx, err := uc.TransferTrx(context.Background(), from, to, sunPerTRX)
+			if len(node.txids) != c.broadcasts {
+				t.Fatalf("broadcast %d times, want %d", len(node.txids), c.broadcasts)
+			}
+			if c.wantErr != api.Return_SUCCESS {
+				var be *BroadcastError
+				if !errors.As(err, &be) || be.Code != c.wantErr {
+					t.Fatalf("err %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the tx signed first is sent again, not a new one
+			for _, txid := range node.txids {
+				if txid != string(tx.Txid) {
+					t.Fatalf("broadcast %x, transfer %x", txid, tx.Txid)
+				}
+			}
+		})
+	}
+}
+
+func TestTransferBroadcastUnknown(t *testing.T) {
+	from, to := newTestAddress(t).String(), newTestAddress(t).String()
+	node := &fakeSweepNode{balances: map[string]int64{string(mustDecode(t, from)): 100 * sunPerTRX}, down: true}
+	trxRepo := newMemTrxRepo()
+	uc := NewTrxUsecase(trxRepo, nil, zap.NewNop(), &TronCli{TronWalletCli: node, GrpcTimeout: time.Second}, nopSigner{}, NewEventBus(), &setting.Config{})
+
+	// the node may have taken it, the signed tx is kept and tracked instead of dropped
+	tx, err := uc.TransferTrx(context.Background(), from, to, sunPerTRX)
+	var unknown *BroadcastUnknownError
+	if !errors.As(err, &unknown) || tx == nil || unknown.Txid != hex.EncodeToString(tx.Txid) {
+		t.Fatalf("tx %v, err %v", tx, err)
+	}
+	w, ok := trxRepo.txs[memTxKey(&Tx{Txid: unknown.Txid, Direction: TxDirectionOut})]
+	if !ok {
+		t.Fatalf("withdrawals %v", trxRepo.txs)
+	}
+	if w.Status != TxStatusPending || w.ExpiresAt == nil {
+		t.Fatalf("withdrawal %+v", w)
+	}
+}
+
+// fakeSolidityNode serves the balances of a solidified block that lags the head
+type fakeSolidityNode struct {
+	api.WalletSolidityClient
+	balances map[string]int64
+	height   int64
+}
+
+func (n *fakeSolidityNode) GetAccount(ctx context.Context, in *core.Account, opts ...grpc.CallOption) (*core.Account, error) {
+	balance, ok := n.balances[string(in.Address)]
+	if !ok {
+		return &core.Account{}, nil
+	}
+	return &core.Account{Address: in.Address, Balance: balance}, nil
+}
+
+func (n *fakeSolidityNode) GetNowBlock2(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*api.BlockExtention, error) {
+	return &api.BlockExtention{BlockHeader: &core.BlockHeader{RawData: &core.BlockHeaderRaw{Number: n.height}}}, nil
+}
+
+func TestConfirmedBalance(t *testing.T) {
+	addr := newTestAddress(t)
+	node := &fakeSweepNode{balances: map[string]int64{string(addr.Bytes()): 5 * sunPerTRX}}
+	cli := &TronCli{TronWalletCli: node, GrpcTimeout: time.Second}
+	uc := NewTrxUsecase(newMemTrxRepo(), nil, zap.NewNop(), cli, nopSigner{}, NewEventBus(), nil)
+	ctx := context.Background()
+
+	if _, err := uc.GetBalance(ctx, addr.String(), true); !errors.Is(err, ErrNoSolidityNode) {
+		t.Fatalf("confirmed balance without solidity nodes: %v", err)
+	}
+
+	cli.TronSolidityCli = &fakeSolidityNode{balances: map[string]int64{string(addr.Bytes()): 2 * sunPerTRX}, height: 90}
+	for confirmed, want := range map[bool]int64{false: 5 * sunPerTRX, true: 2 * sunPerTRX} {
+		balance, err := uc.GetBalance(ctx, addr.String(), confirmed)
+		if err != nil || balance.Int64() != want {
+			t.Fatalf("confirmed %v: balance %v, %v", confirmed, balance, err)
+		}
+	}
+	if num, err := cli.GetSolidBlockNum(ctx); err != nil || num != 90 {
+		t.Fatalf("solid block %d: %v", num, err)
+	}
+}
@@ -0,0 +1,77 @@
+package biz
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const TokenTRX = "TRX"
+
+type TxDirection int
+
+const (
+	TxDirectionIn  TxDirection = 1
+	TxDirectionOut TxDirection = 2
+)
+
+type TxStatus string
+
+const (
+	TxStatusPending    TxStatus = "pending"    // seen, not enough confirmations yet
+	TxStatusConfirmed  TxStatus = "confirmed"  // reached the token's confirmation threshold
+	TxStatusSolidified TxStatus = "solidified" // included in a solidified block, final
+	TxStatusFailed     TxStatus = "failed"     // included but the contract failed, or expired before inclusion
+	TxStatusReverted   TxStatus = "reverted"   // deposit from a block orphaned by a reorg
+)
+
+// Tx is a transfer of TRX, TRC10 or TRC20 touching an address we manage
+type Tx struct {
+	ID            int64           `gorm:"primaryKey" json:"id"`
+	Txid          string          `gorm:"size:64;not null;uniqueIndex:uk_txid_log_direction" json:"txid"`
+	LogIndex      int             `gorm:"not null;uniqueIndex:uk_txid_log_direction" json:"log_index"` // TRC20 event index, 0 for native transfers
+	BlockNum      int64           `gorm:"not null;index" json:"block_num"`
+	BlockHash     string          `gorm:"size:64" json:"block_hash"`
+	BlockTime     *time.Time      `json:"block_time"` // nil until the tx is included
+	Direction     TxDirection     `gorm:"not null;uniqueIndex:uk_txid_log_direction" json:"direction"`
+	Token         string          `gorm:"size:32;not null" json:"token"` // TRX, TRC10 asset id or TRC20 symbol
+	Contract      string          `gorm:"size:34" json:"contract"`
+	From          string          `gorm:"column:from_address;size:34;not null;index" json:"from"`
+	To            string          `gorm:"column:to_address;size:34;not null;index" json:"to"`
+	Amount        decimal.Decimal `gorm:"type:decimal(65,0);not null" json:"amount"` // in the token's smallest unit
+	Status        TxStatus        `gorm:"size:16;not null;index" json:"status"`
+	Confirmations int64           `gorm:"not null;default:0" json:"confirmations"` // blocks from BlockNum to head, inclusive
+	ExpiresAt     *time.Time      `json:"expires_at"`                              // of a broadcast withdrawal, nil for deposits
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// TxEvent records a state change of a Tx. IDs are allocated before commit, so readers of
+// the event log follow Seq, given in commit order, never ID.
+type TxEvent struct {
+	ID            int64     `gorm:"primaryKey" json:"id"`
+	TxID          int64     `gorm:"not null;index" json:"tx_id"` // primary key of Tx, not the txid
+	Tx            *Tx       `json:"tx"`
+	Status        TxStatus  `gorm:"size:16;not null" json:"status"`
+	Confirmations int64     `gorm:"not null" json:"confirmations"`
+	Seq           int64     `gorm:"not null;default:0;index" json:"seq"` // 0 until the committed event is sequenced
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ScanCheckpoint is the last block a scanner fully processed
+type ScanCheckpoint struct {
+	ID        int64  `gorm:"primaryKey"`
+	Name      string `gorm:"size:32;not null;uniqueIndex"`
+	BlockNum  int64  `gorm:"not null"`
+	BlockHash string `gorm:"size:64"`
+	UpdatedAt time.Time
+}
+
+// ScanBlock is a recently scanned block, kept to detect reorgs
+type ScanBlock struct {
+	ID        int64  `gorm:"primaryKey"`
+	Scanner   string `gorm:"size:32;not null;uniqueIndex:uk_scanner_num"`
+	Num       int64  `gorm:"not null;uniqueIndex:uk_scanner_num"`
+	Hash      string `gorm:"size:64;not null"`
+	CreatedAt time.Time
+}
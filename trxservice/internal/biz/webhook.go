@@ -0,0 +1,350 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"go.uber.org/zap"
+)
+
+const (
+	webhookCursor = "webhook"
+	// WebhookSignatureHeader carries the hex HMAC-SHA256 of the body keyed by the tenant secret
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+type WebhookStatus string
+
+const (
+	WebhookStatusPending   WebhookStatus = "pending"
+	WebhookStatusDelivered WebhookStatus = "delivered"
+	WebhookStatusDead      WebhookStatus = "dead" // gave up after max attempts, waits for a replay
+)
+
+// WebhookDelivery is an outbox entry, one per tenant and tx event
+type WebhookDelivery struct {
+	ID            int64         `gorm:"primaryKey" json:"id"`
+	Tenant        string        `gorm:"size:64;not null;uniqueIndex:uk_tenant_event" json:"tenant"`
+	EventID       int64         `gorm:"not null;uniqueIndex:uk_tenant_event" json:"event_id"`
+	Payload       string        `gorm:"type:text;not null" json:"payload"`
+	Status        WebhookStatus `gorm:"size:16;not null;index:idx_status_next" json:"status"`
+	Attempts      int           `gorm:"not null" json:"attempts"`
+	NextAttemptAt time.Time     `gorm:"not null;index:idx_status_next" json:"next_attempt_at"`
+	LastError     string        `gorm:"size:255" json:"last_error"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+}
+
+// EventCursor is the Seq of the last tx event a consumer of the event log has handled
+type EventCursor struct {
+	ID        int64  `gorm:"primaryKey"`
+	Name      string `gorm:"size:32;not null;uniqueIndex"`
+	EventID   int64  `gorm:"not null"` // a Seq, named before events were sequenced
+	UpdatedAt time.Time
+}
+
+// WebhookPayload is the JSON body posted to a tenant
+type WebhookPayload struct {
+	EventID       int64    `json:"event_id"`
+	Tenant        string   `json:"tenant"`
+	Status        TxStatus `json:"status"`
+	Confirmations int64    `json:"confirmations"`
+	Tx            *Tx      `json:"tx"`
+	CreatedAt     int64    `json:"created_at"` // unix seconds of the event
+}
+
+// WebhookRepo persists the webhook outbox
+type WebhookRepo interface {
+	// GetEventCursor return the event ID consumer name has handled, 0 if none
+	GetEventCursor(ctx context.Context, name string) (int64, error)
+	// Enqueue store deliveries and move the cursor of name to eventID atomically
+	Enqueue(ctx context.Context, name string, eventID int64, deliveries []*WebhookDelivery) error
+	// ListDueDeliveries return pending deliveries whose next attempt is not after now
+	ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, d *WebhookDelivery) error
+	// ReplayDeliveries reset dead deliveries to pending, only of tenant and ids when given
+	ReplayDeliveries(ctx context.Context, tenant string, ids []int64) (int64, error)
+}
+
+// WebhookUsecase notifies tenants of tx state changes through a persisted outbox
+type WebhookUsecase struct {
+	repo     WebhookRepo
+	trxRepo  TrxRepo
+	addrRepo AddressRepo
+	bus      *EventBus
+	cfg      *setting.Config
+	log      *zap.Logger
+	client   *http.Client
+	now      func() time.Time
+	wake     chan struct{}
+}
+
+// NewWebhookUsecase new a webhook usecase.
+func NewWebhookUsecase(repo WebhookRepo, trxRepo TrxRepo, addrRepo AddressRepo, bus *EventBus, cfg *setting.Config, logger *zap.Logger) *WebhookUsecase {
+	timeout := time.Duration(cfg.Webhook.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &WebhookUsecase{
+		repo:     repo,
+		trxRepo:  trxRepo,
+		addrRepo: addrRepo,
+		bus:      bus,
+		cfg:      cfg,
+		log:      logger,
+		client:   &http.Client{Timeout: timeout},
+		now:      time.Now,
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Enabled report whether webhook delivery is switched on in config
+func (uc *WebhookUsecase) Enabled() bool {
+	return uc.cfg.Webhook.Enable
+}
+
+// Run enqueue and deliver webhooks until ctx is done, new events wake it up early
+func (uc *WebhookUsecase) Run(ctx context.Context) error {
+	unsubscribe := uc.bus.Subscribe(func(e *TxEvent) {
+		select {
+		case uc.wake <- struct{}{}:
+		default:
+		}
+	})
+	defer unsubscribe()
+
+	interval := time.Duration(uc.cfg.Webhook.Interval) * time.Second
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := uc.enqueue(ctx); err != nil {
+			uc.log.Sugar().Errorw("WebhookUsecase enqueue", "err", err)
+		}
+		if err := uc.deliver(ctx); err != nil {
+			uc.log.Sugar().Errorw("WebhookUsecase deliver", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-uc.wake:
+		}
+	}
+}
+
+// Replay put dead deliveries back in the outbox, only of tenant and ids when given
+func (uc *WebhookUsecase) Replay(ctx context.Context, tenant string, ids []int64) (int64, error) {
+	n, err := uc.repo.ReplayDeliveries(ctx, tenant, ids)
+	if err != nil {
+		return 0, err
+	}
+	uc.log.Sugar().Infow("Replay", "tenant", tenant, "ids", ids, "count", n)
+	select {
+	case uc.wake <- struct{}{}:
+	default:
+	}
+	return n, nil
+}
+
+// enqueue turn events after the cursor into deliveries
+func (uc *WebhookUsecase) enqueue(ctx context.Context) error {
+	const batch = 100
+	cursor, err := uc.repo.GetEventCursor(ctx, webhookCursor)
+	if err != nil {
+		return err
+	}
+	for ctx.Err() == nil {
+		events, err := uc.trxRepo.ListEvents(ctx, cursor, batch)
+		if err != nil || len(events) == 0 {
+			return err
+		}
+		deliveries, err := uc.route(ctx, events)
+		if err != nil {
+			return err
+		}
+		cursor = events[len(events)-1].Seq
+		if err := uc.repo.Enqueue(ctx, webhookCursor, cursor, deliveries); err != nil {
+			return err
+		}
+		if len(events) < batch {
+			break
+		}
+	}
+	return nil
+}
+
+// route build a delivery for every tenant that owns an address of the event's tx
+func (uc *WebhookUsecase) route(ctx context.Context, events []*TxEvent) ([]*WebhookDelivery, error) {
+	tenants := uc.cfg.Webhook.Tenants
+	if len(tenants) == 0 {
+		return nil, nil
+	}
+	addrs := make([]string, 0, 2*len(events))
+	for _, e := range events {
+		addrs = append(addrs, e.Tx.From, e.Tx.To)
+	}
+	deposits, err := uc.addrRepo.ListDepositAddresses(ctx, addrs)
+	if err != nil {
+		return nil, err
+	}
+	accounts := make(map[string]uint32, len(deposits))
+	for _, d := range deposits {
+		accounts[d.Address] = d.Account
+	}
+
+	var deliveries []*WebhookDelivery
+	for _, e := range events {
+		for name, t := range tenants {
+			if !tenantOwns(t, accounts, e.Tx.From, e.Tx.To) {
+				continue
+			}
+			body, err := json.Marshal(&WebhookPayload{
+				EventID:       e.ID,
+				Tenant:        name,
+				Status:        e.Status,
+				Confirmations: e.Confirmations,
+				Tx:            e.Tx,
+				CreatedAt:     e.CreatedAt.Unix(),
+			})
+			if err != nil {
+				return nil, err
+			}
+			deliveries = append(deliveries, &WebhookDelivery{
+				Tenant:        name,
+				EventID:       e.ID,
+				Payload:       string(body),
+				Status:        WebhookStatusPending,
+				NextAttemptAt: uc.now(),
+			})
+		}
+	}
+	return deliveries, nil
+}
+
+func tenantOwns(t setting.WebhookTenant, accounts map[string]uint32, addrs ...string) bool {
+	for _, addr := range addrs {
+		for _, a := range t.Addresses {
+			if a == addr {
+				return true
+			}
+		}
+		account, ok := accounts[addr]
+		if !ok {
+			continue
+		}
+		for _, a := range t.Accounts {
+			if a == account {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// deliver post due deliveries and schedule retries of failed ones
+func (uc *WebhookUsecase) deliver(ctx context.Context) error {
+	deliveries, err := uc.repo.ListDueDeliveries(ctx, uc.now(), 50)
+	if err != nil {
+		return err
+	}
+	for _, d := range deliveries {
+		if ctx.Err() != nil {
+			return nil
+		}
+		err := uc.post(ctx, d)
+		d.Attempts++
+		switch {
+		case err == nil:
+			d.Status, d.LastError = WebhookStatusDelivered, ""
+		case d.Attempts >= uc.maxAttempts():
+			d.Status, d.LastError = WebhookStatusDead, truncate(err.Error(), 255)
+			uc.log.Sugar().Errorw("webhook dead", "id", d.ID, "tenant", d.Tenant, "event", d.EventID, "attempts", d.Attempts, "err", err)
+		default:
+			d.LastError = truncate(err.Error(), 255)
+			d.NextAttemptAt = uc.now().Add(uc.backoff(d.Attempts))
+			uc.log.Sugar().Warnw("webhook failed", "id", d.ID, "tenant", d.Tenant, "event", d.EventID, "attempts", d.Attempts, "err", err)
+		}
+		if err := uc.repo.UpdateDelivery(ctx, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (uc *WebhookUsecase) post(ctx context.Context, d *WebhookDelivery) error {
+	t, ok := uc.cfg.Webhook.Tenants[d.Tenant]
+	if !ok {
+		return fmt.Errorf("tenant %s is not configured", d.Tenant)
+	}
+	body := []byte(d.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Id", fmt.Sprint(d.ID))
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(t.Secret, body))
+	resp, err := uc.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// backoff return the delay before the next attempt after attempts failures
+func (uc *WebhookUsecase) backoff(attempts int) time.Duration {
+	base := time.Duration(uc.cfg.Webhook.BaseBackoff) * time.Second
+	if base <= 0 {
+		base = 5 * time.Second
+	}
+	max := time.Duration(uc.cfg.Webhook.MaxBackoff) * time.Second
+	if max <= 0 {
+		max = time.Hour
+	}
+	d := base
+	for i := 1; i < attempts && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return d
+}
+
+func (uc *WebhookUsecase) maxAttempts() int {
+	if uc.cfg.Webhook.MaxAttempts > 0 {
+		return uc.cfg.Webhook.MaxAttempts
+	}
+	return 10
+}
+
+// SignWebhook return the hex HMAC-SHA256 of body keyed by secret
+func SignWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
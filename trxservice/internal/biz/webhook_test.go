@@ -0,0 +1,254 @@
+package biz
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// memWebhookRepo keeps the outbox in memory
+type memWebhookRepo struct {
+	mu         sync.Mutex
+	cursors    map[string]int64
+	deliveries []*WebhookDelivery
+}
+
+func (r *memWebhookRepo) GetEventCursor(ctx context.Context, name string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cursors[name], nil
+}
+
+func (r *memWebhookRepo) Enqueue(ctx context.Context, name string, eventID int64, deliveries []*WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, d := range deliveries {
+		d.ID = int64(len(r.deliveries) + 1)
+		r.deliveries = append(r.deliveries, d)
+	}
+	r.cursors[name] = eventID
+	return nil
+}
+
+func (r *memWebhookRepo) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var list []*WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.Status == WebhookStatusPending && !d.NextAttemptAt.After(now) && len(list) < limit {
+			copied := *d
+			list = append(list, &copied)
+		}
+	}
+	return list, nil
+}
+
+func (r *memWebhookRepo) UpdateDelivery(ctx context.Context, d *WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	*r.deliveries[d.ID-1] = *d
+	return nil
+}
+
+func (r *memWebhookRepo) ReplayDeliveries(ctx context.Context, tenant string, ids []int64) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var n int64
+	for _, d := range r.deliveries {
+		if d.Status == WebhookStatusDead && (tenant == "" || d.Tenant == tenant) {
+			d.Status, d.Attempts, d.NextAttemptAt = WebhookStatusPending, 0, time.Time{}
+			n++
+		}
+	}
+	return n, nil
+}
+
+// webhookReceiver is an httptest endpoint that checks signatures
+type webhookReceiver struct {
+	t        *testing.T
+	secret   string
+	mu       sync.Mutex
+	fail     bool
+	received []WebhookPayload
+}
+
+func (h *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	if got := r.Header.Get(WebhookSignatureHeader); got != SignWebhook(h.secret, body) {
+		h.t.Errorf("bad signature %s", got)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.fail {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	var p WebhookPayload
+	if err := json.Unmarshal(body, &p); err != nil {
+		h.t.Error(err)
+	}
+	h.received = append(h.received, p)
+}
+
+func newTestWebhook(t *testing.T, url string, trxRepo *memTrxRepo, managed ...string) (*WebhookUsecase, *memWebhookRepo, *time.Time) {
+	cfg := &setting.Config{}
+	cfg.Webhook.MaxAttempts = 3
+	cfg.Webhook.BaseBackoff = 10
+	cfg.Webhook.Tenants = map[string]setting.WebhookTenant{
+		"shop":  {URL: url, Secret: "s3cret", Accounts: []uint32{7}},
+		"other": {URL: url, Secret: "other", Accounts: []uint32{8}},
+	}
+	addrRepo := &memAddressRepo{managed: make(map[string]bool), account: 7}
+	for _, a := range managed {
+		addrRepo.managed[a] = true
+	}
+	repo := &memWebhookRepo{cursors: make(map[string]int64)}
+	uc := NewWebhookUsecase(repo, trxRepo, addrRepo, NewEventBus(), cfg, zap.NewNop())
+	now := time.Unix(1666000000, 0)
+	uc.now = func() time.Time { return now }
+	return uc, repo, &now
+}
+
+func newTestDepositEvent(t *testing.T, repo *memTrxRepo, to string) {
+	t.Helper()
+	_, err := repo.CreateTx(context.Background(), &Tx{
+		Txid: hex.EncodeToString([]byte(to)), Direction: TxDirectionIn, Token: TokenTRX, From: newTestAddress(t).String(), To: to,
+		Amount: decimal.NewFromInt(5), Status: TxStatusPending,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	receiver := &webhookReceiver{t: t, secret: "s3cret"}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	deposit := newTestAddress(t).String()
+	trxRepo := newMemTrxRepo()
+	uc, repo, _ := newTestWebhook(t, srv.URL, trxRepo, deposit)
+	newTestDepositEvent(t, trxRepo, deposit)
+	newTestDepositEvent(t, trxRepo, newTestAddress(t).String()) // not ours
+
+	ctx := context.Background()
+	if err := uc.enqueue(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := uc.deliver(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.deliveries) != 1 || repo.deliveries[0].Status != WebhookStatusDelivered {
+		t.Fatalf("deliveries %+v", repo.deliveries)
+	}
+	if len(receiver.received) != 1 {
+		t.Fatalf("received %d callbacks", len(receiver.received))
+	}
+	p := receiver.received[0]
+	if p.Tenant != "shop" || p.Status != TxStatusPending || p.Tx.To != deposit || p.Tx.Amount.String() != "5" {
+		t.Fatalf("payload %+v", p)
+	}
+
+	// the cursor moved, nothing is enqueued twice
+	if err := uc.enqueue(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.deliveries) != 1 {
+		t.Fatalf("%d deliveries after a second enqueue", len(repo.deliveries))
+	}
+}
+
+func TestWebhookEnqueuesLateCommittedEvent(t *testing.T) {
+	first, second := newTestAddress(t).String(), newTestAddress(t).String()
+	trxRepo := newMemTrxRepo()
+	uc, repo, _ := newTestWebhook(t, "http://127.0.0.1:0", trxRepo, first, second)
+
+	// event 1 commits after event 2 was enqueued, the cursor must not pass over it
+	trxRepo.hold = true
+	newTestDepositEvent(t, trxRepo, first)
+	trxRepo.hold = false
+	newTestDepositEvent(t, trxRepo, second)
+	ctx := context.Background()
+	if err := uc.enqueue(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.deliveries) != 1 || repo.deliveries[0].EventID != 2 {
+		t.Fatalf("deliveries %+v", repo.deliveries)
+	}
+	trxRepo.commitEvents(1)
+	if err := uc.enqueue(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.deliveries) != 2 || repo.deliveries[1].EventID != 1 {
+		t.Fatalf("deliveries %+v", repo.deliveries)
+	}
+}
+
+func TestWebhookRetryDeadLetterAndReplay(t *testing.T) {
+	receiver := &webhookReceiver{t: t, secret: "s3cret", fail: true}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	deposit := newTestAddress(t).String()
+	trxRepo := newMemTrxRepo()
+	uc, repo, now := newTestWebhook(t, srv.URL, trxRepo, deposit)
+	newTestDepositEvent(t, trxRepo, deposit)
+
+	ctx := context.Background()
+	if err := uc.enqueue(ctx); err != nil {
+		t.Fatal(err)
+	}
+	d := repo.deliveries[0]
+	start := *now
+	for i, wait := range []time.Duration{10 * time.Second, 20 * time.Second} {
+		if err := uc.deliver(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if d.Status != WebhookStatusPending || d.Attempts != i+1 || !d.NextAttemptAt.Equal(now.Add(wait)) {
+			t.Fatalf("attempt %d: %+v", i+1, d)
+		}
+		// not due yet
+		*now = d.NextAttemptAt.Add(-time.Second)
+		if err := uc.deliver(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if d.Attempts != i+1 {
+			t.Fatalf("retried before the backoff elapsed")
+		}
+		*now = d.NextAttemptAt
+	}
+	if err := uc.deliver(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if d.Status != WebhookStatusDead || d.Attempts != 3 || d.LastError == "" {
+		t.Fatalf("after max attempts: %+v", d)
+	}
+	if now.Sub(start) != 30*time.Second {
+		t.Fatalf("backoff took %s", now.Sub(start))
+	}
+
+	receiver.mu.Lock()
+	receiver.fail = false
+	receiver.mu.Unlock()
+	if n, err := uc.Replay(ctx, "shop", nil); err != nil || n != 1 {
+		t.Fatalf("replayed %d, %v", n, err)
+	}
+	if err := uc.deliver(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if d.Status != WebhookStatusDelivered || len(receiver.received) != 1 {
+		t.Fatalf("after replay: %+v", d)
+	}
+}
@@ -0,0 +1,111 @@
+package data
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/leondevpt/wallet/trxservice/internal/biz"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type addressRepo struct {
+	data *Data
+	log  *zap.Logger
+}
+
+// NewAddressRepo .
+func NewAddressRepo(data *Data, logger *zap.Logger) biz.AddressRepo {
+	return &addressRepo{
+		data: data,
+		log:  logger,
+	}
+}
+
+func (r *addressRepo) GetDepositAddress(ctx context.Context, userID string, account uint32) (*biz.DepositAddress, error) {
+	var addr biz.DepositAddress
+	err := r.data.DB(ctx).Where("user_id = ? AND account = ?", userID, account).Take(&addr).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, biz.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &addr, nil
+}
+
+func (r *addressRepo) CreateDepositAddress(ctx context.Context, userID string, account uint32, derive func(index uint32) (string, error)) (*biz.DepositAddress, error) {
+	var addr *biz.DepositAddress
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		db := r.data.DB(ctx)
+
+		// 锁住该 account 下最大的 index, 并发请求按顺序分配
+		var last biz.DepositAddress
+		next := uint32(0)
+		err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("account = ?", account).Order("address_index DESC").Take(&last).Error
+		switch {
+		case err == nil:
+			next = last.Index + 1
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+
+		// 加锁后再次检查, 重试的请求直接返回已分配的地址
+		existing, err := r.GetDepositAddress(ctx, userID, account)
+		if err == nil {
+			addr = existing
+			return nil
+		}
+		if !errors.Is(err, biz.ErrNotFound) {
+			return err
+		}
+
+		a, err := derive(next)
+		if err != nil {
+			return err
+		}
+		addr = &biz.DepositAddress{UserID: userID, Account: account, Index: next, Address: a}
+		return db.Create(addr).Error
+	})
+	if isDuplicateKey(err) {
+		// lost the race against a concurrent request of the same user
+		return r.GetDepositAddress(ctx, userID, account)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return addr, nil
+}
+
+func (r *addressRepo) FilterManagedAddresses(ctx context.Context, addrs []string) (map[string]bool, error) {
+	var found []string
+	err := r.data.DB(ctx).Model(&biz.DepositAddress{}).Where("address IN ?", addrs).Pluck("address", &found).Error
+	if err != nil {
+		return nil, err
+	}
+	managed := make(map[string]bool, len(found))
+	for _, a := range found {
+		managed[a] = true
+	}
+	return managed, nil
+}
+
+func (r *addressRepo) ListDepositAddresses(ctx context.Context, addrs []string) ([]*biz.DepositAddress, error) {
+	var list []*biz.DepositAddress
+	err := r.data.DB(ctx).Where("address IN ?", addrs).Find(&list).Error
+	return list, err
+}
+
+func (r *addressRepo) ListDepositAddressesAfter(ctx context.Context, afterID int64, limit int) ([]*biz.DepositAddress, error) {
+	var list []*biz.DepositAddress
+	err := r.data.DB(ctx).Where("id > ?", afterID).Order("id").Limit(limit).Find(&list).Error
+	return list, err
+}
+
+func isDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1062
+}
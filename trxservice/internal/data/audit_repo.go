@@ -0,0 +1,25 @@
+package data
+
+import (
+	"context"
+
+	"github.com/leondevpt/wallet/trxservice/internal/biz"
+	"go.uber.org/zap"
+)
+
+type auditRepo struct {
+	data *Data
+	log  *zap.Logger
+}
+
+// NewAuditRepo .
+func NewAuditRepo(data *Data, logger *zap.Logger) biz.AuditRepo {
+	return &auditRepo{
+		data: data,
+		log:  logger,
+	}
+}
+
+func (r *auditRepo) CreateAuditLog(ctx context.Context, l *biz.AuditLog) error {
+	return r.data.DB(ctx).Create(l).Error
+}
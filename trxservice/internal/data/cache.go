@@ -0,0 +1,191 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/leondevpt/wallet/trxservice/internal/biz"
+	"github.com/leondevpt/wallet/trxservice/pkg/setting"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultBalanceTTL = time.Minute
+	defaultTokenTTL   = 24 * time.Hour
+)
+
+var (
+	cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "trx_cache_requests_total",
+		Help: "Reads of the redis cache by kind of entry and result: hit, miss or error.",
+	}, []string{"cache", "result"})
+	cacheSharedLoads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "trx_cache_shared_loads_total",
+		Help: "Misses answered by the node call of a concurrent identical lookup.",
+	}, []string{"cache"})
+)
+
+func init() {
+	prometheus.MustRegister(cacheRequests, cacheSharedLoads)
+}
+
+// balanceCache keeps the balances of an address in one redis hash, a field per token and
+// pool holding the height and balance of the last read. A read at a height the field has
+// reached is a hit, so the hash stays small and dropping it invalidates the address.
+type balanceCache struct {
+	data  *Data
+	cfg   *setting.Config
+	group singleflight.Group
+	log   *zap.Logger
+}
+
+// NewBalanceCache new the redis cache of balances and TRC20 metadata, nil unless
+// cache.enable is set
+func NewBalanceCache(data *Data, cfg *setting.Config, logger *zap.Logger) biz.BalanceCache {
+	if !cfg.Cache.Enable {
+		return nil
+	}
+	return &balanceCache{data: data, cfg: cfg, log: logger}
+}
+
+func balanceKey(addr string) string {
+	return "trx:balance:" + addr
+}
+
+func balanceField(k biz.BalanceKey) string {
+	if k.Confirmed {
+		return k.Token + ":solid"
+	}
+	return k.Token + ":head"
+}
+
+func tokenKey(contract string) string {
+	return "trx:token:" + contract
+}
+
+func ttl(seconds int, def time.Duration) time.Duration {
+	if seconds <= 0 {
+		return def
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// Balance return the cached balance of k, or load it once for all concurrent callers. The
+// load runs with the context of the first of them.
+func (c *balanceCache) Balance(ctx context.Context, k biz.BalanceKey, load func(ctx context.Context) (*big.Int, error)) (*big.Int, error) {
+	key, field := balanceKey(k.Address), balanceField(k)
+	v, err := c.data.rdb.HGet(ctx, key, field).Result()
+	if err == nil {
+		if balance, ok := parseBalance(v, k.Height); ok {
+			cacheRequests.WithLabelValues("balance", "hit").Inc()
+			return balance, nil
+		}
+	}
+	c.miss("balance", key, err)
+
+	shared, err, dup := c.group.Do(fmt.Sprintf("%s %s %d", key, field, k.Height), func() (interface{}, error) {
+		balance, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		_, err = c.data.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(ctx, key, field, fmt.Sprintf("%d:%s", k.Height, balance))
+			pipe.Expire(ctx, key, ttl(c.cfg.Cache.BalanceTTL, defaultBalanceTTL))
+			return nil
+		})
+		if err != nil {
+			c.log.Sugar().Warnw("cache balance", "key", key, "field", field, "err", err)
+		}
+		return balance, nil
+	})
+	if dup {
+		cacheSharedLoads.WithLabelValues("balance").Inc()
+	}
+	if err != nil {
+		return nil, err
+	}
+	// callers may change what they get
+	return new(big.Int).Set(shared.(*big.Int)), nil
+}
+
+// parseBalance read a field of height:balance, ok when it was read at height or later
+func parseBalance(v string, height int64) (*big.Int, bool) {
+	i := strings.IndexByte(v, ':')
+	if i < 0 {
+		return nil, false
+	}
+	at, err := strconv.ParseInt(v[:i], 10, 64)
+	if err != nil || at < height {
+		return nil, false
+	}
+	return new(big.Int).SetString(v[i+1:], 10)
+}
+
+// InvalidateBalances drop the hashes of addrs
+func (c *balanceCache) InvalidateBalances(ctx context.Context, addrs ...string) error {
+	if len(addrs) == 0 {
+		return nil
+	}
+	keys := make([]string, len(addrs))
+	for i, addr := range addrs {
+		keys[i] = balanceKey(addr)
+	}
+	return c.data.rdb.Del(ctx, keys...).Err()
+}
+
+// TokenInfo return the cached metadata of contract, or load it once for all concurrent
+// callers
+func (c *balanceCache) TokenInfo(ctx context.Context, contract string, load func(ctx context.Context) (*biz.TokenInfo, error)) (*biz.TokenInfo, error) {
+	key := tokenKey(contract)
+	b, err := c.data.rdb.Get(ctx, key).Bytes()
+	if err == nil {
+		info := new(biz.TokenInfo)
+		if err = json.Unmarshal(b, info); err == nil {
+			cacheRequests.WithLabelValues("token", "hit").Inc()
+			return info, nil
+		}
+	}
+	c.miss("token", key, err)
+
+	shared, err, dup := c.group.Do(key, func() (interface{}, error) {
+		info, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		b, err := json.Marshal(info)
+		if err == nil {
+			err = c.data.rdb.Set(ctx, key, b, ttl(c.cfg.Cache.TokenTTL, defaultTokenTTL)).Err()
+		}
+		if err != nil {
+			c.log.Sugar().Warnw("cache token", "key", key, "err", err)
+		}
+		return info, nil
+	})
+	if dup {
+		cacheSharedLoads.WithLabelValues("token").Inc()
+	}
+	if err != nil {
+		return nil, err
+	}
+	info := *shared.(*biz.TokenInfo)
+	return &info, nil
+}
+
+// miss count a lookup that goes to the nodes, err is what redis answered
+func (c *balanceCache) miss(cache, key string, err error) {
+	if err == nil || errors.Is(err, redis.Nil) {
+		cacheRequests.WithLabelValues(cache, "miss").Inc()
+		return
+	}
+	cacheRequests.WithLabelValues(cache, "error").Inc()
+	c.log.Sugar().Warnw("cache read", "key", key, "err", err)
+}
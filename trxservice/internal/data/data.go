@@ -3,6 +3,7 @@ package data
 import (
 	"context"
 	"fmt"
+	"github.com/leondevpt/wallet/trxservice/internal/biz"
 	"github.com/leondevpt/wallet/trxservice/pkg/setting"
 
 	"database/sql"
@@ -13,12 +14,14 @@ import (
 	"go.uber.org/zap"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/schema"
 	"moul.io/zapgorm2"
 )
 
 // ProviderSet is data providers.
-var ProviderSet = wire.NewSet(NewData, NewRedis, NewDB, NewTrxRepo)
+var ProviderSet = wire.NewSet(NewData, NewRedis, NewDB, NewTrxRepo, NewAddressRepo, NewWebhookRepo, NewAuditRepo,
+	NewPayoutRepo, NewSweepRepo, NewMultisigRepo, NewOfflineRepo, NewBalanceCache)
 
 type contextTxKey struct{}
 
@@ -42,6 +45,13 @@ func (d *Data) DB(ctx context.Context) *gorm.DB {
 	return d.db
 }
 
+// InTx run fn in a database transaction, repos called with the ctx passed to fn join it
+func (d *Data) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, contextTxKey{}, tx))
+	})
+}
+
 func NewDB(c *setting.Config) *gorm.DB {
 	newLogger := zapgorm2.New(zap.L())
 	newLogger.SetAsDefault()
@@ -62,27 +72,65 @@ func NewDB(c *setting.Config) *gorm.DB {
 	if err != nil {
 		panic("failed to connect database, err:" + err.Error())
 	}
-	//InitDB(db)
+	InitDB(db)
 	return db
 }
 
-/*
 func InitDB(db *gorm.DB) {
-	if err := db.AutoMigrate(&biz.Tx{}); err != nil {
+	// uk_txid_log became uk_txid_log_direction, the scanner's in row of a withdrawal to a
+	// managed address collided with the withdrawal itself
+	if m := db.Migrator(); m.HasIndex(&biz.Tx{}, "uk_txid_log") {
+		if err := m.DropIndex(&biz.Tx{}, "uk_txid_log"); err != nil {
+			panic(err)
+		}
+	}
+	if err := db.AutoMigrate(&biz.DepositAddress{}, &biz.Tx{}, &biz.TxEvent{}, &biz.ScanCheckpoint{}, &biz.ScanBlock{},
+		&biz.WebhookDelivery{}, &biz.EventCursor{}, &biz.AuditLog{}, &biz.PayoutBatch{}, &biz.PayoutItem{},
+		&biz.SweepRun{}, &biz.SweepTask{}, &biz.MultisigTx{}, &biz.MultisigSignature{},
+		&biz.OfflineTx{}); err != nil {
+		panic(err)
+	}
+	if err := initEventSeq(db); err != nil {
 		panic(err)
 	}
 }
-*/
 
+// eventSeqCounter names the EventCursor holding the last Seq given to a tx event
+const eventSeqCounter = "event_seq"
+
+// initEventSeq create the Seq counter. Events written before sequencing keep their ID as
+// Seq, so cursors stored and handed out as IDs stay valid.
+func initEventSeq(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&biz.EventCursor{Name: eventSeqCounter})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		if err := tx.Model(&biz.TxEvent{}).Where("seq = 0").Update("seq", gorm.Expr("id")).Error; err != nil {
+			return err
+		}
+		var last int64
+		if err := tx.Model(&biz.TxEvent{}).Select("COALESCE(MAX(seq), 0)").Scan(&last).Error; err != nil {
+			return err
+		}
+		return tx.Model(&biz.EventCursor{}).Where("name = ?", eventSeqCounter).Update("event_id", last).Error
+	})
+}
+
+// NewRedis new a client of redis, it connects on first use
 func NewRedis(c *setting.Config) *redis.Client {
 	rdb := redis.NewClient(&redis.Options{
 		Addr:     fmt.Sprintf("%s:%d", c.Redis.Host, c.Redis.Port),
 		Password: c.Redis.Password,
 		DB:       int(c.Redis.DB),
+		PoolSize: c.Redis.PoolSize,
 	})
 	rdb.AddHook(redisotel.TracingHook{})
-	if err := rdb.Close(); err != nil {
-		fmt.Printf("redis close err:%s\n", err.Error())
+	if c.Cache.Enable {
+		// the cache falls back to the nodes while redis is down, tell early it is
+		if err := rdb.Ping(context.Background()).Err(); err != nil {
+			zap.S().Warnw("redis ping", "addr", rdb.Options().Addr, "err", err)
+		}
 	}
 	return rdb
 }
@@ -0,0 +1,85 @@
+package data
+
+import (
+	"context"
+	"errors"
+
+	"github.com/leondevpt/wallet/trxservice/internal/biz"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type multisigRepo struct {
+	data *Data
+	log  *zap.Logger
+}
+
+// NewMultisigRepo .
+func NewMultisigRepo(data *Data, logger *zap.Logger) biz.MultisigRepo {
+	return &multisigRepo{
+		data: data,
+		log:  logger,
+	}
+}
+
+func (r *multisigRepo) CreateMultisigTx(ctx context.Context, m *biz.MultisigTx) error {
+	return r.data.DB(ctx).Create(m).Error
+}
+
+func (r *multisigRepo) GetMultisigTx(ctx context.Context, id int64) (*biz.MultisigTx, []*biz.MultisigSignature, error) {
+	var m biz.MultisigTx
+	err := r.data.DB(ctx).Take(&m, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, biz.ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	var sigs []*biz.MultisigSignature
+	if err := r.data.DB(ctx).Where("multisig_id = ?", id).Order("id").Find(&sigs).Error; err != nil {
+		return nil, nil, err
+	}
+	return &m, sigs, nil
+}
+
+func (r *multisigRepo) ListMultisigTxs(ctx context.Context, owner string, statuses []biz.MultisigStatus, limit int) ([]*biz.MultisigTx, error) {
+	db := r.data.DB(ctx).Where("status IN ?", statuses)
+	if owner != "" {
+		db = db.Where("owner = ?", owner)
+	}
+	var list []*biz.MultisigTx
+	err := db.Order("id DESC").Limit(limit).Find(&list).Error
+	return list, err
+}
+
+func (r *multisigRepo) AddMultisigSignature(ctx context.Context, m *biz.MultisigTx, sig *biz.MultisigSignature) error {
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		if err := r.data.DB(ctx).Create(sig).Error; err != nil {
+			return err
+		}
+		return r.UpdateMultisigTx(ctx, m)
+	})
+	if isDuplicateKey(err) {
+		return biz.ErrMultisigSigned
+	}
+	return err
+}
+
+func (r *multisigRepo) UpdateMultisigTx(ctx context.Context, m *biz.MultisigTx) error {
+	res := r.data.DB(ctx).Model(&biz.MultisigTx{}).Where("id = ? AND version = ?", m.ID, m.Version).Updates(map[string]interface{}{
+		"weight":     m.Weight,
+		"status":     m.Status,
+		"expiring":   m.Expiring,
+		"last_error": m.LastError,
+		"version":    m.Version + 1,
+		"updated_at": m.UpdatedAt,
+	})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return biz.ErrNotFound
+	}
+	m.Version++
+	return nil
+}
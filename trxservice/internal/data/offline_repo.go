@@ -0,0 +1,46 @@
+package data
+
+import (
+	"context"
+	"errors"
+
+	"github.com/leondevpt/wallet/trxservice/internal/biz"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type offlineRepo struct {
+	data *Data
+	log  *zap.Logger
+}
+
+// NewOfflineRepo .
+func NewOfflineRepo(data *Data, logger *zap.Logger) biz.OfflineRepo {
+	return &offlineRepo{
+		data: data,
+		log:  logger,
+	}
+}
+
+func (r *offlineRepo) CreateOfflineTx(ctx context.Context, o *biz.OfflineTx) error {
+	return r.data.DB(ctx).Create(o).Error
+}
+
+func (r *offlineRepo) GetOfflineTx(ctx context.Context, txid string) (*biz.OfflineTx, error) {
+	var o biz.OfflineTx
+	err := r.data.DB(ctx).Where("txid = ?", txid).Take(&o).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, biz.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func (r *offlineRepo) UpdateOfflineTx(ctx context.Context, o *biz.OfflineTx) error {
+	return r.data.DB(ctx).Model(o).Updates(map[string]interface{}{
+		"status":     o.Status,
+		"updated_at": o.UpdatedAt,
+	}).Error
+}
@@ -0,0 +1,141 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/leondevpt/wallet/trxservice/internal/biz"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type payoutRepo struct {
+	data *Data
+	log  *zap.Logger
+}
+
+// NewPayoutRepo .
+func NewPayoutRepo(data *Data, logger *zap.Logger) biz.PayoutRepo {
+	return &payoutRepo{
+		data: data,
+		log:  logger,
+	}
+}
+
+func (r *payoutRepo) CreatePayoutBatch(ctx context.Context, from string, items []*biz.PayoutItem) (*biz.PayoutBatch, []*biz.PayoutItem, error) {
+	refs := make([]string, 0, len(items))
+	for _, item := range items {
+		refs = append(refs, item.ClientReference)
+	}
+	var (
+		batch  *biz.PayoutBatch
+		stored []*biz.PayoutItem
+	)
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		existing, err := r.itemsByReference(ctx, refs)
+		if err != nil {
+			return err
+		}
+		var fresh []*biz.PayoutItem
+		for _, item := range items {
+			if s, ok := existing[item.ClientReference]; !ok {
+				fresh = append(fresh, item)
+			} else if !s.Matches(item) {
+				return biz.ErrPayoutConflict
+			}
+		}
+		if len(fresh) == 0 {
+			batch = new(biz.PayoutBatch)
+			if err := r.data.DB(ctx).Take(batch, existing[refs[0]].BatchID).Error; err != nil {
+				return err
+			}
+		} else {
+			batch = &biz.PayoutBatch{From: from}
+			if err := r.data.DB(ctx).Create(batch).Error; err != nil {
+				return err
+			}
+			for _, item := range fresh {
+				item.BatchID = batch.ID
+			}
+			// a concurrent submission may have stored some of the references meanwhile
+			if err := r.data.DB(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(fresh).Error; err != nil {
+				return err
+			}
+		}
+
+		if existing, err = r.itemsByReference(ctx, refs); err != nil {
+			return err
+		}
+		for _, item := range items {
+			s, ok := existing[item.ClientReference]
+			if !ok || !s.Matches(item) {
+				return biz.ErrPayoutConflict
+			}
+			stored = append(stored, s)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return batch, stored, nil
+}
+
+func (r *payoutRepo) itemsByReference(ctx context.Context, refs []string) (map[string]*biz.PayoutItem, error) {
+	var list []*biz.PayoutItem
+	if err := r.data.DB(ctx).Where("client_reference IN ?", refs).Find(&list).Error; err != nil {
+		return nil, err
+	}
+	m := make(map[string]*biz.PayoutItem, len(list))
+	for _, item := range list {
+		m[item.ClientReference] = item
+	}
+	return m, nil
+}
+
+func (r *payoutRepo) GetPayoutBatch(ctx context.Context, id int64) (*biz.PayoutBatch, []*biz.PayoutItem, error) {
+	var batch biz.PayoutBatch
+	err := r.data.DB(ctx).Take(&batch, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, biz.ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	var items []*biz.PayoutItem
+	if err := r.data.DB(ctx).Where("batch_id = ?", id).Order("id").Find(&items).Error; err != nil {
+		return nil, nil, err
+	}
+	return &batch, items, nil
+}
+
+func (r *payoutRepo) ListPayouts(ctx context.Context, status biz.PayoutStatus, before time.Time, limit int) ([]*biz.PayoutItem, error) {
+	var list []*biz.PayoutItem
+	err := r.data.DB(ctx).
+		Where("status = ? AND updated_at <= ?", status, before).
+		Order("id").Limit(limit).Find(&list).Error
+	return list, err
+}
+
+func (r *payoutRepo) UpdatePayout(ctx context.Context, p *biz.PayoutItem) error {
+	res := r.data.DB(ctx).Model(&biz.PayoutItem{}).Where("id = ? AND version = ?", p.ID, p.Version).Updates(map[string]interface{}{
+		"status":     p.Status,
+		"txid":       p.Txid,
+		"raw_tx":     p.RawTx,
+		"expires_at": p.ExpiresAt,
+		"attempts":   p.Attempts,
+		"last_error": p.LastError,
+		"version":    p.Version + 1,
+		"updated_at": p.UpdatedAt,
+	})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return biz.ErrNotFound
+	}
+	p.Version++
+	return nil
+}
@@ -0,0 +1,122 @@
+package data
+
+import (
+	"context"
+	"errors"
+
+	"github.com/leondevpt/wallet/trxservice/internal/biz"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type sweepRepo struct {
+	data *Data
+	log  *zap.Logger
+}
+
+// NewSweepRepo .
+func NewSweepRepo(data *Data, logger *zap.Logger) biz.SweepRepo {
+	return &sweepRepo{
+		data: data,
+		log:  logger,
+	}
+}
+
+func (r *sweepRepo) LatestSweepRun(ctx context.Context) (*biz.SweepRun, error) {
+	var run biz.SweepRun
+	err := r.data.DB(ctx).Order("id DESC").Take(&run).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, biz.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *sweepRepo) GetSweepRun(ctx context.Context, id int64) (*biz.SweepRun, error) {
+	var run biz.SweepRun
+	err := r.data.DB(ctx).Take(&run, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, biz.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *sweepRepo) CreateSweepRun(ctx context.Context, run *biz.SweepRun) error {
+	return r.data.InTx(ctx, func(ctx context.Context) error {
+		db := r.data.DB(ctx)
+
+		// 锁住最新的 run, 同时只能有一个未完成的 run
+		var last biz.SweepRun
+		err := db.Clauses(clause.Locking{Strength: "UPDATE"}).Order("id DESC").Take(&last).Error
+		switch {
+		case err == nil:
+			if last.Status != biz.SweepRunDone {
+				return biz.ErrSweepRunning
+			}
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+		return db.Create(run).Error
+	})
+}
+
+func (r *sweepRepo) UpdateSweepRun(ctx context.Context, run *biz.SweepRun) error {
+	return r.data.DB(ctx).Model(run).Updates(map[string]interface{}{
+		"status":      run.Status,
+		"cursor":      run.Cursor,
+		"finished_at": run.FinishedAt,
+	}).Error
+}
+
+func (r *sweepRepo) SavePlan(ctx context.Context, run *biz.SweepRun, tasks []*biz.SweepTask) error {
+	return r.data.InTx(ctx, func(ctx context.Context) error {
+		if len(tasks) > 0 {
+			// a page planned before a crash is planned again
+			if err := r.data.DB(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(tasks).Error; err != nil {
+				return err
+			}
+		}
+		return r.UpdateSweepRun(ctx, run)
+	})
+}
+
+func (r *sweepRepo) ListSweepTasks(ctx context.Context, runID int64) ([]*biz.SweepTask, error) {
+	var list []*biz.SweepTask
+	err := r.data.DB(ctx).Where("run_id = ?", runID).Order("id").Find(&list).Error
+	return list, err
+}
+
+func (r *sweepRepo) UpdateSweepTask(ctx context.Context, t *biz.SweepTask) error {
+	res := r.data.DB(ctx).Model(&biz.SweepTask{}).Where("id = ? AND version = ?", t.ID, t.Version).Updates(map[string]interface{}{
+		"amount":          t.Amount,
+		"status":          t.Status,
+		"fund_amount":     t.FundAmount,
+		"delegate_amount": t.DelegateAmount,
+		"fund_txid":       t.FundTxid,
+		"delegate_txid":   t.DelegateTxid,
+		"sweep_txid":      t.SweepTxid,
+		"reclaim_txid":    t.ReclaimTxid,
+		"raw_tx":          t.RawTx,
+		"expires_at":      t.ExpiresAt,
+		"attempts":        t.Attempts,
+		"last_error":      t.LastError,
+		"failure":         t.Failure,
+		"skip":            t.Skip,
+		"version":         t.Version + 1,
+		"updated_at":      t.UpdatedAt,
+	})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return biz.ErrNotFound
+	}
+	t.Version++
+	return nil
+}
@@ -2,9 +2,13 @@ package data
 
 import (
 	"context"
-	"github.com/leondevpt/wallet/trxservice/internal/biz"
+	"errors"
+	"fmt"
 
+	"github.com/leondevpt/wallet/trxservice/internal/biz"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type trxrRepo struct {
@@ -20,6 +24,282 @@ func NewTrxRepo(data *Data, logger *zap.Logger) biz.TrxRepo {
 	}
 }
 
-func (trxrRepo) ListTransactions(ctx context.Context, addr, token string, pageNum, pageSize, queryType int) ([]interface{}, int, error) {
-	return nil, 0, nil
+func (r *trxrRepo) ListTransactions(ctx context.Context, f *biz.TxFilter) ([]*biz.Tx, int64, error) {
+	db := r.data.DB(ctx).Model(&biz.Tx{})
+	if f.Address != "" {
+		switch f.QueryType {
+		case biz.TxDirectionIn:
+			db = db.Where("to_address = ?", f.Address)
+		case biz.TxDirectionOut:
+			db = db.Where("from_address = ?", f.Address)
+		default:
+			db = db.Where("from_address = ? OR to_address = ?", f.Address, f.Address)
+		}
+	} else if f.QueryType != 0 {
+		db = db.Where("direction = ?", f.QueryType)
+	}
+	if f.Token != "" {
+		db = db.Where("token = ?", f.Token)
+	}
+	if !f.StartTime.IsZero() {
+		db = db.Where("block_time >= ?", f.StartTime)
+	}
+	if !f.EndTime.IsZero() {
+		db = db.Where("block_time < ?", f.EndTime)
+	}
+
+	var (
+		txs   []*biz.Tx
+		total int64
+	)
+	if f.Cursor > 0 {
+		err := db.Where("id < ?", f.Cursor).Order("id DESC").Limit(f.PageSize).Find(&txs).Error
+		return txs, 0, err
+	}
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	err := db.Order("id DESC").Offset((f.PageNum - 1) * f.PageSize).Limit(f.PageSize).Find(&txs).Error
+	return txs, total, err
+}
+
+func (r *trxrRepo) GetCheckpoint(ctx context.Context, name string) (*biz.ScanCheckpoint, error) {
+	var cp biz.ScanCheckpoint
+	err := r.data.DB(ctx).Where("name = ?", name).Take(&cp).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, biz.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &cp, nil
+}
+
+func (r *trxrRepo) SaveBlock(ctx context.Context, cp *biz.ScanCheckpoint, txs []*biz.Tx) ([]*biz.TxEvent, error) {
+	var events []*biz.TxEvent
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		events = events[:0]
+		for _, tx := range txs {
+			event, err := r.createTx(ctx, tx)
+			if err != nil {
+				return err
+			}
+			if event != nil {
+				events = append(events, event)
+			}
+		}
+		block := &biz.ScanBlock{Scanner: cp.Name, Num: cp.BlockNum, Hash: cp.BlockHash}
+		if err := r.data.DB(ctx).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "scanner"}, {Name: "num"}},
+			DoUpdates: clause.AssignmentColumns([]string{"hash"}),
+		}).Create(block).Error; err != nil {
+			return err
+		}
+		return r.saveCheckpoint(ctx, cp)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *trxrRepo) saveCheckpoint(ctx context.Context, cp *biz.ScanCheckpoint) error {
+	return r.data.DB(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"block_num", "block_hash", "updated_at"}),
+	}).Create(cp).Error
+}
+
+func (r *trxrRepo) ListScanBlocks(ctx context.Context, scanner string, from int64) ([]*biz.ScanBlock, error) {
+	var blocks []*biz.ScanBlock
+	err := r.data.DB(ctx).Where("scanner = ? AND num >= ?", scanner, from).Order("num DESC").Find(&blocks).Error
+	return blocks, err
+}
+
+func (r *trxrRepo) PruneScanBlocks(ctx context.Context, scanner string, below int64) error {
+	return r.data.DB(ctx).Where("scanner = ? AND num < ?", scanner, below).Delete(&biz.ScanBlock{}).Error
+}
+
+func (r *trxrRepo) Rollback(ctx context.Context, cp *biz.ScanCheckpoint) ([]*biz.TxEvent, error) {
+	var events []*biz.TxEvent
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		events = events[:0]
+		db := r.data.DB(ctx)
+		var txs []*biz.Tx
+		err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("block_num > ? AND status IN ?", cp.BlockNum, []biz.TxStatus{biz.TxStatusPending, biz.TxStatusConfirmed}).
+			Find(&txs).Error
+		if err != nil {
+			return err
+		}
+		for _, tx := range txs {
+			from := tx.Status
+			if tx.Direction == biz.TxDirectionIn {
+				tx.Status = biz.TxStatusReverted
+			} else {
+				// the withdrawal is most likely included again on the new fork
+				tx.Status, tx.BlockNum, tx.BlockHash, tx.BlockTime = biz.TxStatusPending, 0, "", nil
+			}
+			tx.Confirmations = 0
+			err := db.Model(tx).Updates(map[string]interface{}{
+				"status":        tx.Status,
+				"confirmations": tx.Confirmations,
+				"block_num":     tx.BlockNum,
+				"block_hash":    tx.BlockHash,
+				"block_time":    tx.BlockTime,
+			}).Error
+			if err != nil {
+				return err
+			}
+			if tx.Status == from {
+				continue
+			}
+			event, err := r.createEvent(ctx, tx)
+			if err != nil {
+				return err
+			}
+			events = append(events, event)
+		}
+		if err := db.Where("scanner = ? AND num > ?", cp.Name, cp.BlockNum).Delete(&biz.ScanBlock{}).Error; err != nil {
+			return err
+		}
+		return r.saveCheckpoint(ctx, cp)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *trxrRepo) CreateTx(ctx context.Context, tx *biz.Tx) (*biz.TxEvent, error) {
+	var event *biz.TxEvent
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		event, err = r.createTx(ctx, tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if event == nil {
+		return nil, fmt.Errorf("tx %s/%d already recorded", tx.Txid, tx.LogIndex)
+	}
+	return event, nil
+}
+
+// createTx insert tx with its first event. A reverted tx included again on the new fork
+// is revived, it returns a nil event if tx is already stored otherwise.
+func (r *trxrRepo) createTx(ctx context.Context, tx *biz.Tx) (*biz.TxEvent, error) {
+	db := r.data.DB(ctx)
+	res := db.Clauses(clause.OnConflict{DoNothing: true}).Create(tx)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		res = db.Model(&biz.Tx{}).
+			Where("txid = ? AND log_index = ? AND direction = ? AND status = ?", tx.Txid, tx.LogIndex, tx.Direction, biz.TxStatusReverted).
+			Updates(map[string]interface{}{
+				"status":        tx.Status,
+				"confirmations": tx.Confirmations,
+				"block_num":     tx.BlockNum,
+				"block_hash":    tx.BlockHash,
+				"block_time":    tx.BlockTime,
+			})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return nil, res.Error
+		}
+		if err := db.Where("txid = ? AND log_index = ? AND direction = ?", tx.Txid, tx.LogIndex, tx.Direction).Take(tx).Error; err != nil {
+			return nil, err
+		}
+	}
+	return r.createEvent(ctx, tx)
+}
+
+func (r *trxrRepo) createEvent(ctx context.Context, tx *biz.Tx) (*biz.TxEvent, error) {
+	event := &biz.TxEvent{TxID: tx.ID, Tx: tx, Status: tx.Status, Confirmations: tx.Confirmations}
+	if err := r.data.DB(ctx).Omit(clause.Associations).Create(event).Error; err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func (r *trxrRepo) ListUnfinalizedTxs(ctx context.Context, afterID int64, limit int) ([]*biz.Tx, error) {
+	var txs []*biz.Tx
+	err := r.data.DB(ctx).
+		Where("id > ? AND status IN ?", afterID, []biz.TxStatus{biz.TxStatusPending, biz.TxStatusConfirmed}).
+		Order("id").Limit(limit).Find(&txs).Error
+	return txs, err
+}
+
+func (r *trxrRepo) ListEvents(ctx context.Context, afterSeq int64, limit int) ([]*biz.TxEvent, error) {
+	if err := r.sequenceEvents(ctx); err != nil {
+		return nil, err
+	}
+	var events []*biz.TxEvent
+	err := r.data.DB(ctx).Preload("Tx").
+		Where("seq > ?", afterSeq).
+		Order("seq").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+func (r *trxrRepo) LastEventSeq(ctx context.Context) (int64, error) {
+	if err := r.sequenceEvents(ctx); err != nil {
+		return 0, err
+	}
+	var seq int64
+	err := r.data.DB(ctx).Model(&biz.TxEvent{}).Select("COALESCE(MAX(seq), 0)").Scan(&seq).Error
+	return seq, err
+}
+
+// sequenceEvents give committed events without a Seq the next ones. Sequencers hold the
+// counter row lock until their Seqs commit, so a reader never sees a Seq before a lower one
+// and an event committing late gets a Seq after the cursor instead of being skipped.
+func (r *trxrRepo) sequenceEvents(ctx context.Context) error {
+	const batch = 500
+	return r.data.InTx(ctx, func(ctx context.Context) error {
+		db := r.data.DB(ctx)
+		var counter biz.EventCursor
+		if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("name = ?", eventSeqCounter).Take(&counter).Error; err != nil {
+			return err
+		}
+		var ids []int64
+		if err := db.Model(&biz.TxEvent{}).Where("seq = 0").Order("id").Limit(batch).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		for _, id := range ids {
+			counter.EventID++
+			if err := db.Model(&biz.TxEvent{}).Where("id = ?", id).Update("seq", counter.EventID).Error; err != nil {
+				return err
+			}
+		}
+		return db.Model(&counter).Update("event_id", counter.EventID).Error
+	})
+}
+
+func (r *trxrRepo) UpdateTxStatus(ctx context.Context, tx *biz.Tx, from biz.TxStatus) (*biz.TxEvent, error) {
+	var event *biz.TxEvent
+	err := r.data.InTx(ctx, func(ctx context.Context) error {
+		res := r.data.DB(ctx).Model(tx).Where("status = ?", from).Updates(map[string]interface{}{
+			"status":        tx.Status,
+			"confirmations": tx.Confirmations,
+			"block_num":     tx.BlockNum,
+			"block_time":    tx.BlockTime,
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return biz.ErrNotFound
+		}
+		var err error
+		event, err = r.createEvent(ctx, tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
 }
@@ -0,0 +1,82 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/leondevpt/wallet/trxservice/internal/biz"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type webhookRepo struct {
+	data *Data
+	log  *zap.Logger
+}
+
+// NewWebhookRepo .
+func NewWebhookRepo(data *Data, logger *zap.Logger) biz.WebhookRepo {
+	return &webhookRepo{
+		data: data,
+		log:  logger,
+	}
+}
+
+func (r *webhookRepo) GetEventCursor(ctx context.Context, name string) (int64, error) {
+	var cursor biz.EventCursor
+	err := r.data.DB(ctx).Where("name = ?", name).Take(&cursor).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	return cursor.EventID, err
+}
+
+func (r *webhookRepo) Enqueue(ctx context.Context, name string, eventID int64, deliveries []*biz.WebhookDelivery) error {
+	return r.data.InTx(ctx, func(ctx context.Context) error {
+		db := r.data.DB(ctx)
+		if len(deliveries) > 0 {
+			if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(deliveries).Error; err != nil {
+				return err
+			}
+		}
+		return db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"event_id", "updated_at"}),
+		}).Create(&biz.EventCursor{Name: name, EventID: eventID}).Error
+	})
+}
+
+func (r *webhookRepo) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*biz.WebhookDelivery, error) {
+	var list []*biz.WebhookDelivery
+	err := r.data.DB(ctx).
+		Where("status = ? AND next_attempt_at <= ?", biz.WebhookStatusPending, now).
+		Order("next_attempt_at, id").Limit(limit).Find(&list).Error
+	return list, err
+}
+
+func (r *webhookRepo) UpdateDelivery(ctx context.Context, d *biz.WebhookDelivery) error {
+	return r.data.DB(ctx).Model(d).Updates(map[string]interface{}{
+		"status":          d.Status,
+		"attempts":        d.Attempts,
+		"next_attempt_at": d.NextAttemptAt,
+		"last_error":      d.LastError,
+	}).Error
+}
+
+func (r *webhookRepo) ReplayDeliveries(ctx context.Context, tenant string, ids []int64) (int64, error) {
+	db := r.data.DB(ctx).Model(&biz.WebhookDelivery{}).Where("status = ?", biz.WebhookStatusDead)
+	if tenant != "" {
+		db = db.Where("tenant = ?", tenant)
+	}
+	if len(ids) > 0 {
+		db = db.Where("id IN ?", ids)
+	}
+	res := db.Updates(map[string]interface{}{
+		"status":          biz.WebhookStatusPending,
+		"attempts":        0,
+		"next_attempt_at": time.Now(),
+	})
+	return res.RowsAffected, res.Error
+}
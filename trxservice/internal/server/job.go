@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"sync"
+
+	"github.com/leondevpt/wallet/trxservice/internal/biz"
+
+	"go.uber.org/zap"
+)
+
+// Job is a background worker that runs until ctx is done
+type Job interface {
+	Run(ctx context.Context) error
+}
+
+// JobServer runs background jobs along with the gRPC server
+type JobServer struct {
+	jobs   map[string]Job
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	log    *zap.Logger
+}
+
+// NewJobServer is a convenience func to create a JobServer, disabled jobs are skipped
+func NewJobServer(pool *biz.NodePool, solidity *biz.SolidityNodePool, scanner *biz.BlockScanner, tracker *biz.ConfirmationTracker, webhook *biz.WebhookUsecase,
+	energy *biz.EnergyController, payout *biz.PayoutUsecase, sweep *biz.SweepUsecase,
+	multisig *biz.MultisigUsecase, zapLogger *zap.Logger) *JobServer {
+	s := &JobServer{jobs: make(map[string]Job), log: zapLogger}
+	s.jobs["NodePool"] = pool
+	s.jobs["SolidityNodePool"] = solidity
+	if scanner.Enabled() {
+		s.jobs["BlockScanner"] = scanner
+	}
+	if tracker.Enabled() {
+		s.jobs["ConfirmationTracker"] = tracker
+	}
+	if webhook.Enabled() {
+		s.jobs["Webhook"] = webhook
+	}
+	// switched on and off by config reloads, it idles while disabled
+	s.jobs["EnergyController"] = energy
+	if payout.Enabled() {
+		s.jobs["Payout"] = payout
+	}
+	if sweep.Enabled() {
+		s.jobs["Sweep"] = sweep
+	}
+	if multisig.Enabled() {
+		s.jobs["Multisig"] = multisig
+	}
+	return s
+}
+
+// Start starts all jobs in the background
+func (s *JobServer) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	for name, job := range s.jobs {
+		s.wg.Add(1)
+		go func(name string, job Job) {
+			defer s.wg.Done()
+			s.log.Sugar().Infow("job started", "job", name)
+			if err := job.Run(ctx); err != nil {
+				s.log.Sugar().Errorw("job stopped", "job", name, "err", err)
+			}
+		}(name, job)
+	}
+}
+
+// Stop cancels all jobs and waits for them to return
+func (s *JobServer) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
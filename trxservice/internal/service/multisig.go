@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	pb "github.com/leondevpt/wallet/trxservice/api/v1"
+	"github.com/leondevpt/wallet/trxservice/internal/biz"
+	"github.com/leondevpt/wallet/trxservice/pkg/errcode"
+)
+
+const maxMultisigList = 1000
+
+func (s *TrxService) CreateMultisigTransaction(c context.Context, req *pb.CreateMultisigTransactionRequest) (*pb.MultisigTransaction, error) {
+	if err := s.auth.Check(c); err != nil {
+		return nil, err
+	}
+	if !validAddress(req.Owner) || !validAddress(req.To) {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid address"))
+	}
+	if req.PermissionId < 0 || req.PermissionId == 1 {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("permission_id must be 0 or an active permission"))
+	}
+	ta, err := s.toTransferAmount(c, req.Token, req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.muc.Create(c, &biz.MultisigTx{
+		Owner: req.Owner, PermissionID: req.PermissionId, To: req.To,
+		Token: ta.token, Contract: ta.contract, FeeLimit: ta.feeLimit, Amount: ta.amount,
+	})
+	if err != nil {
+		s.log.Sugar().Errorw("CreateMultisigTransaction", "owner", req.Owner, "permission", req.PermissionId, "to", req.To,
+			"token", req.Token, "amount", req.Amount, "err", err)
+		if errors.Is(err, biz.ErrInsufficientBalance) {
+			return nil, errcode.TogRPCError(errcode.InsufficientBalance)
+		}
+		if errors.Is(err, biz.ErrFeeLimitExceeded) {
+			return nil, errcode.TogRPCError(errcode.FeeLimitExceeded.WithDetails(err.Error()))
+		}
+		return nil, err
+	}
+	return s.toMultisigTransaction(c, tx, nil)
+}
+
+func (s *TrxService) SignMultisigTransaction(c context.Context, req *pb.SignMultisigTransactionRequest) (*pb.MultisigTransaction, error) {
+	if err := s.auth.Check(c); err != nil {
+		return nil, err
+	}
+	signature, err := hex.DecodeString(strings.TrimPrefix(req.Signature, "0x"))
+	if err != nil || len(signature) != 65 {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("signature must be 65 bytes of hex"))
+	}
+	tx, sigs, err := s.muc.Sign(c, req.Id, signature)
+	if err != nil {
+		s.log.Sugar().Errorw("SignMultisigTransaction", "id", req.Id, "err", err)
+		switch {
+		case errors.Is(err, biz.ErrNotFound):
+			return nil, errcode.TogRPCError(errcode.NotFound.WithDetails(fmt.Sprintf("multisig transaction %d", req.Id)))
+		case errors.Is(err, biz.ErrMultisigClosed):
+			return nil, errcode.TogRPCError(errcode.MultisigClosed)
+		case errors.Is(err, biz.ErrMultisigSigned):
+			return nil, errcode.TogRPCError(errcode.MultisigSigned)
+		case errors.Is(err, biz.ErrInvalidSignature):
+			return nil, errcode.TogRPCError(errcode.InvalidSignature.WithDetails(err.Error()))
+		}
+		return nil, err
+	}
+	return s.toMultisigTransaction(c, tx, sigs)
+}
+
+func (s *TrxService) GetMultisigTransaction(c context.Context, req *pb.GetMultisigTransactionRequest) (*pb.MultisigTransaction, error) {
+	if err := s.auth.Check(c); err != nil {
+		return nil, err
+	}
+	tx, sigs, err := s.muc.Get(c, req.Id)
+	if err != nil {
+		if errors.Is(err, biz.ErrNotFound) {
+			return nil, errcode.TogRPCError(errcode.NotFound.WithDetails(fmt.Sprintf("multisig transaction %d", req.Id)))
+		}
+		s.log.Sugar().Errorw("GetMultisigTransaction", "id", req.Id, "err", err)
+		return nil, err
+	}
+	return s.toMultisigTransaction(c, tx, sigs)
+}
+
+func (s *TrxService) ListMultisigTransactions(c context.Context, req *pb.ListMultisigTransactionsRequest) (*pb.ListMultisigTransactionsReply, error) {
+	if err := s.auth.Check(c); err != nil {
+		return nil, err
+	}
+	if req.Owner != "" && !validAddress(req.Owner) {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid address"))
+	}
+	statuses := make([]biz.MultisigStatus, 0, len(req.Status))
+	for _, st := range req.Status {
+		switch biz.MultisigStatus(st) {
+		case biz.MultisigStatusPending, biz.MultisigStatusApproved, biz.MultisigStatusSent, biz.MultisigStatusExpired,
+			biz.MultisigStatusFailed:
+			statuses = append(statuses, biz.MultisigStatus(st))
+		default:
+			return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid status " + st))
+		}
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = 100
+	} else if limit > maxMultisigList {
+		limit = maxMultisigList
+	}
+	list, err := s.muc.List(c, req.Owner, statuses, limit)
+	if err != nil {
+		s.log.Sugar().Errorw("ListMultisigTransactions", "owner", req.Owner, "err", err)
+		return nil, err
+	}
+	reply := &pb.ListMultisigTransactionsReply{List: make([]*pb.MultisigTransaction, 0, len(list))}
+	for _, tx := range list {
+		t, err := s.toMultisigTransaction(c, tx, nil)
+		if err != nil {
+			s.log.Sugar().Errorw("ListMultisigTransactions", "token", tx.Token, "err", err)
+			return nil, err
+		}
+		reply.List = append(reply.List, t)
+	}
+	return reply, nil
+}
+
+func (s *TrxService) toMultisigTransaction(c context.Context, tx *biz.MultisigTx, sigs []*biz.MultisigSignature) (*pb.MultisigTransaction, error) {
+	amount, err := s.formatAmount(c, tx.Token, tx.Amount)
+	if err != nil {
+		return nil, err
+	}
+	reply := &pb.MultisigTransaction{
+		Id:           tx.ID,
+		Txid:         tx.Txid,
+		Owner:        tx.Owner,
+		PermissionId: tx.PermissionID,
+		To:           tx.To,
+		Token:        tx.Token,
+		Amount:       amount,
+		RawData:      tx.RawData,
+		Threshold:    tx.Threshold,
+		Weight:       tx.Weight,
+		Status:       string(tx.Status),
+		Expiring:     tx.Expiring,
+		ExpiresAt:    tx.ExpiresAt.Unix(),
+		Error:        tx.LastError,
+	}
+	for _, sig := range sigs {
+		reply.Signatures = append(reply.Signatures, &pb.MultisigSignature{Signer: sig.Signer, SignedAt: sig.CreatedAt.Unix()})
+	}
+	return reply, nil
+}
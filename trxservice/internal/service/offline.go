@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/leondevpt/wallet/trxservice/api/v1"
+	"github.com/leondevpt/wallet/trxservice/internal/biz"
+	"github.com/leondevpt/wallet/trxservice/pkg/bundle"
+	"github.com/leondevpt/wallet/trxservice/pkg/errcode"
+)
+
+const (
+	defaultOfflineExpiration = 3600
+	maxOfflineExpiration     = 86400 - 60 // a day less a minute for the head block lagging behind
+)
+
+func (s *TrxService) ExportTransaction(c context.Context, req *pb.ExportTransactionRequest) (*pb.ExportTransactionReply, error) {
+	if err := s.auth.Check(c); err != nil {
+		return nil, err
+	}
+	if !validAddress(req.From) || !validAddress(req.To) {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid address"))
+	}
+	if req.Format != "" && req.Format != bundle.FormatJSON && req.Format != bundle.FormatHex {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("format must be json or hex"))
+	}
+	expiration := req.ExpireSeconds
+	if expiration == 0 {
+		expiration = defaultOfflineExpiration
+	}
+	if expiration < 0 || expiration > maxOfflineExpiration {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails(fmt.Sprintf("expire_seconds must be at most %d", maxOfflineExpiration)))
+	}
+	token := req.Token
+	if token == "" {
+		token = biz.TokenTRX
+	}
+	ta, err := s.toTransferAmount(c, token, req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	o, tx, err := s.ouc.Export(c, &biz.OfflineTx{
+		From: req.From, To: req.To, Token: ta.token, Contract: ta.contract, Amount: ta.amount, FeeLimit: ta.feeLimit,
+	}, req.FeeLimit, time.Duration(expiration)*time.Second)
+	if err != nil {
+		s.log.Sugar().Errorw("ExportTransaction", "from", req.From, "to", req.To, "token", token, "amount", req.Amount, "err", err)
+		if errors.Is(err, biz.ErrInsufficientBalance) {
+			return nil, errcode.TogRPCError(errcode.InsufficientBalance)
+		}
+		if errors.Is(err, biz.ErrFeeLimitExceeded) {
+			return nil, errcode.TogRPCError(errcode.FeeLimitExceeded.WithDetails(err.Error()))
+		}
+		return nil, err
+	}
+	summary, err := s.offlineSummary(c, o)
+	if err != nil {
+		return nil, err
+	}
+	b, err := bundle.New(tx.Transaction, tx.Txid, o.From, summary)
+	if err != nil {
+		return nil, err
+	}
+	out, err := bundle.Encode(b, req.Format)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ExportTransactionReply{Txid: o.Txid, Expiration: b.Expiration, Bundle: out}, nil
+}
+
+func (s *TrxService) ImportTransaction(c context.Context, req *pb.ImportTransactionRequest) (*pb.ImportTransactionReply, error) {
+	if err := s.auth.Check(c); err != nil {
+		return nil, err
+	}
+	b, _, err := bundle.Decode(req.Bundle)
+	if err != nil {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails(err.Error()))
+	}
+	tx, err := bundle.Transaction(b)
+	if err != nil {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails(err.Error()))
+	}
+	o, err := s.ouc.Import(c, tx)
+	if err != nil {
+		s.log.Sugar().Errorw("ImportTransaction", "txid", b.Txid, "err", err)
+		if errors.Is(err, biz.ErrOfflineMismatch) {
+			return nil, errcode.TogRPCError(errcode.OfflineMismatch.WithDetails(err.Error()))
+		}
+		if errors.Is(err, biz.ErrOfflineExpired) {
+			return nil, errcode.TogRPCError(errcode.OfflineExpired)
+		}
+		return nil, broadcastError(err)
+	}
+	s.log.Sugar().Infow("ImportTransaction", "txid", o.Txid, "from", o.From)
+	return &pb.ImportTransactionReply{Txid: o.Txid}, nil
+}
+
+// offlineSummary describe an exported transfer for the offline signer
+func (s *TrxService) offlineSummary(c context.Context, o *biz.OfflineTx) (string, error) {
+	amount, err := s.formatAmount(c, o.Token, o.Amount)
+	if err != nil {
+		return "", err
+	}
+	expires := o.ExpiresAt.UTC().Format(time.RFC3339)
+	if o.Contract == "" {
+		return fmt.Sprintf("send %s TRX from %s to %s, expires %s", amount, o.From, o.To, expires), nil
+	}
+	return fmt.Sprintf("send %s %s (%s) from %s to %s, fee limit %s TRX, expires %s", amount, o.Token, o.Contract, o.From, o.To,
+		sunToTRX(o.FeeLimit), expires), nil
+}
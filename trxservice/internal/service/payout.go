@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	pb "github.com/leondevpt/wallet/trxservice/api/v1"
+	"github.com/leondevpt/wallet/trxservice/internal/biz"
+	"github.com/leondevpt/wallet/trxservice/pkg/errcode"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	maxPayoutItems        = 1000
+	maxClientReferenceLen = 64
+)
+
+func (s *TrxService) SubmitPayoutBatch(c context.Context, req *pb.SubmitPayoutBatchRequest) (*pb.PayoutBatchReply, error) {
+	if err := s.auth.Check(c); err != nil {
+		return nil, err
+	}
+	if !validAddress(req.From) {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid address"))
+	}
+	if len(req.Items) == 0 || len(req.Items) > maxPayoutItems {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails(fmt.Sprintf("1 to %d items are required", maxPayoutItems)))
+	}
+	items := make([]*biz.PayoutItem, 0, len(req.Items))
+	refs := make(map[string]bool, len(req.Items))
+	for _, it := range req.Items {
+		item, err := s.toPayoutItem(c, it)
+		if err != nil {
+			return nil, err
+		}
+		if refs[item.ClientReference] {
+			return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("duplicate client_reference " + item.ClientReference))
+		}
+		refs[item.ClientReference] = true
+		items = append(items, item)
+	}
+
+	batch, stored, err := s.puc.Submit(c, req.From, items)
+	if err != nil {
+		s.log.Sugar().Errorw("SubmitPayoutBatch", "from", req.From, "items", len(items), "err", err)
+		if errors.Is(err, biz.ErrPayoutConflict) {
+			return nil, errcode.TogRPCError(errcode.PayoutConflict)
+		}
+		return nil, err
+	}
+	s.log.Sugar().Infow("SubmitPayoutBatch", "from", req.From, "items", len(items), "batch", batch.ID)
+	return s.toPayoutBatchReply(c, batch, stored)
+}
+
+func (s *TrxService) GetPayoutBatch(c context.Context, req *pb.GetPayoutBatchRequest) (*pb.PayoutBatchReply, error) {
+	if err := s.auth.Check(c); err != nil {
+		return nil, err
+	}
+	batch, items, err := s.puc.GetBatch(c, req.BatchId)
+	if err != nil {
+		if errors.Is(err, biz.ErrNotFound) {
+			return nil, errcode.TogRPCError(errcode.NotFound.WithDetails(fmt.Sprintf("batch %d", req.BatchId)))
+		}
+		s.log.Sugar().Errorw("GetPayoutBatch", "batch", req.BatchId, "err", err)
+		return nil, err
+	}
+	return s.toPayoutBatchReply(c, batch, items)
+}
+
+// toPayoutItem validate a requested payout and convert its amount to the token's smallest unit
+func (s *TrxService) toPayoutItem(c context.Context, it *pb.PayoutItem) (*biz.PayoutItem, error) {
+	if it.ClientReference == "" || len(it.ClientReference) > maxClientReferenceLen {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails(fmt.Sprintf("client_reference of 1 to %d characters is required", maxClientReferenceLen)))
+	}
+	if !validAddress(it.Address) {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid address " + it.Address))
+	}
+	ta, err := s.toTransferAmount(c, it.Token, it.Amount)
+	if err != nil {
+		return nil, err
+	}
+	return &biz.PayoutItem{
+		ClientReference: it.ClientReference, To: it.Address,
+		Token: ta.token, Contract: ta.contract, FeeLimit: ta.feeLimit, Amount: ta.amount,
+	}, nil
+}
+
+// transferAmount is the validated token and amount of a requested transfer
+type transferAmount struct {
+	token    string
+	contract string // empty for TRX
+	feeLimit int64
+	amount   decimal.Decimal // in the token's smallest unit
+}
+
+// toTransferAmount validate token and amount and convert amount to the token's smallest unit
+func (s *TrxService) toTransferAmount(c context.Context, token, amount string) (*transferAmount, error) {
+	ta := &transferAmount{token: biz.TokenTRX}
+	var decimals int32 = 6
+	if !strings.EqualFold(token, biz.TokenTRX) {
+		ok, tokenInfo := checkTokenSupport(token)
+		if !ok {
+			return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails(fmt.Sprintf("token %s not support", token)))
+		}
+		ta.token, ta.contract, ta.feeLimit = strings.ToUpper(token), tokenInfo.ContractAddr, tokenInfo.FeeLimit
+		d, err := s.uc.TokenDecimals(c, tokenInfo)
+		if err != nil {
+			return nil, err
+		}
+		decimals = d
+	}
+	d, err := decimal.NewFromString(amount)
+	if err != nil || !d.IsPositive() {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid amount " + amount))
+	}
+	ta.amount = d.Shift(decimals)
+	if !ta.amount.IsInteger() {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails(fmt.Sprintf("amount exceeds %d decimals", decimals)))
+	}
+	return ta, nil
+}
+
+func (s *TrxService) toPayoutBatchReply(c context.Context, batch *biz.PayoutBatch, items []*biz.PayoutItem) (*pb.PayoutBatchReply, error) {
+	reply := &pb.PayoutBatchReply{BatchId: batch.ID, From: batch.From, Items: make([]*pb.PayoutItemStatus, 0, len(items))}
+	for _, item := range items {
+		amount, err := s.formatAmount(c, item.Token, item.Amount)
+		if err != nil {
+			return nil, err
+		}
+		reply.Items = append(reply.Items, &pb.PayoutItemStatus{
+			Id:              item.ID,
+			BatchId:         item.BatchID,
+			ClientReference: item.ClientReference,
+			Address:         item.To,
+			Token:           item.Token,
+			Amount:          amount,
+			Status:          string(item.Status),
+			Txid:            item.Txid,
+			Error:           item.LastError,
+		})
+	}
+	return reply, nil
+}
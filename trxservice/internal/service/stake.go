@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	pb "github.com/leondevpt/wallet/trxservice/api/v1"
+	"github.com/leondevpt/wallet/trxservice/internal/biz"
+	"github.com/leondevpt/wallet/trxservice/pkg/errcode"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/proto"
+)
+
+const maxResourceAddresses = 20
+
+func (s *TrxService) FreezeBalanceV2(c context.Context, req *pb.FreezeBalanceV2Request) (*pb.StakeReply, error) {
+	if err := s.auth.Check(c); err != nil {
+		return nil, err
+	}
+	if !validAddress(req.Owner) {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid address"))
+	}
+	sun, err := parseTRXAmount(req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.uc.FreezeBalanceV2(c, req.Owner, sun, core.ResourceCode(req.Resource))
+	if err != nil {
+		s.log.Sugar().Errorw("FreezeBalanceV2", "owner", req.Owner, "amount", req.Amount, "resource", req.Resource, "err", err)
+		return nil, broadcastError(err)
+	}
+	s.log.Sugar().Infow("FreezeBalanceV2", "owner", req.Owner, "amount", req.Amount, "resource", req.Resource, "txid", hex.EncodeToString(tx.Txid))
+	return toStakeReply(tx)
+}
+
+func (s *TrxService) UnfreezeBalanceV2(c context.Context, req *pb.UnfreezeBalanceV2Request) (*pb.StakeReply, error) {
+	if err := s.auth.Check(c); err != nil {
+		return nil, err
+	}
+	if !validAddress(req.Owner) {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid address"))
+	}
+	sun, err := parseTRXAmount(req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.uc.UnfreezeBalanceV2(c, req.Owner, sun, core.ResourceCode(req.Resource))
+	if err != nil {
+		s.log.Sugar().Errorw("UnfreezeBalanceV2", "owner", req.Owner, "amount", req.Amount, "resource", req.Resource, "err", err)
+		return nil, broadcastError(err)
+	}
+	s.log.Sugar().Infow("UnfreezeBalanceV2", "owner", req.Owner, "amount", req.Amount, "resource", req.Resource, "txid", hex.EncodeToString(tx.Txid))
+	return toStakeReply(tx)
+}
+
+func (s *TrxService) WithdrawExpireUnfreeze(c context.Context, req *pb.WithdrawExpireUnfreezeRequest) (*pb.StakeReply, error) {
+	if err := s.auth.Check(c); err != nil {
+		return nil, err
+	}
+	if !validAddress(req.Owner) {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid address"))
+	}
+	tx, err := s.uc.WithdrawExpireUnfreeze(c, req.Owner)
+	if err != nil {
+		s.log.Sugar().Errorw("WithdrawExpireUnfreeze", "owner", req.Owner, "err", err)
+		return nil, broadcastError(err)
+	}
+	s.log.Sugar().Infow("WithdrawExpireUnfreeze", "owner", req.Owner, "txid", hex.EncodeToString(tx.Txid))
+	return toStakeReply(tx)
+}
+
+func (s *TrxService) DelegateResource(c context.Context, req *pb.DelegateResourceRequest) (*pb.StakeReply, error) {
+	if err := s.auth.Check(c); err != nil {
+		return nil, err
+	}
+	if !validAddress(req.Owner) || !validAddress(req.Receiver) {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid address"))
+	}
+	sun, err := parseTRXAmount(req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.uc.DelegateResource(c, req.Owner, req.Receiver, sun, core.ResourceCode(req.Resource), req.Lock)
+	if err != nil {
+		s.log.Sugar().Errorw("DelegateResource", "owner", req.Owner, "receiver", req.Receiver, "amount", req.Amount, "resource", req.Resource, "err", err)
+		return nil, broadcastError(err)
+	}
+	s.log.Sugar().Infow("DelegateResource", "owner", req.Owner, "receiver", req.Receiver, "amount", req.Amount, "resource", req.Resource, "txid", hex.EncodeToString(tx.Txid))
+	return toStakeReply(tx)
+}
+
+func (s *TrxService) UnDelegateResource(c context.Context, req *pb.UnDelegateResourceRequest) (*pb.StakeReply, error) {
+	if err := s.auth.Check(c); err != nil {
+		return nil, err
+	}
+	if !validAddress(req.Owner) || !validAddress(req.Receiver) {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid address"))
+	}
+	sun, err := parseTRXAmount(req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.uc.UnDelegateResource(c, req.Owner, req.Receiver, sun, core.ResourceCode(req.Resource))
+	if err != nil {
+		s.log.Sugar().Errorw("UnDelegateResource", "owner", req.Owner, "receiver", req.Receiver, "amount", req.Amount, "resource", req.Resource, "err", err)
+		return nil, broadcastError(err)
+	}
+	s.log.Sugar().Infow("UnDelegateResource", "owner", req.Owner, "receiver", req.Receiver, "amount", req.Amount, "resource", req.Resource, "txid", hex.EncodeToString(tx.Txid))
+	return toStakeReply(tx)
+}
+
+func (s *TrxService) GetAccountResources(c context.Context, req *pb.GetAccountResourcesRequest) (*pb.GetAccountResourcesReply, error) {
+	if err := s.auth.Check(c); err != nil {
+		return nil, err
+	}
+	if len(req.Addresses) == 0 || len(req.Addresses) > maxResourceAddresses {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails(fmt.Sprintf("1 to %d addresses are required", maxResourceAddresses)))
+	}
+	reply := &pb.GetAccountResourcesReply{List: make([]*pb.AccountResources, 0, len(req.Addresses))}
+	for _, addr := range req.Addresses {
+		if !validAddress(addr) {
+			return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid address " + addr))
+		}
+		r, err := s.uc.GetAccountResources(c, addr)
+		if err != nil {
+			s.log.Sugar().Errorw("GetAccountResources", "addr", addr, "err", err)
+			return nil, err
+		}
+		reply.List = append(reply.List, toPbAccountResources(r))
+	}
+	return reply, nil
+}
+
+// parseTRXAmount convert a decimal TRX amount to SUN
+func parseTRXAmount(amount string) (int64, error) {
+	d, err := decimal.NewFromString(amount)
+	if err != nil || !d.IsPositive() {
+		return 0, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid amount"))
+	}
+	sun := d.Shift(6)
+	if !sun.IsInteger() {
+		return 0, errcode.TogRPCError(errcode.InvalidParams.WithDetails("amount exceeds 6 decimals"))
+	}
+	return sun.IntPart(), nil
+}
+
+func sunToTRX(sun int64) string {
+	return decimal.New(sun, -6).String()
+}
+
+func toStakeReply(tx *api.TransactionExtention) (*pb.StakeReply, error) {
+	raw, err := proto.Marshal(tx.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.StakeReply{
+		Txid:           hex.EncodeToString(tx.Txid),
+		Expiration:     tx.Transaction.GetRawData().GetExpiration(),
+		RawTransaction: hex.EncodeToString(raw),
+	}, nil
+}
+
+func toPbAccountResources(r *biz.AccountResources) *pb.AccountResources {
+	p := &pb.AccountResources{
+		Address:            r.Address,
+		FreeBandwidthLimit: r.FreeBandwidthLimit,
+		FreeBandwidthUsed:  r.FreeBandwidthUsed,
+		BandwidthLimit:     r.BandwidthLimit,
+		BandwidthUsed:      r.BandwidthUsed,
+		EnergyLimit:        r.EnergyLimit,
+		EnergyUsed:         r.EnergyUsed,
+		FrozenBandwidth:    sunToTRX(r.FrozenBandwidth),
+		FrozenEnergy:       sunToTRX(r.FrozenEnergy),
+		Withdrawable:       sunToTRX(r.Withdrawable),
+		DelegatedOut:       toPbDelegations(r.DelegatedOut),
+		DelegatedIn:        toPbDelegations(r.DelegatedIn),
+	}
+	for _, u := range r.Unfreezing {
+		p.Unfreezing = append(p.Unfreezing, &pb.Unfreeze{
+			Resource:   pb.Resource(u.Resource),
+			Amount:     sunToTRX(u.Amount),
+			ExpireTime: u.ExpireTime.Unix(),
+		})
+	}
+	return p
+}
+
+func toPbDelegations(ds []*biz.Delegation) []*pb.Delegation {
+	list := make([]*pb.Delegation, 0, len(ds))
+	for _, d := range ds {
+		list = append(list, &pb.Delegation{
+			From:                   d.From,
+			To:                     d.To,
+			Bandwidth:              sunToTRX(d.Bandwidth),
+			Energy:                 sunToTRX(d.Energy),
+			ExpireTimeForBandwidth: unixOrZero(d.ExpireTimeForBandwidth),
+			ExpireTimeForEnergy:    unixOrZero(d.ExpireTimeForEnergy),
+		})
+	}
+	return list
+}
+
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pb "github.com/leondevpt/wallet/trxservice/api/v1"
+	"github.com/leondevpt/wallet/trxservice/internal/biz"
+	"github.com/leondevpt/wallet/trxservice/pkg/errcode"
+)
+
+func (s *TrxService) StartSweep(c context.Context, req *pb.StartSweepRequest) (*pb.SweepRunReply, error) {
+	if err := s.auth.Check(c); err != nil {
+		return nil, err
+	}
+	run, err := s.suc.Start(c, req.DryRun)
+	if err != nil {
+		if errors.Is(err, biz.ErrSweepDisabled) || errors.Is(err, biz.ErrSweepRunning) {
+			return nil, errcode.TogRPCError(errcode.SweepUnavailable.WithDetails(err.Error()))
+		}
+		s.log.Sugar().Errorw("StartSweep", "dry_run", req.DryRun, "err", err)
+		return nil, err
+	}
+	s.log.Sugar().Infow("StartSweep", "run", run.ID, "dry_run", req.DryRun)
+	return s.toSweepRunReply(c, run, nil)
+}
+
+func (s *TrxService) GetSweepRun(c context.Context, req *pb.GetSweepRunRequest) (*pb.SweepRunReply, error) {
+	if err := s.auth.Check(c); err != nil {
+		return nil, err
+	}
+	run, tasks, err := s.suc.GetRun(c, req.RunId)
+	if err != nil {
+		if errors.Is(err, biz.ErrNotFound) {
+			return nil, errcode.TogRPCError(errcode.NotFound.WithDetails(fmt.Sprintf("sweep run %d", req.RunId)))
+		}
+		s.log.Sugar().Errorw("GetSweepRun", "run", req.RunId, "err", err)
+		return nil, err
+	}
+	return s.toSweepRunReply(c, run, tasks)
+}
+
+func (s *TrxService) toSweepRunReply(c context.Context, run *biz.SweepRun, tasks []*biz.SweepTask) (*pb.SweepRunReply, error) {
+	reply := &pb.SweepRunReply{
+		RunId:     run.ID,
+		DryRun:    run.DryRun,
+		Status:    string(run.Status),
+		CreatedAt: run.CreatedAt.Unix(),
+		Tasks:     make([]*pb.SweepTask, 0, len(tasks)),
+	}
+	if run.FinishedAt != nil {
+		reply.FinishedAt = run.FinishedAt.Unix()
+	}
+	for _, t := range tasks {
+		amount, err := s.formatAmount(c, t.Token, t.Amount)
+		if err != nil {
+			return nil, err
+		}
+		reply.Tasks = append(reply.Tasks, &pb.SweepTask{
+			Id:             t.ID,
+			Address:        t.Address,
+			Token:          t.Token,
+			Amount:         amount,
+			Status:         string(t.Status),
+			FundAmount:     sunToTRX(t.FundAmount),
+			DelegateAmount: sunToTRX(t.DelegateAmount),
+			FundTxid:       t.FundTxid,
+			DelegateTxid:   t.DelegateTxid,
+			SweepTxid:      t.SweepTxid,
+			ReclaimTxid:    t.ReclaimTxid,
+			Error:          t.LastError,
+		})
+	}
+	return reply, nil
+}
@@ -2,37 +2,58 @@ package service
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 	pb "github.com/leondevpt/wallet/trxservice/api/v1"
 	"github.com/leondevpt/wallet/trxservice/internal/biz"
+	"github.com/leondevpt/wallet/trxservice/pkg/errcode"
 	"github.com/leondevpt/wallet/trxservice/pkg/setting"
 
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
 	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
 )
 
 var _ pb.TrxServiceServer = &TrxService{}
 
+const (
+	maxSubscribeAddresses = 100
+	// lastEventIDMetadata is the Last-Event-ID header of a reconnecting EventSource
+	lastEventIDMetadata = "last-event-id"
+)
+
 type TrxService struct {
 	auth *Auth
 	uc   *biz.TrxUsecase
+	auc  *biz.AddressUsecase
+	wuc  *biz.WebhookUsecase
+	puc  *biz.PayoutUsecase
+	suc  *biz.SweepUsecase
+	muc  *biz.MultisigUsecase
+	ouc  *biz.OfflineUsecase
 	pb.UnimplementedTrxServiceServer
 	log *zap.Logger
 }
 
-func NewTrxService(uc *biz.TrxUsecase, log *zap.Logger) pb.TrxServiceServer {
-	return &TrxService{uc: uc, log: log, auth: &Auth{}}
+func NewTrxService(uc *biz.TrxUsecase, auc *biz.AddressUsecase, wuc *biz.WebhookUsecase, puc *biz.PayoutUsecase, suc *biz.SweepUsecase,
+	muc *biz.MultisigUsecase, ouc *biz.OfflineUsecase, log *zap.Logger) pb.TrxServiceServer {
+	return &TrxService{uc: uc, auc: auc, wuc: wuc, puc: puc, suc: suc, muc: muc, ouc: ouc, log: log, auth: &Auth{}}
 }
 
 func (s *TrxService) GetTrxBalance(c context.Context, req *pb.GetTrxBalanceRequest) (*pb.GetTrxBalanceReply, error) {
 	if err := s.auth.Check(c); err != nil {
 		return nil, err
 	}
-	bigBalance, err := s.uc.GetBalance(c, req.Address)
+	bigBalance, err := s.uc.GetBalance(c, req.Address, req.Confirmed)
 	if err != nil {
-		s.log.Sugar().Errorw("GetTrxBalance", "addr", req.Address, "err", err)
-		return nil, err
+		s.log.Sugar().Errorw("GetTrxBalance", "addr", req.Address, "confirmed", req.Confirmed, "err", err)
+		return nil, solidityError(err)
 	}
 
 	d := decimal.NewFromBigInt(bigBalance, 0)
@@ -50,21 +71,377 @@ func (s *TrxService) GetTRC20TokenBalance(c context.Context, req *pb.GetTRC20Tok
 		return nil, fmt.Errorf("token %s not support", req.Token)
 	}
 
-	bigBalance, err := s.uc.GetTRC20TokenBalance(c, req.Address, tokenInfo.ContractAddr)
+	bigBalance, err := s.uc.GetTRC20TokenBalance(c, req.Address, tokenInfo.ContractAddr, req.Confirmed)
 	if err != nil {
-		s.log.Sugar().Errorw("GetTRC20TokenBalance", "addr", req.Address, "token", req.Token, "err", err)
-		return nil, err
+		s.log.Sugar().Errorw("GetTRC20TokenBalance", "addr", req.Address, "token", req.Token, "confirmed", req.Confirmed, "err", err)
+		return nil, solidityError(err)
 	}
 
+	decimals, err := s.uc.TokenDecimals(c, tokenInfo)
+	if err != nil {
+		s.log.Sugar().Errorw("GetTRC20TokenBalance", "token", req.Token, "err", err)
+		return nil, err
+	}
 	d := decimal.NewFromBigInt(bigBalance, 0)
 
-	result := d.Div(decimal.New(1, int32(tokenInfo.Decimal)))
+	result := d.Div(decimal.New(1, decimals))
 
 	s.log.Sugar().Infow("GetTRC20TokenBalance", "addr", req.Address, "token", req.Token, "balance", result.String())
 
 	return &pb.GetTRC20TokenBalanceReply{Token: req.Token, Balance: result.String()}, nil
 }
 
+func (s *TrxService) TransferTrx(c context.Context, req *pb.TransferTrxRequest) (*pb.TransferTrxReply, error) {
+	if err := s.auth.Check(c); err != nil {
+		return nil, err
+	}
+	if !validAddress(req.From) || !validAddress(req.To) {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid address"))
+	}
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil || !amount.IsPositive() {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid amount"))
+	}
+	sun := amount.Shift(6)
+	if !sun.IsInteger() {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("amount exceeds 6 decimals"))
+	}
+
+	tx, err := s.uc.TransferTrx(c, req.From, req.To, sun.IntPart())
+	if err != nil {
+		s.log.Sugar().Errorw("TransferTrx", "from", req.From, "to", req.To, "amount", req.Amount, "err", err)
+		if errors.Is(err, biz.ErrInsufficientBalance) {
+			return nil, errcode.TogRPCError(errcode.InsufficientBalance)
+		}
+		if errors.Is(err, biz.ErrSignerNotConfigured) {
+			return nil, errcode.TogRPCError(errcode.SignerNotConfigured)
+		}
+		return nil, broadcastError(err)
+	}
+
+	raw, err := proto.Marshal(tx.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	txid := hex.EncodeToString(tx.Txid)
+	s.log.Sugar().Infow("TransferTrx", "from", req.From, "to", req.To, "amount", req.Amount, "txid", txid)
+	return &pb.TransferTrxReply{
+		Txid:           txid,
+		Expiration:     tx.Transaction.GetRawData().GetExpiration(),
+		RawTransaction: hex.EncodeToString(raw),
+	}, nil
+}
+
+func (s *TrxService) TransferTRC20(c context.Context, req *pb.TransferTRC20Request) (*pb.TransferTRC20Reply, error) {
+	if err := s.auth.Check(c); err != nil {
+		return nil, err
+	}
+	ok, tokenInfo := checkTokenSupport(req.Token)
+	if !ok {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails(fmt.Sprintf("token %s not support", req.Token)))
+	}
+	if !validAddress(req.From) || !validAddress(req.To) {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid address"))
+	}
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil || !amount.IsPositive() {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid amount"))
+	}
+	decimals, err := s.uc.TokenDecimals(c, tokenInfo)
+	if err != nil {
+		s.log.Sugar().Errorw("TransferTRC20", "token", req.Token, "err", err)
+		return nil, err
+	}
+	value := amount.Shift(decimals)
+	if !value.IsInteger() {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails(fmt.Sprintf("amount exceeds %d decimals", decimals)))
+	}
+
+	tx, err := s.uc.TransferTRC20(c, strings.ToUpper(req.Token), req.From, req.To, tokenInfo.ContractAddr, value.BigInt(), req.FeeLimit, tokenInfo.FeeLimit)
+	if err != nil {
+		s.log.Sugar().Errorw("TransferTRC20", "from", req.From, "to", req.To, "token", req.Token, "amount", req.Amount, "err", err)
+		if errors.Is(err, biz.ErrInsufficientBalance) {
+			return nil, errcode.TogRPCError(errcode.InsufficientBalance)
+		}
+		if errors.Is(err, biz.ErrSignerNotConfigured) {
+			return nil, errcode.TogRPCError(errcode.SignerNotConfigured)
+		}
+		if errors.Is(err, biz.ErrFeeLimitExceeded) {
+			return nil, errcode.TogRPCError(errcode.FeeLimitExceeded.WithDetails(err.Error()))
+		}
+		return nil, broadcastError(err)
+	}
+
+	raw, err := proto.Marshal(tx.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	txid := hex.EncodeToString(tx.Txid)
+	s.log.Sugar().Infow("TransferTRC20", "from", req.From, "to", req.To, "token", req.Token, "amount", req.Amount, "txid", txid)
+	return &pb.TransferTRC20Reply{
+		Token:          req.Token,
+		Txid:           txid,
+		Expiration:     tx.Transaction.GetRawData().GetExpiration(),
+		RawTransaction: hex.EncodeToString(raw),
+	}, nil
+}
+
+func (s *TrxService) EstimateFee(c context.Context, req *pb.EstimateFeeRequest) (*pb.EstimateFeeReply, error) {
+	if err := s.auth.Check(c); err != nil {
+		return nil, err
+	}
+	var (
+		contractAddr string
+		decimals     int32 = 6
+	)
+	if req.Token != "" && !strings.EqualFold(req.Token, biz.TokenTRX) {
+		ok, tokenInfo := checkTokenSupport(req.Token)
+		if !ok {
+			return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails(fmt.Sprintf("token %s not support", req.Token)))
+		}
+		d, err := s.uc.TokenDecimals(c, tokenInfo)
+		if err != nil {
+			s.log.Sugar().Errorw("EstimateFee", "token", req.Token, "err", err)
+			return nil, err
+		}
+		contractAddr, decimals = tokenInfo.ContractAddr, d
+	}
+	if !validAddress(req.From) || !validAddress(req.To) {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid address"))
+	}
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil || !amount.IsPositive() {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid amount"))
+	}
+	value := amount.Shift(decimals)
+	if !value.IsInteger() {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails(fmt.Sprintf("amount exceeds %d decimals", decimals)))
+	}
+
+	est, err := s.uc.EstimateFee(c, req.From, req.To, contractAddr, value.BigInt())
+	if err != nil {
+		s.log.Sugar().Errorw("EstimateFee", "from", req.From, "to", req.To, "token", req.Token, "amount", req.Amount, "err", err)
+		return nil, err
+	}
+	return &pb.EstimateFeeReply{
+		Energy:          est.Energy,
+		Bandwidth:       est.Bandwidth,
+		FreeBandwidth:   est.FreeBandwidth,
+		StakedBandwidth: est.StakedBandwidth,
+		StakedEnergy:    est.StakedEnergy,
+		EnergyFee:       est.EnergyFee,
+		NewAccount:      est.NewAccount,
+		Burn:            est.Burn,
+		FeeLimit:        est.FeeLimit,
+	}, nil
+}
+
+func (s *TrxService) NewDepositAddress(c context.Context, req *pb.NewDepositAddressRequest) (*pb.NewDepositAddressReply, error) {
+	if err := s.auth.Check(c); err != nil {
+		return nil, err
+	}
+	if req.UserId == "" {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("user_id is required"))
+	}
+	addr, err := s.auc.NewDepositAddress(c, req.UserId, req.Account)
+	if err != nil {
+		s.log.Sugar().Errorw("NewDepositAddress", "user", req.UserId, "account", req.Account, "err", err)
+		return nil, err
+	}
+	return &pb.NewDepositAddressReply{
+		Address: addr.Address,
+		Account: addr.Account,
+		Index:   addr.Index,
+		Path:    s.auc.Path(addr),
+	}, nil
+}
+
+func (s *TrxService) ListTransactions(c context.Context, req *pb.ListTransactionsRequest) (*pb.ListTransactionsReply, error) {
+	if err := s.auth.Check(c); err != nil {
+		return nil, err
+	}
+	if req.Address != "" && !validAddress(req.Address) {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid address"))
+	}
+	if req.QueryType < 0 || req.QueryType > int32(biz.TxDirectionOut) {
+		return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid query_type"))
+	}
+	f := &biz.TxFilter{
+		Address:   req.Address,
+		Token:     strings.ToUpper(req.Token),
+		QueryType: biz.TxDirection(req.QueryType),
+		PageNum:   int(req.Page),
+		PageSize:  int(req.PageSize),
+	}
+	if req.StartTime > 0 {
+		f.StartTime = time.Unix(req.StartTime, 0)
+	}
+	if req.EndTime > 0 {
+		f.EndTime = time.Unix(req.EndTime, 0)
+	}
+	if req.Cursor != "" {
+		cursor, err := strconv.ParseInt(req.Cursor, 10, 64)
+		if err != nil || cursor <= 0 {
+			return nil, errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid cursor"))
+		}
+		f.Cursor = cursor
+	}
+
+	txs, total, err := s.uc.ListTransactions(c, f)
+	if err != nil {
+		s.log.Sugar().Errorw("ListTransactions", "addr", req.Address, "token", req.Token, "err", err)
+		return nil, err
+	}
+	reply := &pb.ListTransactionsReply{
+		List:  make([]*pb.Transaction, 0, len(txs)),
+		Pager: &pb.Pager{Page: int64(f.PageNum), PageSize: int64(f.PageSize), TotalRows: total},
+	}
+	for _, tx := range txs {
+		t, err := s.toPbTransaction(c, tx)
+		if err != nil {
+			s.log.Sugar().Errorw("ListTransactions", "token", tx.Token, "err", err)
+			return nil, err
+		}
+		reply.List = append(reply.List, t)
+	}
+	if len(txs) == f.PageSize {
+		reply.NextCursor = strconv.FormatInt(txs[len(txs)-1].ID, 10)
+	}
+	return reply, nil
+}
+
+func (s *TrxService) SubscribeTransfers(req *pb.SubscribeTransfersRequest, stream pb.TrxService_SubscribeTransfersServer) error {
+	c := stream.Context()
+	if err := s.auth.Check(c); err != nil {
+		return err
+	}
+	if len(req.Addresses) == 0 || len(req.Addresses) > maxSubscribeAddresses {
+		return errcode.TogRPCError(errcode.InvalidParams.WithDetails(fmt.Sprintf("1 to %d addresses are required", maxSubscribeAddresses)))
+	}
+	f := &biz.TransferFilter{Addresses: make(map[string]bool), Tokens: make(map[string]bool)}
+	for _, addr := range req.Addresses {
+		if !validAddress(addr) {
+			return errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid address " + addr))
+		}
+		f.Addresses[addr] = true
+	}
+	for _, token := range req.Tokens {
+		f.Tokens[strings.ToUpper(token)] = true
+	}
+
+	// EventSource reconnects with the last id it saw, the gateway forwards it as metadata
+	cursorStr := req.Cursor
+	if md, ok := metadata.FromIncomingContext(c); ok && cursorStr == "" && len(md.Get(lastEventIDMetadata)) > 0 {
+		cursorStr = md.Get(lastEventIDMetadata)[0]
+	}
+	var cursor int64
+	if cursorStr != "" {
+		var err error
+		if cursor, err = strconv.ParseInt(cursorStr, 10, 64); err != nil || cursor <= 0 {
+			return errcode.TogRPCError(errcode.InvalidParams.WithDetails("invalid cursor"))
+		}
+	}
+
+	err := s.uc.SubscribeTransfers(c, f, cursor, func(e *biz.TxEvent) error {
+		t, err := s.toPbTransaction(c, e.Tx)
+		if err != nil {
+			return err
+		}
+		t.Status, t.Confirmations = string(e.Status), e.Confirmations
+		return stream.Send(&pb.TransferEvent{Cursor: strconv.FormatInt(e.Seq, 10), Transaction: t})
+	})
+	if err != nil {
+		s.log.Sugar().Errorw("SubscribeTransfers", "addrs", req.Addresses, "cursor", cursorStr, "err", err)
+	}
+	return err
+}
+
+func (s *TrxService) ReplayWebhooks(c context.Context, req *pb.ReplayWebhooksRequest) (*pb.ReplayWebhooksReply, error) {
+	if err := s.auth.Check(c); err != nil {
+		return nil, err
+	}
+	count, err := s.wuc.Replay(c, req.Tenant, req.Ids)
+	if err != nil {
+		s.log.Sugar().Errorw("ReplayWebhooks", "tenant", req.Tenant, "ids", req.Ids, "err", err)
+		return nil, err
+	}
+	return &pb.ReplayWebhooksReply{Count: count}, nil
+}
+
+func (s *TrxService) toPbTransaction(c context.Context, tx *biz.Tx) (*pb.Transaction, error) {
+	amount, err := s.formatAmount(c, tx.Token, tx.Amount)
+	if err != nil {
+		return nil, err
+	}
+	t := &pb.Transaction{
+		Txid:          tx.Txid,
+		LogIndex:      int32(tx.LogIndex),
+		BlockNum:      tx.BlockNum,
+		Direction:     int32(tx.Direction),
+		Token:         tx.Token,
+		Contract:      tx.Contract,
+		From:          tx.From,
+		To:            tx.To,
+		Amount:        amount,
+		Status:        string(tx.Status),
+		Confirmations: tx.Confirmations,
+	}
+	if tx.BlockTime != nil {
+		t.BlockTime = tx.BlockTime.Unix()
+	}
+	return t, nil
+}
+
+// tokenDecimal return the precision of token, 0 for TRC10 assets we do not know. Tokens
+// configured without decimals use those of their contract.
+func (s *TrxService) tokenDecimal(c context.Context, token string) (int32, error) {
+	if strings.EqualFold(token, biz.TokenTRX) {
+		return 6, nil
+	}
+	if ok, info := checkTokenSupport(token); ok {
+		return s.uc.TokenDecimals(c, info)
+	}
+	return 0, nil
+}
+
+// formatAmount render amount of token, given in its smallest unit
+func (s *TrxService) formatAmount(c context.Context, token string, amount decimal.Decimal) (string, error) {
+	d, err := s.tokenDecimal(c, token)
+	if err != nil {
+		return "", err
+	}
+	return amount.Shift(-d).String(), nil
+}
+
+// broadcastError translate a transaction the node refused into its errcode class, other
+// errors are returned as they are
+func broadcastError(err error) error {
+	// the caller must look the tx up by txid instead of sending the request again
+	var unknown *biz.BroadcastUnknownError
+	if errors.As(err, &unknown) {
+		return errcode.TogRPCError(errcode.BroadcastUnknown.WithDetails("txid " + unknown.Txid))
+	}
+	var be *biz.BroadcastError
+	if !errors.As(err, &be) {
+		return err
+	}
+	return errcode.TogRPCError(errcode.FromBroadcastCode(be.Code).WithDetails(be.Message))
+}
+
+// solidityError tell the caller confirmed reads need solidity nodes, other errors are
+// returned as they are
+func solidityError(err error) error {
+	if errors.Is(err, biz.ErrNoSolidityNode) {
+		return errcode.TogRPCError(errcode.InvalidParams.WithDetails("confirmed reads need solidity nodes, see app.solidity_addr"))
+	}
+	return err
+}
+
+func validAddress(addr string) bool {
+	a, err := address.Base58ToAddress(addr)
+	return err == nil && len(a) == address.AddressLength && a[0] == address.TronBytePrefix
+}
+
 func checkTokenSupport(tokenSymbol string) (bool, setting.Token) {
 	for k, info := range setting.Conf.TokenList {
 		if strings.ToUpper(k) == strings.ToUpper(tokenSymbol) {
@@ -0,0 +1,48 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+
+	protov1 "github.com/golang/protobuf/proto"
+	gwruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
+)
+
+// SSEContentType selects SSEMarshaler through the Accept header
+const SSEContentType = "text/event-stream"
+
+// SSEMarshaler writes gateway stream chunks as server-sent events. A result with a
+// cursor uses it as the event id, so EventSource resumes with Last-Event-ID.
+type SSEMarshaler struct {
+	gwruntime.JSONPb
+}
+
+func (m *SSEMarshaler) ContentType() string {
+	return SSEContentType
+}
+
+func (m *SSEMarshaler) Marshal(v interface{}) ([]byte, error) {
+	data, err := m.JSONPb.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	switch chunk := v.(type) {
+	case map[string]interface{}:
+		if c, ok := chunk["result"].(interface{ GetCursor() string }); ok && c.GetCursor() != "" {
+			fmt.Fprintf(&buf, "id: %s\n", c.GetCursor())
+		}
+	case map[string]protov1.Message:
+		if _, ok := chunk["error"]; ok {
+			buf.WriteString("event: error\n")
+		}
+	}
+	buf.WriteString("data: ")
+	buf.Write(data)
+	return buf.Bytes(), nil
+}
+
+// Delimiter end every event with a blank line
+func (m *SSEMarshaler) Delimiter() []byte {
+	return []byte("\n\n")
+}
@@ -20,8 +20,6 @@ import (
 	"github.com/leondevpt/wallet/trxservice/pkg/setting"
 	"github.com/leondevpt/wallet/trxservice/version"
 
-	"github.com/leondevpt/wallet/trxservice/global"
-
 	gwruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	//"go.opencensus.io/zpages"
@@ -29,7 +27,6 @@ import (
 	"golang.org/x/net/http2/h2c"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/resolver"
 )
 
 var (
@@ -52,10 +49,6 @@ func main() {
 	setting.Init()
 
 	fmt.Printf("Cfg:%v\n", setting.Conf)
-	// 注册自定义的 静态resolver
-	builder := util.NewStaticResolverBuilder(map[string][]string{global.TronNode: setting.Conf.App.Node_Addr})
-	resolver.Register(builder)
-
 	lg := logger.NewZapLogger()
 
 	done := make(chan os.Signal, 1)
@@ -107,6 +100,7 @@ func run(ctx context.Context, app app) error {
 // and shutdown the Order microservice
 type app struct {
 	grpcServer *server.GrpcServer
+	jobServer  *server.JobServer
 }
 
 // start starts the REST and gRPC Servers in the background
@@ -118,21 +112,24 @@ func (a app) start() error {
 	gatewayMux := runGrpcGatewayServer()
 	httpMux.Handle("/", gatewayMux)
 
+	a.jobServer.Start()
 	return http.ListenAndServe(fmt.Sprintf(":%d", setting.Conf.GrpcPort), grpcHandlerFunc(grpcS, httpMux))
 
 }
 
 // stop shuts down the servers
 func (a app) shutdown() error {
+	a.jobServer.Stop()
 	a.grpcServer.Stop()
 	return nil
 }
 
 // newApp creates a new app with REST & gRPC servers
 // this func performs all app related initialization
-func newApp(gs *server.GrpcServer) (app, error) {
+func newApp(gs *server.GrpcServer, js *server.JobServer) (app, error) {
 	return app{
 		grpcServer: gs,
+		jobServer:  js,
 	}, nil
 }
 
@@ -161,7 +158,16 @@ func runHttpServer() *http.ServeMux {
 func runGrpcGatewayServer() *gwruntime.ServeMux {
 	endpoint := fmt.Sprintf("0.0.0.0:%d", setting.Conf.GrpcPort)
 
-	gwmux := gwruntime.NewServeMux()
+	gwmux := gwruntime.NewServeMux(
+		// SubscribeTransfers is served as server-sent events to clients that accept them
+		gwruntime.WithMarshalerOption(util.SSEContentType, &util.SSEMarshaler{JSONPb: gwruntime.JSONPb{OrigName: true}}),
+		gwruntime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
+			if key == "Last-Event-Id" {
+				return "last-event-id", true
+			}
+			return gwruntime.DefaultHeaderMatcher(key)
+		}),
+	)
 	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
 	err := pb.RegisterTrxServiceHandlerFromEndpoint(context.Background(), gwmux, endpoint, opts)
 	if err != nil {
@@ -0,0 +1,130 @@
+// Package bundle encodes the transactions carried to and from an offline signer
+package bundle
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	protov1 "github.com/golang/protobuf/proto"
+	pb "github.com/leondevpt/wallet/trxservice/api/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	FormatJSON = "json"
+	FormatHex  = "hex"
+)
+
+// trc20TransferMethod is the selector of transfer(address,uint256)
+var trc20TransferMethod = []byte{0xa9, 0x05, 0x9c, 0xbb}
+
+// New bundle tx with a summary for the signer to check
+func New(tx *core.Transaction, txid []byte, owner, summary string) (*pb.OfflineBundle, error) {
+	raw, err := proto.Marshal(tx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.OfflineBundle{
+		Txid:        hex.EncodeToString(txid),
+		Owner:       owner,
+		Expiration:  tx.GetRawData().GetExpiration(),
+		Summary:     summary,
+		Transaction: hex.EncodeToString(raw),
+	}, nil
+}
+
+// Encode b as indented JSON or as the hex of its protobuf encoding
+func Encode(b *pb.OfflineBundle, format string) (string, error) {
+	switch format {
+	case FormatJSON, "":
+		out, err := protojson.MarshalOptions{Multiline: true, UseProtoNames: true}.Marshal(b)
+		return string(out), err
+	case FormatHex:
+		out, err := proto.Marshal(b)
+		return hex.EncodeToString(out), err
+	}
+	return "", fmt.Errorf("unknown bundle format %s", format)
+}
+
+// Decode a bundle made by Encode and report its format
+func Decode(s string) (*pb.OfflineBundle, string, error) {
+	s = strings.TrimSpace(s)
+	b := new(pb.OfflineBundle)
+	if strings.HasPrefix(s, "{") {
+		if err := protojson.Unmarshal([]byte(s), b); err != nil {
+			return nil, "", fmt.Errorf("invalid bundle: %v", err)
+		}
+		return b, FormatJSON, nil
+	}
+	raw, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid bundle: %v", err)
+	}
+	if err := proto.Unmarshal(raw, b); err != nil {
+		return nil, "", fmt.Errorf("invalid bundle: %v", err)
+	}
+	return b, FormatHex, nil
+}
+
+// Transaction decode the transaction of b
+func Transaction(b *pb.OfflineBundle) (*core.Transaction, error) {
+	raw, err := hex.DecodeString(b.Transaction)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction: %v", err)
+	}
+	tx := new(core.Transaction)
+	if err := proto.Unmarshal(raw, tx); err != nil {
+		return nil, fmt.Errorf("invalid transaction: %v", err)
+	}
+	return tx, nil
+}
+
+// SetTransaction replace the transaction of b, e.g. once it is signed
+func SetTransaction(b *pb.OfflineBundle, tx *core.Transaction) error {
+	raw, err := proto.Marshal(tx)
+	if err != nil {
+		return err
+	}
+	b.Transaction = hex.EncodeToString(raw)
+	return nil
+}
+
+// Describe what tx does from its contract alone, the signer compares it with the summary
+// of the bundle. Amounts are in SUN or in the token's smallest unit.
+func Describe(tx *core.Transaction) (string, error) {
+	raw := tx.GetRawData()
+	if len(raw.GetContract()) != 1 {
+		return "", fmt.Errorf("transaction has %d contracts", len(raw.GetContract()))
+	}
+	contract := raw.GetContract()[0]
+	expires := time.UnixMilli(raw.GetExpiration()).UTC().Format(time.RFC3339)
+	switch contract.GetType() {
+	case core.Transaction_Contract_TransferContract:
+		c := new(core.TransferContract)
+		if err := contract.GetParameter().UnmarshalTo(protov1.MessageV2(c)); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("send %d SUN from %s to %s, expires %s", c.Amount, address.Address(c.OwnerAddress),
+			address.Address(c.ToAddress), expires), nil
+	case core.Transaction_Contract_TriggerSmartContract:
+		c := new(core.TriggerSmartContract)
+		if err := contract.GetParameter().UnmarshalTo(protov1.MessageV2(c)); err != nil {
+			return "", err
+		}
+		if len(c.Data) != 4+64 || !bytes.Equal(c.Data[:4], trc20TransferMethod) || c.CallValue != 0 {
+			return "", fmt.Errorf("contract call is not a TRC20 transfer")
+		}
+		to := address.Address(append([]byte{address.TronBytePrefix}, c.Data[4+12:4+32]...))
+		amount := new(big.Int).SetBytes(c.Data[4+32:])
+		return fmt.Sprintf("send %s units of TRC20 %s from %s to %s, fee limit %d SUN, expires %s", amount,
+			address.Address(c.ContractAddress), address.Address(c.OwnerAddress), to, raw.GetFeeLimit(), expires), nil
+	}
+	return "", fmt.Errorf("unsupported contract type %s", contract.GetType())
+}
@@ -0,0 +1,26 @@
+package errcode
+
+import "github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+
+// FromBroadcastCode return the class of a Return code of BroadcastTransaction, nil for SUCCESS
+func FromBroadcastCode(code api.ReturnResponseCode) *Error {
+	switch code {
+	case api.Return_SUCCESS:
+		return nil
+	case api.Return_SIGERROR:
+		return BroadcastSignature
+	case api.Return_CONTRACT_VALIDATE_ERROR, api.Return_CONTRACT_EXE_ERROR:
+		return BroadcastRejected
+	case api.Return_BANDWITH_ERROR:
+		return BroadcastResource
+	case api.Return_DUP_TRANSACTION_ERROR:
+		return BroadcastDuplicate
+	case api.Return_TAPOS_ERROR, api.Return_TRANSACTION_EXPIRATION_ERROR:
+		return BroadcastExpired
+	case api.Return_TOO_BIG_TRANSACTION_ERROR:
+		return BroadcastTooBig
+	case api.Return_SERVER_BUSY, api.Return_NO_CONNECTION, api.Return_NOT_ENOUGH_EFFECTIVE_CONNECTION:
+		return BroadcastUnavailable
+	}
+	return BroadcastFailed
+}
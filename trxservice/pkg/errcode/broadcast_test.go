@@ -0,0 +1,51 @@
+package errcode
+
+import (
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	pb "github.com/leondevpt/wallet/trxservice/api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFromBroadcastCode(t *testing.T) {
+	for _, c := range []struct {
+		code      api.ReturnResponseCode
+		class     *Error
+		retryable bool
+		rpc       codes.Code
+	}{
+		{api.Return_SIGERROR, BroadcastSignature, false, codes.InvalidArgument},
+		{api.Return_CONTRACT_VALIDATE_ERROR, BroadcastRejected, false, codes.FailedPrecondition},
+		{api.Return_CONTRACT_EXE_ERROR, BroadcastRejected, false, codes.FailedPrecondition},
+		{api.Return_BANDWITH_ERROR, BroadcastResource, false, codes.FailedPrecondition},
+		{api.Return_DUP_TRANSACTION_ERROR, BroadcastDuplicate, false, codes.AlreadyExists},
+		{api.Return_TAPOS_ERROR, BroadcastExpired, true, codes.Aborted},
+		{api.Return_TRANSACTION_EXPIRATION_ERROR, BroadcastExpired, true, codes.Aborted},
+		{api.Return_TOO_BIG_TRANSACTION_ERROR, BroadcastTooBig, false, codes.InvalidArgument},
+		{api.Return_SERVER_BUSY, BroadcastUnavailable, true, codes.Unavailable},
+		{api.Return_NO_CONNECTION, BroadcastUnavailable, true, codes.Unavailable},
+		{api.Return_NOT_ENOUGH_EFFECTIVE_CONNECTION, BroadcastUnavailable, true, codes.Unavailable},
+		{api.Return_OTHER_ERROR, BroadcastFailed, false, codes.Unknown},
+	} {
+		class := FromBroadcastCode(c.code)
+		if class != c.class || class.Retryable() != c.retryable || ToRPCCode(class.Code()) != c.rpc {
+			t.Errorf("%s: class %d retryable %v rpc %s", c.code, class.Code(), class.Retryable(), ToRPCCode(class.Code()))
+		}
+	}
+	if FromBroadcastCode(api.Return_SUCCESS) != nil {
+		t.Error("SUCCESS has a class")
+	}
+}
+
+func TestTogRPCErrorDetail(t *testing.T) {
+	s, _ := status.FromError(TogRPCError(BroadcastUnavailable.WithDetails("server busy")))
+	if s.Code() != codes.Unavailable || len(s.Details()) != 1 {
+		t.Fatalf("status %v", s)
+	}
+	e, ok := s.Details()[0].(*pb.Error)
+	if !ok || e.Code != int32(BroadcastUnavailable.Code()) || !e.Retryable || len(e.Details) != 1 || e.Details[0] != "server busy" {
+		t.Fatalf("detail %v", s.Details()[0])
+	}
+}
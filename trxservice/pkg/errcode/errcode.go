@@ -63,6 +63,60 @@ func (e *Error) StatusCode() int {
 		return http.StatusUnauthorized
 	case TooManyRequests.Code():
 		return http.StatusTooManyRequests
+	case InsufficientBalance.Code():
+		return http.StatusBadRequest
+	case FeeLimitExceeded.Code():
+		return http.StatusBadRequest
+	case PayoutConflict.Code():
+		return http.StatusConflict
+	case SweepUnavailable.Code():
+		return http.StatusConflict
+	case MultisigClosed.Code():
+		return http.StatusConflict
+	case MultisigSigned.Code():
+		return http.StatusConflict
+	case InvalidSignature.Code():
+		return http.StatusBadRequest
+	case OfflineMismatch.Code():
+		return http.StatusBadRequest
+	case OfflineExpired.Code():
+		return http.StatusBadRequest
+	case SignerNotConfigured.Code():
+		return http.StatusServiceUnavailable
+	case BroadcastUnknown.Code():
+		return http.StatusBadGateway
+	case BroadcastSignature.Code():
+		return http.StatusBadRequest
+	case BroadcastRejected.Code():
+		return http.StatusBadRequest
+	case BroadcastResource.Code():
+		return http.StatusBadRequest
+	case BroadcastDuplicate.Code():
+		return http.StatusConflict
+	case BroadcastExpired.Code():
+		return http.StatusConflict
+	case BroadcastTooBig.Code():
+		return http.StatusBadRequest
+	case BroadcastUnavailable.Code():
+		return http.StatusServiceUnavailable
+	case BroadcastFailed.Code():
+		return http.StatusBadGateway
 	}
 	return http.StatusInternalServerError
 }
+
+// Retryable report whether sending the same request again may succeed. Terminal errors
+// need the request, an account or the node to change first.
+func (e *Error) Retryable() bool {
+	return retryable(e.Code())
+}
+
+func retryable(code int) bool {
+	switch code {
+	case ServerError.Code(), DeadlineExceeded.Code(), TooManyRequests.Code(), LimitExceed.Code():
+		return true
+	case BroadcastExpired.Code(), BroadcastUnavailable.Code():
+		return true
+	}
+	return false
+}
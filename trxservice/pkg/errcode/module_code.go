@@ -0,0 +1,26 @@
+package errcode
+
+var (
+	InsufficientBalance = NewError(20010001, "余额不足")
+	FeeLimitExceeded    = NewError(20010002, "预估手续费超过上限")
+	PayoutConflict      = NewError(20010003, "付款单号已用于不同的付款")
+	SweepUnavailable    = NewError(20010004, "归集任务不可用")
+	MultisigClosed      = NewError(20010005, "多签交易已不再收集签名")
+	MultisigSigned      = NewError(20010006, "该密钥已签名")
+	InvalidSignature    = NewError(20010007, "签名无效或不属于该权限")
+	OfflineMismatch     = NewError(20010008, "交易不是导出的交易或签名不属于转出地址")
+	OfflineExpired      = NewError(20010009, "交易已过期")
+
+	// classes of the Return code of a broadcast, see FromBroadcastCode
+	BroadcastSignature   = NewError(20010010, "交易签名错误")
+	BroadcastRejected    = NewError(20010011, "交易未通过合约校验或执行失败")
+	BroadcastResource    = NewError(20010012, "带宽或手续费不足")
+	BroadcastDuplicate   = NewError(20010013, "交易已广播")
+	BroadcastExpired     = NewError(20010014, "交易已过期或引用的区块不存在")
+	BroadcastTooBig      = NewError(20010015, "交易过大")
+	BroadcastUnavailable = NewError(20010016, "节点繁忙或无法连接")
+	BroadcastFailed      = NewError(20010017, "广播失败")
+
+	SignerNotConfigured = NewError(20010018, "未配置热钱包签名")
+	BroadcastUnknown    = NewError(20010019, "广播结果未知, 交易可能已上链, 请按 txid 核对后再重试")
+)
@@ -8,7 +8,9 @@ import (
 )
 
 func TogRPCError(err *Error) error {
-	s, _ := status.New(ToRPCCode(err.Code()), err.Msg()).WithDetails(&pb.Error{Code: int32(err.Code()), Message: err.Msg()})
+	s, _ := status.New(ToRPCCode(err.Code()), err.Msg()).WithDetails(&pb.Error{
+		Code: int32(err.Code()), Message: err.Msg(), Details: err.Details(), Retryable: err.Retryable(),
+	})
 	return s.Err()
 }
 
@@ -31,6 +33,44 @@ func ToRPCCode(code int) codes.Code {
 		statusCode = codes.ResourceExhausted
 	case MethodNotAllowed.Code():
 		statusCode = codes.Unimplemented
+	case InsufficientBalance.Code():
+		statusCode = codes.FailedPrecondition
+	case FeeLimitExceeded.Code():
+		statusCode = codes.FailedPrecondition
+	case PayoutConflict.Code():
+		statusCode = codes.AlreadyExists
+	case SweepUnavailable.Code():
+		statusCode = codes.FailedPrecondition
+	case MultisigClosed.Code():
+		statusCode = codes.FailedPrecondition
+	case MultisigSigned.Code():
+		statusCode = codes.AlreadyExists
+	case InvalidSignature.Code():
+		statusCode = codes.InvalidArgument
+	case OfflineMismatch.Code():
+		statusCode = codes.InvalidArgument
+	case OfflineExpired.Code():
+		statusCode = codes.FailedPrecondition
+	case SignerNotConfigured.Code():
+		statusCode = codes.FailedPrecondition
+	case BroadcastUnknown.Code():
+		statusCode = codes.Unknown
+	case BroadcastSignature.Code():
+		statusCode = codes.InvalidArgument
+	case BroadcastRejected.Code():
+		statusCode = codes.FailedPrecondition
+	case BroadcastResource.Code():
+		statusCode = codes.FailedPrecondition
+	case BroadcastDuplicate.Code():
+		statusCode = codes.AlreadyExists
+	case BroadcastExpired.Code():
+		statusCode = codes.Aborted
+	case BroadcastTooBig.Code():
+		statusCode = codes.InvalidArgument
+	case BroadcastUnavailable.Code():
+		statusCode = codes.Unavailable
+	case BroadcastFailed.Code():
+		statusCode = codes.Unknown
 	default:
 		statusCode = codes.Unknown
 	}
@@ -43,7 +83,7 @@ type Status struct {
 }
 
 func ToRPCStatus(code int, msg string) *Status {
-	s, _ := status.New(ToRPCCode(code), msg).WithDetails(&pb.Error{Code: int32(code), Message: msg})
+	s, _ := status.New(ToRPCCode(code), msg).WithDetails(&pb.Error{Code: int32(code), Message: msg, Retryable: retryable(code)})
 	return &Status{s}
 }
 
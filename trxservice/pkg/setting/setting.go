@@ -2,6 +2,7 @@ package setting
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/opentracing/opentracing-go"
@@ -12,8 +13,18 @@ var (
 	Tracer opentracing.Tracer
 	// 保存所有配置信息
 	Conf = new(Config)
+
+	watchersMu sync.Mutex
+	watchers   []func(*Config)
 )
 
+// OnChange register fn to be called with Conf each time the config file is reloaded
+func OnChange(fn func(*Config)) {
+	watchersMu.Lock()
+	defer watchersMu.Unlock()
+	watchers = append(watchers, fn)
+}
+
 func Init() (err error) {
 	//viper.SetConfigFile("config.yaml")
 	viper.SetConfigName("config") // 配置文件名称(无扩展名)
@@ -41,6 +52,11 @@ func Init() (err error) {
 			return
 		}
 		fmt.Printf("cfg:%v\n", Conf)
+		watchersMu.Lock()
+		defer watchersMu.Unlock()
+		for _, fn := range watchers {
+			fn(Conf)
+		}
 	})
 	return
 }
@@ -50,17 +66,31 @@ type Config struct {
 	Log       `mapstructure:"log"`
 	DB        `mapstructure:"db"`
 	Redis     `mapstructure:"redis"`
+	Cache     `mapstructure:"cache"`
 	TokenList map[string]Token `mapstructure:"tokenList" json:"tokenList"`
 	Metrics   `mapstructure:"metrics"`
 	Trace     `mapstructure:"trace"`
+	Signer    `mapstructure:"signer"`
+	HDWallet  `mapstructure:"hd_wallet"`
+	Scanner   `mapstructure:"scanner"`
+	Tracker   `mapstructure:"tracker"`
+	Webhook   `mapstructure:"webhook"`
+
+	Tron        `mapstructure:"tron"`
+	Transaction `mapstructure:"transaction"`
+	EnergyTopUp `mapstructure:"energy_topup"`
+	Payout      `mapstructure:"payout"`
+	Sweep       `mapstructure:"sweep"`
+	Multisig    `mapstructure:"multisig"`
 }
 
 type App struct {
-	Name      string   `mapstructure:"name"`
-	RunMode   string   `mapstructure:"run_mode"`
-	HttpPort  int      `mapstructure:"http_port"`
-	GrpcPort  int      `mapstructure:"grpc_port"`
-	Node_Addr []string `mapstructure:"node_addr"`
+	Name          string   `mapstructure:"name"`
+	RunMode       string   `mapstructure:"run_mode"`
+	HttpPort      int      `mapstructure:"http_port"`
+	GrpcPort      int      `mapstructure:"grpc_port"`
+	Node_Addr     []string `mapstructure:"node_addr"`
+	Solidity_Addr []string `mapstructure:"solidity_addr"` // java-tron's WalletSolidity service, for reads of solidified state
 }
 type Log struct {
 	Level      string `mapstructure:"level"`
@@ -88,10 +118,19 @@ type Redis struct {
 	PoolSize int    `mapstructure:"pool_size"`
 }
 
+// Cache keeps balances and TRC20 metadata read from the nodes in redis
+type Cache struct {
+	Enable     bool `mapstructure:"enable"`
+	BalanceTTL int  `mapstructure:"balance_ttl"` // seconds a balance is kept, 60 when 0
+	TokenTTL   int  `mapstructure:"token_ttl"`   // seconds the name, symbol and decimals of a TRC20 contract are kept, 86400 when 0
+}
+
 type Token struct {
-	Name         string `mapstructure:"name" json:"name"`
-	Decimal      uint   `mapstructure:"decimal" json:"decimal"`
-	ContractAddr string `mapstructure:"contractAddr" json:"contractAddr"`
+	Name          string `mapstructure:"name" json:"name"`
+	Decimal       uint   `mapstructure:"decimal" json:"decimal"` // read from the contract when 0
+	ContractAddr  string `mapstructure:"contractAddr" json:"contractAddr"`
+	FeeLimit      int64  `mapstructure:"feeLimit" json:"feeLimit"`           // SUN, ceiling of the estimated fee limit
+	Confirmations int64  `mapstructure:"confirmations" json:"confirmations"` // tracker.confirmations when 0
 }
 
 type Metrics struct {
@@ -105,3 +144,134 @@ type Trace struct {
 	ServiceName string `mapstructure:"service_name"`
 	LogSpans    bool   `mapstructure:"log_spans"`
 }
+
+type Signer struct {
+	Type             string `mapstructure:"type"` // local, keystore, remote, empty for no signer
+	PrivateKey       string `mapstructure:"private_key"`
+	KeystoreFile     string `mapstructure:"keystore_file"`
+	KeystorePassword string `mapstructure:"keystore_password"`
+	RemoteAddr       string `mapstructure:"remote_addr"`
+	RemoteCAFile     string `mapstructure:"remote_ca_file"`
+}
+
+type HDWallet struct {
+	MasterKey   string            `mapstructure:"master_key"`   // xprv at m
+	AccountKeys map[string]string `mapstructure:"account_keys"` // account => xpub/xprv at m/44'/195'/account'
+}
+
+type Scanner struct {
+	Enable      bool  `mapstructure:"enable"`
+	StartBlock  int64 `mapstructure:"start_block"`  // used when there is no checkpoint, 0 means the current head
+	Interval    int   `mapstructure:"interval"`     // seconds
+	Batch       int   `mapstructure:"batch"`        // max blocks per round
+	ReorgWindow int64 `mapstructure:"reorg_window"` // recent blocks kept to find a fork point, must exceed the solidification depth
+}
+
+type Tracker struct {
+	Enable        bool  `mapstructure:"enable"`
+	Interval      int   `mapstructure:"interval"`      // seconds
+	Batch         int   `mapstructure:"batch"`         // txs loaded per query
+	Confirmations int64 `mapstructure:"confirmations"` // threshold for TRX, TRC10 and tokens without their own
+}
+
+type Webhook struct {
+	Enable      bool                     `mapstructure:"enable"`
+	Interval    int                      `mapstructure:"interval"`     // seconds between outbox polls
+	Timeout     int                      `mapstructure:"timeout"`      // seconds per request
+	MaxAttempts int                      `mapstructure:"max_attempts"` // a delivery is dead after this many failures
+	BaseBackoff int                      `mapstructure:"base_backoff"` // seconds, doubled after each failure
+	MaxBackoff  int                      `mapstructure:"max_backoff"`  // seconds
+	Tenants     map[string]WebhookTenant `mapstructure:"tenants"`
+}
+
+// WebhookTenant receives events of transfers touching its deposit accounts or addresses
+type WebhookTenant struct {
+	URL       string   `mapstructure:"url"`
+	Secret    string   `mapstructure:"secret"`    // HMAC-SHA256 key
+	Accounts  []uint32 `mapstructure:"accounts"`  // HD accounts of its deposit addresses
+	Addresses []string `mapstructure:"addresses"` // other addresses, e.g. its hot wallets
+}
+
+// Tron tunes the health checks of the nodes of app.node_addr and app.solidity_addr
+type Tron struct {
+	ProbeInterval int   `mapstructure:"probe_interval"` // seconds between probes of the head block of every node, 5 when 0
+	ProbeTimeout  int   `mapstructure:"probe_timeout"`  // seconds a probe waits for a node, 3 when 0
+	MaxLag        int64 `mapstructure:"max_lag"`        // blocks a node may be behind the best one, 10 when 0
+	MaxLatency    int   `mapstructure:"max_latency"`    // milliseconds a probe may take, no limit when 0
+
+	RateLimit       float64 `mapstructure:"rate_limit"`       // calls per second to each node, no limit when 0
+	RateBurst       int     `mapstructure:"rate_burst"`       // calls a node takes at once after a pause, rate_limit rounded up when 0
+	Retries         int     `mapstructure:"retries"`          // retries of a call a node refused as unavailable or throttled, 2 when 0, none when negative
+	RetryBackoff    int     `mapstructure:"retry_backoff"`    // milliseconds of the first retry backoff, doubled on each retry and jittered, 100 when 0
+	BreakerFailures int     `mapstructure:"breaker_failures"` // failed calls in a row that take a node out, 5 when 0
+	BreakerCooldown int     `mapstructure:"breaker_cooldown"` // seconds a node is out before a trial call, 30 when 0
+	CallTimeout     int     `mapstructure:"call_timeout"`     // milliseconds a node has to answer a call before it counts as failed, no limit when 0
+
+	Nodes []TronNode `mapstructure:"nodes"` // how to reach nodes that need more than plaintext gRPC
+}
+
+// TronNode is the connection of one node of app.node_addr or app.solidity_addr, matched by address
+type TronNode struct {
+	Addr       string   `mapstructure:"addr"`
+	Backend    string   `mapstructure:"backend"` // grpc, or http for the full node HTTP API at http(s)://addr
+	TLS        bool     `mapstructure:"tls"`
+	CAFile     string   `mapstructure:"ca_file"`     // PEM CA bundle, the system roots when empty
+	ServerName string   `mapstructure:"server_name"` // name the certificate is verified against, the host of addr when empty
+	APIKeys    []string `mapstructure:"api_keys"`    // sent as TRON-PRO-API-KEY, the next one is used after a 401 or quota error
+	RateLimit  float64  `mapstructure:"rate_limit"`  // calls per second, tron.rate_limit when 0
+}
+
+type Transaction struct {
+	Expiration   int `mapstructure:"expiration"`   // seconds a built transaction stays valid, the node's 60 when 0, at most 86340
+	Rebuilds     int `mapstructure:"rebuilds"`     // times a transfer rejected as expired is built and signed again, 2 when 0, none when negative
+	Rebroadcasts int `mapstructure:"rebroadcasts"` // times a transfer a busy node did not take is broadcast again, after tron.retry_backoff, 3 when 0, none when negative
+}
+
+type EnergyTopUp struct {
+	Enable        bool           `mapstructure:"enable"`
+	Interval      int            `mapstructure:"interval"`       // seconds
+	Cooldown      int            `mapstructure:"cooldown"`       // seconds an address is left alone after a delegation
+	AlertInterval int            `mapstructure:"alert_interval"` // seconds between repeated alerts of an address
+	Policies      []EnergyPolicy `mapstructure:"policies"`       // a list, so addresses removed on reload are dropped
+}
+
+// EnergyPolicy keeps the available energy of a hot wallet at or above Threshold
+type EnergyPolicy struct {
+	Address        string `mapstructure:"address"`         // hot wallet
+	StakingAccount string `mapstructure:"staking_account"` // delegates its staked energy, the signer must hold its key
+	Threshold      int64  `mapstructure:"threshold"`       // top up when available energy drops below
+	Target         int64  `mapstructure:"target"`          // available energy after a top-up, twice Threshold when 0
+	Lock           bool   `mapstructure:"lock"`            // lock delegations for 3 days
+}
+
+type Payout struct {
+	Enable      bool `mapstructure:"enable"`
+	Interval    int  `mapstructure:"interval"`    // seconds between polls, submissions start a round at once
+	Concurrency int  `mapstructure:"concurrency"` // hot wallets paid from in parallel
+	Batch       int  `mapstructure:"batch"`       // max items per round
+}
+
+type Sweep struct {
+	Enable         bool         `mapstructure:"enable"`
+	DryRun         bool         `mapstructure:"dry_run"`         // scheduled runs only plan and record, nothing is sent
+	Interval       int          `mapstructure:"interval"`        // seconds between scheduled runs
+	Times          []string     `mapstructure:"times"`           // daily start times as 15:04 local time, used instead of interval
+	Treasury       string       `mapstructure:"treasury"`        // cold or treasury address receiving the funds
+	GasWallet      string       `mapstructure:"gas_wallet"`      // sends the TRX fees of TRC20 sweeps, the signer must hold its key
+	StakingAccount string       `mapstructure:"staking_account"` // delegates energy to TRC20 sweeps instead when set, the signer must hold its key
+	Batch          int          `mapstructure:"batch"`           // deposit addresses checked per query
+	Tokens         []SweepToken `mapstructure:"tokens"`
+}
+
+// SweepToken sweeps deposit addresses holding at least Threshold of Token
+type SweepToken struct {
+	Token     string `mapstructure:"token"`     // TRX or a symbol of tokenList
+	Threshold string `mapstructure:"threshold"` // in token units, e.g. "10.5"
+}
+
+type Multisig struct {
+	Enable     bool `mapstructure:"enable"`
+	Interval   int  `mapstructure:"interval"`    // seconds between checks of pending transactions
+	Expiration int  `mapstructure:"expiration"`  // seconds approvers have to sign, at most 86400
+	WarnBefore int  `mapstructure:"warn_before"` // seconds before expiration a pending transaction is flagged
+}
@@ -29,14 +29,43 @@ func wireApp(cfg *setting.Config, logger *zap.Logger) (app, error) {
 		return app{}, err
 	}
 	trxRepo := data.NewTrxRepo(dataData, logger)
-	tronCli := biz.NewTronCli()
-	trxUsecase := biz.NewTrxUsecase(trxRepo, logger, tronCli)
-	trxServiceServer := service.NewTrxService(trxUsecase, logger)
+	balanceCache := data.NewBalanceCache(dataData, cfg, logger)
+	nodePool := biz.NewNodePool(cfg, logger)
+	solidityNodePool := biz.NewSolidityNodePool(cfg, logger)
+	tronCli := biz.NewTronCli(nodePool, solidityNodePool)
+	signer, err := biz.NewSigner(cfg)
+	if err != nil {
+		return app{}, err
+	}
+	eventBus := biz.NewEventBus()
+	trxUsecase := biz.NewTrxUsecase(trxRepo, balanceCache, logger, tronCli, signer, eventBus, cfg)
+	addressRepo := data.NewAddressRepo(dataData, logger)
+	hdWallet, err := biz.NewHDWallet(cfg)
+	if err != nil {
+		return app{}, err
+	}
+	addressUsecase := biz.NewAddressUsecase(addressRepo, hdWallet, logger)
+	webhookRepo := data.NewWebhookRepo(dataData, logger)
+	webhookUsecase := biz.NewWebhookUsecase(webhookRepo, trxRepo, addressRepo, eventBus, cfg, logger)
+	payoutRepo := data.NewPayoutRepo(dataData, logger)
+	payoutUsecase := biz.NewPayoutUsecase(trxUsecase, payoutRepo, cfg, logger)
+	sweepRepo := data.NewSweepRepo(dataData, logger)
+	auditRepo := data.NewAuditRepo(dataData, logger)
+	sweepUsecase := biz.NewSweepUsecase(trxUsecase, hdWallet, sweepRepo, addressRepo, auditRepo, cfg, logger)
+	multisigRepo := data.NewMultisigRepo(dataData, logger)
+	multisigUsecase := biz.NewMultisigUsecase(trxUsecase, multisigRepo, auditRepo, cfg, logger)
+	offlineRepo := data.NewOfflineRepo(dataData, logger)
+	offlineUsecase := biz.NewOfflineUsecase(trxUsecase, offlineRepo, logger)
+	trxServiceServer := service.NewTrxService(trxUsecase, addressUsecase, webhookUsecase, payoutUsecase, sweepUsecase, multisigUsecase, offlineUsecase, logger)
 	grpcServer, err := server.NewGrpcServer(trxServiceServer, cfg, logger)
 	if err != nil {
 		return app{}, err
 	}
-	mainApp, err := newApp(grpcServer)
+	blockScanner := biz.NewBlockScanner(tronCli, trxRepo, addressRepo, balanceCache, eventBus, cfg, logger)
+	confirmationTracker := biz.NewConfirmationTracker(tronCli, trxRepo, eventBus, cfg, logger)
+	energyController := biz.NewEnergyController(trxUsecase, auditRepo, cfg, logger)
+	jobServer := server.NewJobServer(nodePool, solidityNodePool, blockScanner, confirmationTracker, webhookUsecase, energyController, payoutUsecase, sweepUsecase, multisigUsecase, logger)
+	mainApp, err := newApp(grpcServer, jobServer)
 	if err != nil {
 		return app{}, err
 	}